@@ -23,6 +23,11 @@ import (
 // @name Authorization
 // @description Используйте JWT токен с префиксом "Bearer ". Пример: "Bearer eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
 
+// @securityDefinitions.apikey APIKeyAuth
+// @in header
+// @name X-API-Key
+// @description API ключ партнера или внутреннего сервиса, выдается администратором. Открывает доступ на чтение согласно областям ключа
+
 func main() {
 	cfg, err := config.Load()
 	if err != nil {
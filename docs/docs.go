@@ -15,14 +15,34 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admin/benefit-types": {
-            "post": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "Возвращает открытые ключи для проверки подписи access токенов в формате JWKS (RFC 7517). Access токены содержат aud job_solition:access: токены с другой аудиторией (например, job_solition:challenge у промежуточного шага входа) нельзя принимать как access токены. Ответ не оборачивается в стандартный формат API",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Открытые ключи JWT",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JWKSet"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/api-keys": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Создает новый тип бенефита",
+                "description": "Возвращает все выданные API ключи, включая отозванные. Сами ключи не возвращаются, только их префиксы",
                 "consumes": [
                     "application/json"
                 ],
@@ -32,31 +52,14 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Создание типа бенефита",
-                "parameters": [
-                    {
-                        "description": "Данные типа бенефита",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.BenefitTypeInput"
-                        }
-                    }
-                ],
+                "summary": "Список API ключей",
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponseDTO"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -76,16 +79,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/benefit-types/{id}": {
-            "put": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Обновляет существующий тип бенефита",
+                "description": "Создает API ключ с указанными областями доступа (companies:read, reviews:read), суточной квотой (0 - без ограничения) и сроком действия. Ключ возвращается только в этом ответе",
                 "consumes": [
                     "application/json"
                 ],
@@ -95,28 +96,21 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Обновление типа бенефита",
+                "summary": "Выдача API ключа",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID типа бенефита",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Данные типа бенефита",
+                        "description": "Параметры ключа",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.BenefitTypeInput"
+                            "$ref": "#/definitions/models.APIKeyCreateInput"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
@@ -139,12 +133,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponseDTO"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -152,14 +140,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/api-keys/{id}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Удаляет тип бенефита по ID",
+                "description": "Отзывает API ключ, после чего запросы с ним отклоняются",
                 "consumes": [
                     "application/json"
                 ],
@@ -169,11 +159,11 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Удаление типа бенефита",
+                "summary": "Отзыв API ключа",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID типа бенефита",
+                        "description": "ID ключа",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -219,14 +209,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/cities": {
+        "/admin/benefit-types": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Создает новый город",
+                "description": "Создает новый тип бенефита",
                 "consumes": [
                     "application/json"
                 ],
@@ -236,15 +226,15 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Создание города",
+                "summary": "Создание типа бенефита",
                 "parameters": [
                     {
-                        "description": "Данные города",
+                        "description": "Данные типа бенефита",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CityInput"
+                            "$ref": "#/definitions/models.BenefitTypeInput"
                         }
                     }
                 ],
@@ -282,14 +272,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/cities/{id}": {
+        "/admin/benefit-types/{id}": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Обновляет существующий город",
+                "description": "Обновляет существующий тип бенефита",
                 "consumes": [
                     "application/json"
                 ],
@@ -299,22 +289,22 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Обновление города",
+                "summary": "Обновление типа бенефита",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID города",
+                        "description": "ID типа бенефита",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Данные города",
+                        "description": "Данные типа бенефита",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CityInput"
+                            "$ref": "#/definitions/models.BenefitTypeInput"
                         }
                     }
                 ],
@@ -363,7 +353,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Удаляет город по ID",
+                "description": "Удаляет тип бенефита по ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -373,11 +363,11 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Удаление города",
+                "summary": "Удаление типа бенефита",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID города",
+                        "description": "ID типа бенефита",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -423,14 +413,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/companies": {
+        "/admin/cities": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Создает новую компанию (только для администратора)",
+                "description": "Создает новый город",
                 "consumes": [
                     "application/json"
                 ],
@@ -440,15 +430,15 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Создание компании",
+                "summary": "Создание города",
                 "parameters": [
                     {
-                        "description": "Данные компании (name, size, city_id, industries обязательны)",
+                        "description": "Данные города",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CompanyInput"
+                            "$ref": "#/definitions/models.CityInput"
                         }
                     }
                 ],
@@ -471,6 +461,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -480,14 +476,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/companies/{id}": {
+        "/admin/cities/{id}": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Обновляет информацию о компании (только для администратора)",
+                "description": "Обновляет существующий город",
                 "consumes": [
                     "application/json"
                 ],
@@ -497,22 +493,22 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Обновление компании",
+                "summary": "Обновление города",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID компании",
+                        "description": "ID города",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Данные для обновления",
+                        "description": "Данные города",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CompanyUpdateInput"
+                            "$ref": "#/definitions/models.CityInput"
                         }
                     }
                 ],
@@ -561,7 +557,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Удаляет компанию по ID (только для администратора)",
+                "description": "Удаляет город по ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -571,11 +567,11 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Удаление компании",
+                "summary": "Удаление города",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID компании",
+                        "description": "ID города",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -621,14 +617,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/employment-periods": {
+        "/admin/companies": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Создает новый период работы",
+                "description": "Создает новую компанию (только для администратора)",
                 "consumes": [
                     "application/json"
                 ],
@@ -638,15 +634,15 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Создание периода работы",
+                "summary": "Создание компании",
                 "parameters": [
                     {
-                        "description": "Данные периода работы",
+                        "description": "Данные компании (name, size, city_id, industries обязательны)",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.EmploymentPeriodInput"
+                            "$ref": "#/definitions/models.CompanyInput"
                         }
                     }
                 ],
@@ -669,12 +665,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponseDTO"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -684,14 +674,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/employment-periods/{id}": {
+        "/admin/companies/{id}": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Обновляет существующий период работы",
+                "description": "Обновляет информацию о компании (только для администратора)",
                 "consumes": [
                     "application/json"
                 ],
@@ -701,22 +691,22 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Обновление периода работы",
+                "summary": "Обновление компании",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID периода работы",
+                        "description": "ID компании",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Данные периода работы",
+                        "description": "Данные для обновления",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.EmploymentPeriodInput"
+                            "$ref": "#/definitions/models.CompanyUpdateInput"
                         }
                     }
                 ],
@@ -765,7 +755,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Удаляет период работы по ID",
+                "description": "Удаляет компанию по ID (только для администратора)",
                 "consumes": [
                     "application/json"
                 ],
@@ -775,11 +765,11 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Удаление периода работы",
+                "summary": "Удаление компании",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID периода работы",
+                        "description": "ID компании",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -825,14 +815,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/employment-types": {
-            "post": {
+        "/admin/companies/{id}/representatives": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Создает новый тип занятости",
+                "description": "Возвращает заявки и представителей компании во всех статусах",
                 "consumes": [
                     "application/json"
                 ],
@@ -842,21 +832,19 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Создание типа занятости",
+                "summary": "Представители компании",
                 "parameters": [
                     {
-                        "description": "Данные типа занятости",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.EmploymentTypeInput"
-                        }
+                        "type": "integer",
+                        "description": "ID компании",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
@@ -879,6 +867,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -886,16 +880,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/employment-types/{id}": {
-            "put": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Обновляет существующий тип занятости",
+                "description": "Делает пользователя подтвержденным представителем компании без заявки и подтверждения корпоративного email. Если права не переданы, выдаются все",
                 "consumes": [
                     "application/json"
                 ],
@@ -905,28 +897,28 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Обновление типа занятости",
+                "summary": "Назначение представителя компании",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID типа занятости",
+                        "description": "ID компании",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Данные типа занятости",
+                        "description": "Пользователь",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.EmploymentTypeInput"
+                            "$ref": "#/definitions/models.CompanyRepresentativeInput"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
@@ -962,14 +954,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/companies/{id}/representatives/{userId}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Удаляет тип занятости по ID",
+                "description": "Лишает пользователя статуса представителя компании. Опубликованные им ответы на отзывы сохраняются",
                 "consumes": [
                     "application/json"
                 ],
@@ -979,14 +973,21 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Удаление типа занятости",
+                "summary": "Отзыв полномочий представителя компании",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID типа занятости",
+                        "description": "ID компании",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1029,14 +1030,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/industries": {
+        "/admin/employment-periods": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Создает новую индустрию",
+                "description": "Создает новый период работы",
                 "consumes": [
                     "application/json"
                 ],
@@ -1046,15 +1047,15 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Создание индустрии",
+                "summary": "Создание периода работы",
                 "parameters": [
                     {
-                        "description": "Данные индустрии",
+                        "description": "Данные периода работы",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.IndustryInput"
+                            "$ref": "#/definitions/models.EmploymentPeriodInput"
                         }
                     }
                 ],
@@ -1092,14 +1093,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/industries/{id}": {
+        "/admin/employment-periods/{id}": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Обновляет существующую индустрию",
+                "description": "Обновляет существующий период работы",
                 "consumes": [
                     "application/json"
                 ],
@@ -1109,22 +1110,22 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Обновление индустрии",
+                "summary": "Обновление периода работы",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID индустрии",
+                        "description": "ID периода работы",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Данные индустрии",
+                        "description": "Данные периода работы",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.IndustryInput"
+                            "$ref": "#/definitions/models.EmploymentPeriodInput"
                         }
                     }
                 ],
@@ -1173,7 +1174,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Удаляет индустрию по ID",
+                "description": "Удаляет период работы по ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1183,11 +1184,11 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Удаление индустрии",
+                "summary": "Удаление периода работы",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID индустрии",
+                        "description": "ID периода работы",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1233,14 +1234,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/rating-categories": {
+        "/admin/employment-types": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Создает новую категорию рейтинга",
+                "description": "Создает новый тип занятости",
                 "consumes": [
                     "application/json"
                 ],
@@ -1250,15 +1251,15 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Создание категории рейтинга",
+                "summary": "Создание типа занятости",
                 "parameters": [
                     {
-                        "description": "Данные категории",
+                        "description": "Данные типа занятости",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.RatingCategoryInput"
+                            "$ref": "#/definitions/models.EmploymentTypeInput"
                         }
                     }
                 ],
@@ -1296,14 +1297,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/rating-categories/{id}": {
+        "/admin/employment-types/{id}": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Обновляет существующую категорию рейтинга",
+                "description": "Обновляет существующий тип занятости",
                 "consumes": [
                     "application/json"
                 ],
@@ -1313,22 +1314,22 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Обновление категории рейтинга",
+                "summary": "Обновление типа занятости",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID категории",
+                        "description": "ID типа занятости",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Данные категории",
+                        "description": "Данные типа занятости",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.RatingCategoryInput"
+                            "$ref": "#/definitions/models.EmploymentTypeInput"
                         }
                     }
                 ],
@@ -1377,7 +1378,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Удаляет категорию рейтинга по ID",
+                "description": "Удаляет тип занятости по ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1387,11 +1388,11 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Удаление категории рейтинга",
+                "summary": "Удаление типа занятости",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID категории",
+                        "description": "ID типа занятости",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1437,14 +1438,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/reviews/moderation/approved": {
-            "get": {
+        "/admin/industries": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Возвращает список одобренных отзывов",
+                "description": "Создает новую индустрию",
                 "consumes": [
                     "application/json"
                 ],
@@ -1454,28 +1455,31 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Одобренные отзывы",
+                "summary": "Создание индустрии",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Количество записей на странице",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Данные индустрии",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.IndustryInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -1497,14 +1501,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/reviews/moderation/pending": {
-            "get": {
+        "/admin/industries/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Возвращает список отзывов, ожидающих модерации",
+                "description": "Обновляет существующую индустрию",
                 "consumes": [
                     "application/json"
                 ],
@@ -1514,19 +1518,23 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Отзывы на модерации",
+                "summary": "Обновление индустрии",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
+                        "description": "ID индустрии",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Количество записей на странице",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Данные индустрии",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.IndustryInput"
+                        }
                     }
                 ],
                 "responses": {
@@ -1536,6 +1544,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -1548,6 +1562,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1555,16 +1575,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/reviews/moderation/rejected": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Возвращает список отклоненных отзывов",
+                "description": "Удаляет индустрию по ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1574,19 +1592,14 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Отклоненные отзывы",
+                "summary": "Удаление индустрии",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Количество записей на странице",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "ID индустрии",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1596,6 +1609,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -1608,6 +1627,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1617,14 +1642,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/reviews/{id}": {
-            "put": {
+        "/admin/rating-categories": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Обновляет отзыв по ID (для администратора)",
+                "description": "Создает новую категорию рейтинга",
                 "consumes": [
                     "application/json"
                 ],
@@ -1634,28 +1659,21 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Обновление отзыва",
+                "summary": "Создание категории рейтинга",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID отзыва",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Данные для обновления",
+                        "description": "Данные категории",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.AdminReviewUpdateInput"
+                            "$ref": "#/definitions/models.RatingCategoryInput"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
@@ -1678,12 +1696,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponseDTO"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1691,14 +1703,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/rating-categories/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Удаляет отзыв по ID (для администратора)",
+                "description": "Обновляет существующую категорию рейтинга",
                 "consumes": [
                     "application/json"
                 ],
@@ -1708,14 +1722,23 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Удаление отзыва",
+                "summary": "Обновление категории рейтинга",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID отзыва",
+                        "description": "ID категории",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Данные категории",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RatingCategoryInput"
+                        }
                     }
                 ],
                 "responses": {
@@ -1756,16 +1779,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/reviews/{id}/approve": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Одобряет отзыв, прошедший модерацию",
+                "description": "Удаляет категорию рейтинга по ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1775,23 +1796,14 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Одобрение отзыва",
+                "summary": "Удаление категории рейтинга",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID отзыва",
+                        "description": "ID категории",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Данные модерации",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ReviewModerationInput"
-                        }
                     }
                 ],
                 "responses": {
@@ -1834,14 +1846,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/reviews/{id}/reject": {
-            "put": {
+        "/admin/representatives": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Отклоняет отзыв с указанием причины",
+                "description": "Возвращает заявки на представительство компаний. По умолчанию - ожидающие решения администратора",
                 "consumes": [
                     "application/json"
                 ],
@@ -1851,23 +1863,38 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Отклонение отзыва",
+                "summary": "Заявки на представительство компаний",
                 "parameters": [
+                    {
+                        "enum": [
+                            "unconfirmed",
+                            "pending",
+                            "verified",
+                            "rejected",
+                            "revoked"
+                        ],
+                        "type": "string",
+                        "description": "Статус заявки",
+                        "name": "status",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "ID отзыва",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "ID компании",
+                        "name": "company_id",
+                        "in": "query"
                     },
                     {
-                        "description": "Данные модерации",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ReviewModerationInput"
-                        }
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей на странице",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1895,12 +1922,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponseDTO"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1910,14 +1931,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/statistics": {
-            "get": {
+        "/admin/representatives/{claimId}/approve": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Возвращает общую статистику по сайту: количество пользователей, компаний, отзывов и т.д.",
+                "description": "Одобряет заявку с подтвержденным корпоративным email. Если права не переданы, представитель получает все права в рамках компании",
                 "consumes": [
                     "application/json"
                 ],
@@ -1927,12 +1948,35 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Получение статистики",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ResponseDTO"
+                "summary": "Одобрение заявки на представительство",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID заявки",
+                        "name": "claimId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Права и комментарий",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.CompanyClaimModerationInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
                     "401": {
@@ -1947,6 +1991,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1956,14 +2006,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/users": {
-            "get": {
+        "/admin/representatives/{claimId}/reject": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Возвращает список пользователей с пагинацией",
+                "description": "Отклоняет заявку на представительство компании. Пользователь может подать новую заявку",
                 "consumes": [
                     "application/json"
                 ],
@@ -1973,19 +2023,22 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Получение списка пользователей",
+                "summary": "Отклонение заявки на представительство",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
+                        "description": "ID заявки",
+                        "name": "claimId",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Количество записей на странице",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Причина отклонения",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.CompanyClaimModerationInput"
+                        }
                     }
                 ],
                 "responses": {
@@ -1995,6 +2048,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -2007,6 +2066,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2016,14 +2081,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/users/{id}": {
+        "/admin/reviews/moderation/approved": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Возвращает информацию о пользователе по ID",
+                "description": "Возвращает список одобренных отзывов",
                 "consumes": [
                     "application/json"
                 ],
@@ -2033,14 +2098,19 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Получение пользователя",
+                "summary": "Одобренные отзывы",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID пользователя",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей на странице",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2050,12 +2120,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponseDTO"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -2068,12 +2132,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponseDTO"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2081,14 +2139,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/reviews/moderation/flags": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Удаляет пользователя по ID",
+                "description": "Возвращает отзывы с открытыми жалобами читателей: сначала с наибольшим числом жалоб. Для каждого отзыва приводятся жалобы и их количество по причинам",
                 "consumes": [
                     "application/json"
                 ],
@@ -2098,14 +2158,19 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Удаление пользователя",
+                "summary": "Отзывы с жалобами",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID пользователя",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей на странице",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2115,12 +2180,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponseDTO"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -2133,12 +2192,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponseDTO"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2148,14 +2201,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/users/{id}/role": {
-            "put": {
+        "/admin/reviews/moderation/pending": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Обновляет роль пользователя (admin, moderator, user)",
+                "description": "Возвращает список отзывов, ожидающих модерации. В moderation_signals передается результат автоматической предмодерации (решение, балл и сработавшие правила), найденные в тексте персональные данные, а для отзывов, помеченных подозрительными, - отзывы с почти совпадающим текстом",
                 "consumes": [
                     "application/json"
                 ],
@@ -2165,23 +2218,19 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Обновление роли пользователя",
+                "summary": "Отзывы на модерации",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID пользователя",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
-                        "description": "Новая роль пользователя",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UserRoleUpdateInput"
-                        }
+                        "type": "integer",
+                        "description": "Количество записей на странице",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2191,12 +2240,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponseDTO"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -2209,12 +2252,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponseDTO"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2224,9 +2261,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/forgot-password": {
-            "post": {
-                "description": "Позволяет пользователю сбросить пароль, указав email и новый пароль",
+        "/admin/reviews/moderation/rejected": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает список отклоненных отзывов",
                 "consumes": [
                     "application/json"
                 ],
@@ -2234,18 +2276,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Восстановление пароля",
+                "summary": "Отклоненные отзывы",
                 "parameters": [
                     {
-                        "description": "Email пользователя и новый пароль",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ForgotPasswordInput"
-                        }
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей на странице",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2255,14 +2300,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
@@ -2276,9 +2321,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
-            "post": {
-                "description": "Аутентифицирует пользователя и выдает токены",
+        "/admin/reviews/moderation/responses": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает версии ответов компаний на отзывы, ожидающие модерации",
                 "consumes": [
                     "application/json"
                 ],
@@ -2286,18 +2336,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Вход в систему",
+                "summary": "Ответы компаний на модерации",
                 "parameters": [
                     {
-                        "description": "Данные для входа",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UserLoginInput"
-                        }
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей на странице",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2307,14 +2360,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
@@ -2328,9 +2381,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/logout": {
-            "post": {
-                "description": "Выход из системы, удаление refresh токена",
+        "/admin/reviews/moderation/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает правки одобренных отзывов, ожидающие модерации, с изменениями относительно опубликованной версии",
                 "consumes": [
                     "application/json"
                 ],
@@ -2338,32 +2396,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Выход из системы",
+                "summary": "Правки отзывов на модерации",
                 "parameters": [
                     {
-                        "description": "Refresh токен",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                ],
-                "responses": {
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей на странице",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
@@ -2377,9 +2441,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/refresh": {
-            "post": {
-                "description": "Обновляет пару access/refresh токенов",
+        "/admin/reviews/responses/{responseId}/approve": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Публикует ожидающую модерации версию ответа компании на отзыв",
                 "consumes": [
                     "application/json"
                 ],
@@ -2387,20 +2456,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Обновление токенов",
+                "summary": "Одобрение ответа компании",
                 "parameters": [
                     {
-                        "description": "Refresh токен",
+                        "type": "integer",
+                        "description": "ID ответа",
+                        "name": "responseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Комментарий модератора",
                         "name": "input",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.ReviewResponseModerationInput"
                         }
                     }
                 ],
@@ -2423,6 +2495,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2432,9 +2516,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
-            "post": {
-                "description": "Регистрирует нового пользователя",
+        "/admin/reviews/responses/{responseId}/reject": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отклоняет ожидающую модерации версию ответа компании на отзыв. Опубликованная ранее версия ответа сохраняется",
                 "consumes": [
                     "application/json"
                 ],
@@ -2442,23 +2531,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Регистрация нового пользователя",
+                "summary": "Отклонение ответа компании",
                 "parameters": [
                     {
-                        "description": "Данные для регистрации",
+                        "type": "integer",
+                        "description": "ID ответа",
+                        "name": "responseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Причина отклонения",
                         "name": "input",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UserRegisterInput"
+                            "$ref": "#/definitions/models.ReviewResponseModerationInput"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
@@ -2469,39 +2564,22 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
-                    }
-                }
-            }
-        },
-        "/benefit-types": {
-            "get": {
-                "description": "Возвращает список всех доступных типов бенефитов",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "benefit-types"
-                ],
-                "summary": "Получение всех типов бенефитов",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/utils.ResponseDTO"
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
                     "500": {
@@ -2513,9 +2591,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/benefit-types/{id}": {
-            "get": {
-                "description": "Возвращает информацию о типе бенефита по его ID",
+        "/admin/reviews/revisions/{revisionId}/approve": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Публикует правку одобренного отзыва и пересчитывает рейтинг компании",
                 "consumes": [
                     "application/json"
                 ],
@@ -2523,16 +2606,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "benefit-types"
+                    "admin"
                 ],
-                "summary": "Получение типа бенефита по ID",
+                "summary": "Одобрение правки отзыва",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID типа бенефита",
-                        "name": "id",
+                        "description": "ID правки",
+                        "name": "revisionId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Комментарий модератора",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewRevisionModerationInput"
+                        }
                     }
                 ],
                 "responses": {
@@ -2548,6 +2639,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -2563,9 +2666,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/cities": {
-            "get": {
-                "description": "Возвращает список городов с возможностью фильтрации и пагинации",
+        "/admin/reviews/revisions/{revisionId}/reject": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отклоняет правку одобренного отзыва. Опубликованная версия отзыва не меняется",
                 "consumes": [
                     "application/json"
                 ],
@@ -2573,45 +2681,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "cities"
+                    "admin"
                 ],
-                "summary": "Список городов",
+                "summary": "Отклонение правки отзыва",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Поисковый запрос",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Фильтр по стране",
-                        "name": "country",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Поле для сортировки (name, region)",
-                        "name": "sort_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Порядок сортировки (asc, desc)",
-                        "name": "sort_order",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
+                        "description": "ID правки",
+                        "name": "revisionId",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Количество записей на странице",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Причина отклонения",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewRevisionModerationInput"
+                        }
                     }
                 ],
                 "responses": {
@@ -2627,6 +2714,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2636,9 +2741,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/cities/search": {
-            "get": {
-                "description": "Ищет города по названию или региону для автодополнения",
+        "/admin/reviews/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновляет отзыв по ID (для администратора)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2646,22 +2756,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "cities"
+                    "admin"
                 ],
-                "summary": "Поиск городов",
+                "summary": "Обновление отзыва",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Поисковый запрос",
-                        "name": "query",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "ID отзыва",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Максимальное количество результатов (по умолчанию 20)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Данные для обновления",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AdminReviewUpdateInput"
+                        }
                     }
                 ],
                 "responses": {
@@ -2677,18 +2790,39 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
-                    }
-                }
-            }
-        },
-        "/companies": {
-            "get": {
-                "description": "Возвращает список компаний с возможностью фильтрации и пагинации",
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет отзыв по ID (для администратора)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2696,73 +2830,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "companies"
+                    "admin"
                 ],
-                "summary": "Список компаний",
+                "summary": "Удаление отзыва",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Поисковый запрос",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Фильтр по индустриям (может содержать несколько ID индустрий через запятую, например: industries=1,2,3)",
-                        "name": "industries",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "small",
-                            "medium",
-                            "large",
-                            "enterprise"
-                        ],
-                        "type": "string",
-                        "description": "Фильтр по размеру компании",
-                        "name": "size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Фильтр по названию города",
-                        "name": "city",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Фильтр по ID города",
-                        "name": "city_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Поле для сортировки (name, rating, reviews_count, created_at)",
-                        "name": "sort_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Порядок сортировки (asc, desc)",
-                        "name": "sort_order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
-                        "description": "Количество записей на странице",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2778,6 +2855,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2787,9 +2882,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/companies/{id_or_slug}": {
-            "get": {
-                "description": "Возвращает детальную информацию о компании по её ID или slug",
+        "/admin/reviews/{id}/approve": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Одобряет отзыв, прошедший модерацию",
                 "consumes": [
                     "application/json"
                 ],
@@ -2797,16 +2897,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "companies"
+                    "admin"
                 ],
-                "summary": "Информация о компании",
+                "summary": "Одобрение отзыва",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID или slug компании",
-                        "name": "id_or_slug",
+                        "type": "integer",
+                        "description": "ID отзыва",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Данные модерации",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewModerationInput"
+                        }
                     }
                 ],
                 "responses": {
@@ -2822,6 +2931,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -2837,9 +2958,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/employment-periods": {
-            "get": {
-                "description": "Возвращает список всех доступных периодов работы",
+        "/admin/reviews/{id}/flags/act": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Подтверждает жалобы на отзыв: отзыв отклоняется с комментарием модератора и снимается с публикации, рейтинг компании пересчитывается. Авторы жалоб получают уведомление",
                 "consumes": [
                     "application/json"
                 ],
@@ -2847,9 +2973,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "employment-periods"
+                    "admin"
+                ],
+                "summary": "Принятие мер по жалобам на отзыв",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Причина снятия отзыва",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewFlagResolutionInput"
+                        }
+                    }
                 ],
-                "summary": "Получение всех периодов работы",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2857,6 +3001,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2866,9 +3034,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/employment-periods/{id}": {
-            "get": {
-                "description": "Возвращает информацию о периоде работы по его ID",
+        "/admin/reviews/{id}/flags/dismiss": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Закрывает открытые жалобы на отзыв без мер: отзыв остается опубликованным и снова показывается, если был скрыт. Авторы жалоб получают уведомление",
                 "consumes": [
                     "application/json"
                 ],
@@ -2876,16 +3049,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "employment-periods"
+                    "admin"
                 ],
-                "summary": "Получение периода работы по ID",
+                "summary": "Отклонение жалоб на отзыв",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID периода работы",
+                        "description": "ID отзыва",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Комментарий модератора",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewFlagResolutionInput"
+                        }
                     }
                 ],
                 "responses": {
@@ -2901,6 +3082,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -2916,9 +3109,4268 @@ const docTemplate = `{
                 }
             }
         },
-        "/employment-types": {
+        "/admin/reviews/{id}/reject": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отклоняет отзыв с указанием причины",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Отклонение отзыва",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные модерации",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewModerationInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/statistics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает общую статистику по сайту: количество пользователей, компаний, отзывов и т.д.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Получение статистики",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает список пользователей с пагинацией",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Получение списка пользователей",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей на странице",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает информацию о пользователе по ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Получение пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет пользователя по ID. Одобренные отзывы пользователя остаются опубликованными от имени анонимного пользователя, остальные удаляются",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Удаление пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/export": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Запускает сборку архива с персональными данными указанного пользователя. Запрос фиксируется в журнале событий безопасности пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Выгрузка данных пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/exports": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает запросы на выгрузку данных указанного пользователя со ссылками на скачивание готовых архивов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Выгрузки данных пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/login-attempts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает журнал успешных и неудачных попыток входа пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Попытки входа пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей на странице",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновляет роль пользователя (admin, moderator, user). Выданные пользователю access токены сразу перестают действовать",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Обновление роли пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новая роль пользователя",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserRoleUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает активные сессии пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Сессии пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Завершает все сессии пользователя и отзывает его access токены, после чего ему потребуется войти заново",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Завершение всех сессий пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/sessions/{sessionId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Завершает одну из сессий пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Завершение сессии пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID сессии",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/unlock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Снимает блокировку входа в аккаунт пользователя после неудачных попыток. Неудачные попытки до этого момента больше не учитываются",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Разблокировка входа",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/2fa/enable": {
+            "post": {
+                "description": "Подтверждает подключение двухфакторной аутентификации кодом из приложения и завершает вход. Коды восстановления показываются один раз",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Подтверждение 2FA при входе",
+                "parameters": [
+                    {
+                        "description": "Challenge токен и код",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorLoginInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/2fa/setup": {
+            "post": {
+                "description": "Начинает обязательное подключение двухфакторной аутентификации для администраторов и модераторов. Возвращает секрет и otpauth ссылку",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Подключение 2FA при входе",
+                "parameters": [
+                    {
+                        "description": "Challenge токен из ответа /auth/login",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorChallengeInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/cancel-email-change": {
+            "post": {
+                "description": "Отменяет запрос на смену email по токену из уведомления, отправленного на прежний адрес",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Отмена смены email",
+                "parameters": [
+                    {
+                        "description": "Токен отмены",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.EmailChangeTokenInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/confirm-email-change": {
+            "post": {
+                "description": "Меняет email пользователя по токену из письма на новый адрес. Все сессии пользователя завершаются, выданные access токены перестают действовать",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Подтверждение смены email",
+                "parameters": [
+                    {
+                        "description": "Токен подтверждения",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.EmailChangeTokenInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/forgot-password": {
+            "post": {
+                "description": "Отправляет на email пользователя одноразовую ссылку для сброса пароля. Ответ не зависит от того, существует ли пользователь",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Запрос на восстановление пароля",
+                "parameters": [
+                    {
+                        "description": "Email пользователя",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ForgotPasswordInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Аутентифицирует пользователя и выдает токены. Если у пользователя включена двухфакторная аутентификация, вместо токенов возвращается challenge_token для /auth/login/2fa",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Вход в систему",
+                "parameters": [
+                    {
+                        "description": "Данные для входа",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserLoginInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login/2fa": {
+            "post": {
+                "description": "Завершает вход пользователя с включенной двухфакторной аутентификацией. Принимает код из приложения или код восстановления",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Второй шаг входа",
+                "parameters": [
+                    {
+                        "description": "Challenge токен и код",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorLoginInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "description": "Выход из системы, удаление refresh токена",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Выход из системы",
+                "parameters": [
+                    {
+                        "description": "Refresh токен",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/magic-link": {
+            "post": {
+                "description": "Отправляет на email пользователя одноразовую ссылку для входа без пароля. Ответ не зависит от того, существует ли пользователь",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Запрос ссылки для входа",
+                "parameters": [
+                    {
+                        "description": "Email пользователя",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MagicLinkRequestInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/magic-link/login": {
+            "post": {
+                "description": "Обменивает одноразовый токен из письма на пару токенов, как при обычном входе. Email пользователя считается подтвержденным. Если у пользователя включена двухфакторная аутентификация, вместо токенов возвращается challenge_token для /auth/login/2fa",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Вход по ссылке",
+                "parameters": [
+                    {
+                        "description": "Токен из письма",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MagicLinkLoginInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/providers": {
+            "get": {
+                "description": "Возвращает список настроенных внешних провайдеров входа",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Провайдеры входа",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/{provider}/authorize": {
+            "get": {
+                "description": "Перенаправляет пользователя на страницу входа провайдера. После входа провайдер вернет пользователя на фронтенд с параметрами code и state",
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Вход через внешнего провайдера",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя провайдера",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/{provider}/callback": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обменивает code от провайдера на данные пользователя. Находит привязанного пользователя, привязывает аккаунт по подтвержденному email или создает нового пользователя, после чего выдает токены как при обычном входе. Если вход начат привязкой аккаунта в профиле, запрос должен содержать access токен того же пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Завершение входа через внешнего провайдера",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя провайдера",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Code и state из адреса возврата",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OIDCCallbackInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Обновляет пару access/refresh токенов. Повторное использование уже замененного refresh токена отзывает все токены этого входа",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Обновление токенов",
+                "parameters": [
+                    {
+                        "description": "Refresh токен",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Регистрирует нового пользователя. Пароль проверяется по политике паролей, при нарушении в error.errors перечисляются все невыполненные правила",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Регистрация нового пользователя",
+                "parameters": [
+                    {
+                        "description": "Данные для регистрации",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserRegisterInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/resend-verification": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Повторно отправляет письмо для подтверждения email текущего пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Повторная отправка письма подтверждения",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/reset-password": {
+            "post": {
+                "description": "Устанавливает новый пароль по одноразовому токену из письма и завершает все активные сессии пользователя. Новый пароль проверяется по политике паролей и не должен совпадать с недавними",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Сброс пароля",
+                "parameters": [
+                    {
+                        "description": "Токен сброса и новый пароль",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ResetPasswordInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/verify-email": {
+            "post": {
+                "description": "Подтверждает email пользователя по токену из письма",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Подтверждение email",
+                "parameters": [
+                    {
+                        "description": "Токен подтверждения",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.VerifyEmailInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/benefit-types": {
+            "get": {
+                "description": "Возвращает список всех доступных типов бенефитов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "benefit-types"
+                ],
+                "summary": "Получение всех типов бенефитов",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/benefit-types/{id}": {
+            "get": {
+                "description": "Возвращает информацию о типе бенефита по его ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "benefit-types"
+                ],
+                "summary": "Получение типа бенефита по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID типа бенефита",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/cities": {
+            "get": {
+                "description": "Возвращает список городов с возможностью фильтрации и пагинации",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cities"
+                ],
+                "summary": "Список городов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Поисковый запрос",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по стране",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поле для сортировки (name, region)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Порядок сортировки (asc, desc)",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей на странице",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/cities/search": {
+            "get": {
+                "description": "Ищет города по названию или региону для автодополнения",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cities"
+                ],
+                "summary": "Поиск городов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Поисковый запрос",
+                        "name": "query",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимальное количество результатов (по умолчанию 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/companies": {
+            "get": {
+                "security": [
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает список компаний с возможностью фильтрации и пагинации",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "companies"
+                ],
+                "summary": "Список компаний",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Поисковый запрос",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Фильтр по индустриям (может содержать несколько ID индустрий через запятую, например: industries=1,2,3)",
+                        "name": "industries",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "small",
+                            "medium",
+                            "large",
+                            "enterprise"
+                        ],
+                        "type": "string",
+                        "description": "Фильтр по размеру компании",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по названию города",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Фильтр по ID города",
+                        "name": "city_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поле для сортировки (name, rating, reviews_count, created_at)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Порядок сортировки (asc, desc)",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей на странице",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/companies/{id_or_slug}": {
+            "get": {
+                "security": [
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает детальную информацию о компании по её ID или slug",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "companies"
+                ],
+                "summary": "Информация о компании",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID или slug компании",
+                        "name": "id_or_slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/companies/{id}/claims": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создает заявку пользователя на представительство компании. Корпоративный email должен принадлежать домену сайта компании, на него отправляется код подтверждения. После подтверждения кода заявку рассматривает администратор",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "companies"
+                ],
+                "summary": "Заявка на представительство компании",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID компании",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Корпоративный email и комментарий",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CompanyClaimInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/companies/{id}/claims/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Проверяет код, отправленный на корпоративный email, и передает заявку на рассмотрение администратору. После нескольких неверных попыток заявку нужно подать заново",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "companies"
+                ],
+                "summary": "Подтверждение корпоративного email заявки",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID компании",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Код подтверждения",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CompanyClaimConfirmInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/companies/{id}/profile": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Меняет логотип и контактные данные компании. Доступно подтвержденным представителям компании с правом company:update",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "companies"
+                ],
+                "summary": "Обновление профиля компании представителем",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID компании",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Изменяемые поля",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CompanyProfileUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/employment-periods": {
+            "get": {
+                "description": "Возвращает список всех доступных периодов работы",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "employment-periods"
+                ],
+                "summary": "Получение всех периодов работы",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/employment-periods/{id}": {
+            "get": {
+                "description": "Возвращает информацию о периоде работы по его ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "employment-periods"
+                ],
+                "summary": "Получение периода работы по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID периода работы",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/employment-types": {
+            "get": {
+                "description": "Возвращает список всех доступных типов занятости",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "employment-types"
+                ],
+                "summary": "Получение всех типов занятости",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/employment-types/{id}": {
+            "get": {
+                "description": "Возвращает информацию о типе занятости по его ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "employment-types"
+                ],
+                "summary": "Получение типа занятости по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID типа занятости",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/industries": {
+            "get": {
+                "description": "Возвращает список отраслей с возможностью фильтрации и пагинации",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "industries"
+                ],
+                "summary": "Список отраслей",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Поисковый запрос",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поле для сортировки (name)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Порядок сортировки (asc, desc)",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей на странице",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/industries/company/{id}": {
+            "get": {
+                "description": "Возвращает список отраслей указанной компании",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "industries"
+                ],
+                "summary": "Отрасли компании",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID компании",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/industries/{id}/color": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновляет цвет (hex) указанной индустрии",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "industries"
+                ],
+                "summary": "Обновление цвета индустрии",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID индустрии",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления цвета",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateColorInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/rating-categories": {
+            "get": {
+                "description": "Возвращает список всех доступных категорий рейтингов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rating-categories"
+                ],
+                "summary": "Получение всех категорий рейтингов",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/rating-categories/{id}": {
+            "get": {
+                "description": "Возвращает категорию рейтинга по её ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rating-categories"
+                ],
+                "summary": "Получение категории рейтинга по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID категории рейтинга",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создает новый отзыв о компании вместе с оценками по категориям и льготами в одной транзакции. Город, период работы, тип занятости, категории и льготы проверяются заранее, все ошибки возвращаются одним ответом. Повтор запроса с тем же заголовком Idempotency-Key возвращает исходный ответ и не создает новый отзыв. Если включена автоматическая предмодерация, отзыв может быть сразу опубликован или отклонен, иначе он отправляется модераторам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Создание отзыва",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности запроса",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Данные отзыва",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/company/{companyId}": {
+            "get": {
+                "security": [
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает список отзывов о компании. Телефоны, адреса почты, номера документов и имена людей в тексте отзывов скрываются",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Отзывы о компании",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID компании",
+                        "name": "companyId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поле для сортировки (rating, created_at)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Порядок сортировки (asc, desc)",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей на странице",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Фильтр по ID города",
+                        "name": "city_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Минимальный рейтинг (от 1 до 5)",
+                        "name": "min_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Максимальный рейтинг (от 1 до 5)",
+                        "name": "max_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Фильтр по статусу бывшего сотрудника (true/false)",
+                        "name": "is_former_employee",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает отзыв по его ID. Телефоны, адреса почты, номера документов и имена людей в тексте отзыва скрываются",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Получение отзыва",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Изменяет текст, оценки по категориям и льготы собственного отзыва. Каждая правка сохраняется в истории. Неодобренный отзыв меняется сразу, правка одобренного отзыва отправляется на модерацию, а до ее одобрения опубликованной остается прежняя версия. Если включена автоматическая предмодерация, отзыв и правка могут быть сразу одобрены или отклонены",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Редактирование отзыва автором",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Изменяемые поля",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewEditInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}/flags": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отправляет жалобу на опубликованный отзыв: клевета, персональные данные, спам, оскорбления, ложная информация или другое. От одного пользователя принимается одна жалоба на отзыв. При достижении порога жалоб отзыв скрывается до решения модератора и не учитывается в рейтинге компании",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Жалоба на отзыв",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Причина и комментарий",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewFlagInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}/response": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает последнюю версию ответа компании на отзыв вместе со статусом модерации. Доступно представителям компании с правом reviews:respond, модераторам и администраторам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Ответ компании на отзыв с состоянием модерации",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создает официальный ответ компании на одобренный отзыв или заменяет текст существующего. Доступно только подтвержденным представителям компании с правом reviews:respond. Каждая версия ответа проходит модерацию, до ее одобрения вместе с отзывом показывается прежняя опубликованная версия",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Ответ компании на отзыв",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Текст ответа",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewResponseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет ответ компании на отзыв, включая опубликованную версию. Доступно представителям компании, модераторам и администраторам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Удаление ответа компании на отзыв",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает правки отзыва с изменениями относительно опубликованной версии. Доступно автору отзыва, модераторам и администраторам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "История правок отзыва",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}/useful": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавляет отметку \"полезно\" для отзыва",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Отметить отзыв как полезный",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет отметку \"полезно\" с отзыва",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Убрать отметку \"полезно\"",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}/withdraw": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Снимает собственный отзыв с публикации. Отзыв перестает отображаться публично, рейтинг компании пересчитывается, а ожидающие модерации правки отменяются",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Отзыв отзыва автором",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/suggestions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает список предложений с фильтрацией по типу и сортировкой по дате",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suggestions"
+                ],
+                "summary": "Получить все предложения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Тип предложения (company/suggestion)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Порядок сортировки (asc/desc)",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество элементов на странице",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создает новое предложение компании или улучшение. Если пользователь авторизован, предложение привязывается к нему",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suggestions"
+                ],
+                "summary": "Создать новое предложение",
+                "parameters": [
+                    {
+                        "description": "Информация о предложении",
+                        "name": "suggestion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SuggestionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/suggestions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет предложение по ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suggestions"
+                ],
+                "summary": "Удалить предложение",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID предложения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/exports/{token}": {
+            "get": {
+                "description": "Отдает zip-архив с данными пользователя по токену из ссылки на скачивание",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Скачивание выгрузки данных",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Токен ссылки на скачивание",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает профиль текущего пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Профиль пользователя",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновляет профиль текущего пользователя. При смене пароля остальные сессии завершаются, а выданные access токены перестают действовать: текущей сессии нужно получить новый токен через /auth/refresh",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Обновление профиля",
+                "parameters": [
+                    {
+                        "description": "Данные для обновления",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Назначает удаление аккаунта текущего пользователя после льготного периода, в течение которого удаление можно отменить. После удаления персональные данные стираются, а одобренные отзывы остаются опубликованными от имени анонимного пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Удаление аккаунта",
+                "parameters": [
+                    {
+                        "description": "Текущий пароль",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AccountDeletionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/2fa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отключает двухфакторную аутентификацию. Требует пароль и код из приложения или код восстановления",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Отключение 2FA",
+                "parameters": [
+                    {
+                        "description": "Пароль и код",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorDisableInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/2fa/enable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Подтверждает подключение двухфакторной аутентификации кодом из приложения. Коды восстановления показываются один раз",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Включение 2FA",
+                "parameters": [
+                    {
+                        "description": "Код из приложения",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorCodeInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/2fa/recovery-codes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создает новый набор кодов восстановления взамен прежнего. Требует код из приложения",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Новые коды восстановления",
+                "parameters": [
+                    {
+                        "description": "Код из приложения",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorCodeInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/2fa/setup": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создает секрет TOTP для текущего пользователя и возвращает его вместе с otpauth ссылкой для приложения-аутентификатора",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Начало подключения 2FA",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/companies": {
             "get": {
-                "description": "Возвращает список всех доступных типов занятости",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает заявки текущего пользователя на представительство компаний с их статусами и выданными правами",
                 "consumes": [
                     "application/json"
                 ],
@@ -2926,9 +7378,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "employment-types"
+                    "users"
                 ],
-                "summary": "Получение всех типов занятости",
+                "summary": "Мои заявки на представительство компаний",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2936,6 +7388,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2945,9 +7403,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/employment-types/{id}": {
+        "/users/me/drafts": {
             "get": {
-                "description": "Возвращает информацию о типе занятости по его ID",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает черновики отзывов текущего пользователя, начиная с последних измененных",
                 "consumes": [
                     "application/json"
                 ],
@@ -2955,18 +7418,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "employment-types"
-                ],
-                "summary": "Получение типа занятости по ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID типа занятости",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "users"
                 ],
+                "summary": "Мои черновики отзывов",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2974,14 +7428,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponseDTO"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
@@ -2993,11 +7441,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/industries": {
-            "get": {
-                "description": "Возвращает список отраслей с возможностью фильтрации и пагинации",
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сохраняет частично заполненную форму отзыва. Все поля необязательны, полная проверка выполняется при отправке черновика. Черновик удаляется автоматически, если долго не меняется",
                 "consumes": [
                     "application/json"
                 ],
@@ -3005,44 +7456,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "industries"
+                    "users"
                 ],
-                "summary": "Список отраслей",
+                "summary": "Создание черновика отзыва",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Поисковый запрос",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Поле для сортировки (name)",
-                        "name": "sort_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Порядок сортировки (asc, desc)",
-                        "name": "sort_order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Количество записей на странице",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Данные черновика",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewDraftInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
@@ -3053,6 +7483,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3062,9 +7498,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/industries/company/{id}": {
+        "/users/me/drafts/{id}": {
             "get": {
-                "description": "Возвращает список отраслей указанной компании",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает черновик отзыва текущего пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -3072,13 +7513,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "industries"
+                    "users"
                 ],
-                "summary": "Отрасли компании",
+                "summary": "Черновик отзыва",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID компании",
+                        "description": "ID черновика",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3097,6 +7538,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -3110,16 +7557,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/industries/{id}/color": {
+            },
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Обновляет цвет (hex) указанной индустрии",
+                "description": "Заменяет содержимое черновика отзыва текущим состоянием формы",
                 "consumes": [
                     "application/json"
                 ],
@@ -3127,24 +7572,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "industries"
+                    "users"
                 ],
-                "summary": "Обновление цвета индустрии",
+                "summary": "Автосохранение черновика отзыва",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID индустрии",
+                        "description": "ID черновика",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Данные для обновления цвета",
+                        "description": "Данные черновика",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.UpdateColorInput"
+                            "$ref": "#/definitions/models.ReviewDraftInput"
                         }
                     }
                 ],
@@ -3167,12 +7612,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponseDTO"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -3186,11 +7625,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/rating-categories": {
-            "get": {
-                "description": "Возвращает список всех доступных категорий рейтингов",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет черновик отзыва текущего пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -3198,9 +7640,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "rating-categories"
+                    "users"
+                ],
+                "summary": "Удаление черновика отзыва",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID черновика",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Получение всех категорий рейтингов",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3208,6 +7659,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3217,9 +7686,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/rating-categories/{id}": {
-            "get": {
-                "description": "Возвращает категорию рейтинга по её ID",
+        "/users/me/drafts/{id}/submit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создает отзыв из черновика так же, как при создании отзыва: черновик проходит полную проверку, а после успешной отправки удаляется. Повтор запроса с тем же заголовком Idempotency-Key возвращает исходный ответ",
                 "consumes": [
                     "application/json"
                 ],
@@ -3227,21 +7701,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "rating-categories"
+                    "users"
                 ],
-                "summary": "Получение категории рейтинга по ID",
+                "summary": "Отправка черновика отзыва",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности запроса",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
                     {
                         "type": "integer",
-                        "description": "ID категории рейтинга",
+                        "description": "ID черновика",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
@@ -3252,12 +7732,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3267,14 +7765,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/reviews": {
+        "/users/me/email": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Создает новый отзыв о компании",
+                "description": "Отправляет ссылку подтверждения на новый адрес и уведомление со ссылкой отмены на текущий. Email меняется только после подтверждения с нового адреса",
                 "consumes": [
                     "application/json"
                 ],
@@ -3282,41 +7780,87 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reviews"
+                    "users"
                 ],
-                "summary": "Создание отзыва",
+                "summary": "Запрос на смену email",
                 "parameters": [
                     {
-                        "description": "Данные отзыва",
+                        "description": "Новый email и текущий пароль",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ReviewInput"
+                            "$ref": "#/definitions/models.EmailChangeInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     }
+                }
+            }
+        },
+        "/users/me/export": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Запускает сборку архива со всеми персональными данными текущего пользователя: профиль, отзывы с оценками по категориям и льготами, отметки полезности, предложения, сессии и история входов. Каждый раздел выгружается в JSON и CSV. Статус и ссылка на скачивание доступны в /users/me/exports",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
                 ],
+                "summary": "Запрос выгрузки данных",
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponseDTO"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
@@ -3330,9 +7874,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/reviews/company/{companyId}": {
+        "/users/me/exports": {
             "get": {
-                "description": "Возвращает список отзывов о компании",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает запросы на выгрузку данных текущего пользователя. У готовых архивов указана ссылка на скачивание, которая действует ограниченное время",
                 "consumes": [
                     "application/json"
                 ],
@@ -3340,66 +7889,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reviews"
-                ],
-                "summary": "Отзывы о компании",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID компании",
-                        "name": "companyId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Поле для сортировки (rating, created_at)",
-                        "name": "sort_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Порядок сортировки (asc, desc)",
-                        "name": "sort_order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Количество записей на странице",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Фильтр по ID города",
-                        "name": "city_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "number",
-                        "description": "Минимальный рейтинг (от 1 до 5)",
-                        "name": "min_rating",
-                        "in": "query"
-                    },
-                    {
-                        "type": "number",
-                        "description": "Максимальный рейтинг (от 1 до 5)",
-                        "name": "max_rating",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Фильтр по статусу бывшего сотрудника (true/false)",
-                        "name": "is_former_employee",
-                        "in": "query"
-                    }
+                    "users"
                 ],
+                "summary": "Выгрузки данных пользователя",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3407,14 +7899,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponseDTO"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
@@ -3428,9 +7914,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/reviews/{id}": {
+        "/users/me/identities": {
             "get": {
-                "description": "Возвращает отзыв по его ID",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает внешние провайдеры входа, привязанные к текущему пользователю",
                 "consumes": [
                     "application/json"
                 ],
@@ -3438,18 +7929,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reviews"
-                ],
-                "summary": "Получение отзыва",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID отзыва",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "users"
                 ],
+                "summary": "Привязанные внешние аккаунты",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3457,14 +7939,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponseDTO"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
@@ -3478,14 +7954,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/reviews/{id}/useful": {
+        "/users/me/identities/{provider}": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Добавляет отметку \"полезно\" для отзыва",
+                "description": "Возвращает адрес страницы входа провайдера. После возврата фронтенд передает code и state в /auth/oidc/{provider}/callback вместе с access токеном, и аккаунт привязывается к текущему пользователю",
                 "consumes": [
                     "application/json"
                 ],
@@ -3493,14 +7969,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reviews"
+                    "users"
                 ],
-                "summary": "Отметить отзыв как полезный",
+                "summary": "Привязка внешнего аккаунта",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID отзыва",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Имя провайдера",
+                        "name": "provider",
                         "in": "path",
                         "required": true
                     }
@@ -3512,12 +7988,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponseDTO"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -3544,7 +8014,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Удаляет отметку \"полезно\" с отзыва",
+                "description": "Отвязывает провайдера входа от текущего пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -3552,14 +8022,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reviews"
+                    "users"
                 ],
-                "summary": "Убрать отметку \"полезно\"",
+                "summary": "Отвязка внешнего аккаунта",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID отзыва",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Имя провайдера",
+                        "name": "provider",
                         "in": "path",
                         "required": true
                     }
@@ -3571,12 +8041,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponseDTO"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -3598,14 +8062,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/suggestions": {
-            "get": {
+        "/users/me/restore": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Возвращает список предложений с фильтрацией по типу и сортировкой по дате",
+                "description": "Отменяет назначенное удаление аккаунта текущего пользователя, если льготный период еще не истек",
                 "consumes": [
                     "application/json"
                 ],
@@ -3613,41 +8077,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "suggestions"
-                ],
-                "summary": "Получить все предложения",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Тип предложения (company/suggestion)",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Порядок сортировки (asc/desc)",
-                        "name": "sort_order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Количество элементов на странице",
-                        "name": "limit",
-                        "in": "query"
-                    }
+                    "users"
                 ],
+                "summary": "Отмена удаления аккаунта",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
                     "400": {
@@ -3656,47 +8093,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/utils.ErrorResponseDTO"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Создает новое предложение компании или улучшение",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "suggestions"
-                ],
-                "summary": "Создать новое предложение",
-                "parameters": [
-                    {
-                        "description": "Информация о предложении",
-                        "name": "suggestion",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.SuggestionInput"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
@@ -3710,14 +8108,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/suggestions/{id}": {
-            "delete": {
+        "/users/me/reviews": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Удаляет предложение по ID",
+                "description": "Возвращает отзывы текущего пользователя с состоянием модерации: статусом, комментарием модератора и последней правкой, если она ожидает модерации или отклонена. В counts - количество отзывов по статусам",
                 "consumes": [
                     "application/json"
                 ],
@@ -3725,24 +8123,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "suggestions"
+                    "users"
                 ],
-                "summary": "Удалить предложение",
+                "summary": "Отзывы пользователя",
                 "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Статусы отзывов (pending, approved, rejected, withdrawn), несколько значений или через запятую",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID компании",
+                        "name": "company_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поле для сортировки (rating, created_at, useful_count)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Порядок сортировки (asc, desc)",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "ID предложения",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей на странице",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
                     "400": {
@@ -3751,8 +8181,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
@@ -3766,14 +8196,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me": {
+        "/users/me/sessions": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Возвращает профиль текущего пользователя",
+                "description": "Возвращает список устройств и браузеров, с которых выполнен вход в аккаунт",
                 "consumes": [
                     "application/json"
                 ],
@@ -3783,7 +8213,7 @@ const docTemplate = `{
                 "tags": [
                     "users"
                 ],
-                "summary": "Профиль пользователя",
+                "summary": "Активные сессии",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3805,13 +8235,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Обновляет профиль текущего пользователя",
+                "description": "Завершает все сессии текущего пользователя, кроме той, из которой выполнен запрос",
                 "consumes": [
                     "application/json"
                 ],
@@ -3821,18 +8251,7 @@ const docTemplate = `{
                 "tags": [
                     "users"
                 ],
-                "summary": "Обновление профиля",
-                "parameters": [
-                    {
-                        "description": "Данные для обновления",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UserUpdateInput"
-                        }
-                    }
-                ],
+                "summary": "Завершение остальных сессий",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3861,14 +8280,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me/reviews": {
-            "get": {
+        "/users/me/sessions/{sessionId}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Возвращает отзывы текущего пользователя",
+                "description": "Завершает одну из сессий текущего пользователя. Refresh токены сессии перестают действовать",
                 "consumes": [
                     "application/json"
                 ],
@@ -3878,25 +8297,14 @@ const docTemplate = `{
                 "tags": [
                     "users"
                 ],
-                "summary": "Отзывы пользователя",
+                "summary": "Завершение сессии",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Статус отзывов (pending, approved, rejected)",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Количество записей на странице",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "ID сессии",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -3906,12 +8314,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.ResponseDTO"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponseDTO"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponseDTO"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3936,6 +8356,55 @@ const docTemplate = `{
                 }
             }
         },
+        "models.APIKeyCreateInput": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "daily_quota": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.APIKeyScope"
+                    }
+                }
+            }
+        },
+        "models.APIKeyScope": {
+            "type": "string",
+            "enum": [
+                "companies:read",
+                "reviews:read"
+            ],
+            "x-enum-varnames": [
+                "APIKeyScopeCompaniesRead",
+                "APIKeyScopeReviewsRead"
+            ]
+        },
+        "models.AccountDeletionInput": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
         "models.AdminReviewUpdateInput": {
             "type": "object",
             "properties": {
@@ -4014,6 +8483,47 @@ const docTemplate = `{
                 }
             }
         },
+        "models.CompanyClaimConfirmInput": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CompanyClaimInput": {
+            "type": "object",
+            "required": [
+                "corporate_email"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "corporate_email": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "models.CompanyClaimModerationInput": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RepresentativePermission"
+                    }
+                }
+            }
+        },
         "models.CompanyInput": {
             "type": "object",
             "required": [
@@ -4064,6 +8574,41 @@ const docTemplate = `{
                 }
             }
         },
+        "models.CompanyProfileUpdateInput": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "logo": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CompanyRepresentativeInput": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RepresentativePermission"
+                    }
+                },
+                "user_id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
         "models.CompanyUpdateInput": {
             "type": "object",
             "properties": {
@@ -4109,24 +8654,132 @@ const docTemplate = `{
                 }
             }
         },
-        "models.EmploymentPeriodInput": {
+        "models.EmailChangeInput": {
+            "type": "object",
+            "required": [
+                "new_email",
+                "password"
+            ],
+            "properties": {
+                "new_email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EmailChangeTokenInput": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EmploymentPeriodInput": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                }
+            }
+        },
+        "models.EmploymentTypeInput": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                }
+            }
+        },
+        "models.ForgotPasswordInput": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.IndustryInput": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                }
+            }
+        },
+        "models.MagicLinkLoginInput": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MagicLinkRequestInput": {
             "type": "object",
             "required": [
-                "name"
+                "email"
             ],
             "properties": {
-                "description": {
-                    "type": "string",
-                    "maxLength": 255
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OIDCCallbackInput": {
+            "type": "object",
+            "required": [
+                "code",
+                "state"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
+                "state": {
+                    "type": "string"
                 }
             }
         },
-        "models.EmploymentTypeInput": {
+        "models.RatingCategoryInput": {
             "type": "object",
             "required": [
                 "name"
@@ -4143,57 +8796,175 @@ const docTemplate = `{
                 }
             }
         },
-        "models.ForgotPasswordInput": {
+        "models.RepresentativePermission": {
+            "type": "string",
+            "enum": [
+                "reviews:respond",
+                "company:update"
+            ],
+            "x-enum-varnames": [
+                "RepresentativePermissionReviewsRespond",
+                "RepresentativePermissionCompanyUpdate"
+            ]
+        },
+        "models.ResetPasswordInput": {
             "type": "object",
             "required": [
-                "email",
                 "password",
-                "password_confirm"
+                "password_confirm",
+                "token"
             ],
             "properties": {
-                "email": {
-                    "type": "string"
-                },
                 "password": {
-                    "type": "string",
-                    "minLength": 8
+                    "type": "string"
                 },
                 "password_confirm": {
                     "type": "string"
+                },
+                "token": {
+                    "type": "string"
                 }
             }
         },
-        "models.IndustryInput": {
+        "models.ReviewDraftInput": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "color": {
+                "benefit_type_ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "category_ratings": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "city_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "company_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "cons": {
                     "type": "string",
-                    "maxLength": 20
+                    "maxLength": 20000
                 },
-                "name": {
+                "employment_period_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "employment_type_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "is_former_employee": {
+                    "type": "boolean"
+                },
+                "is_recommended": {
+                    "type": "boolean"
+                },
+                "position": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "pros": {
+                    "type": "string",
+                    "maxLength": 20000
+                }
+            }
+        },
+        "models.ReviewEditInput": {
+            "type": "object",
+            "properties": {
+                "benefit_type_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "category_ratings": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "cons": {
+                    "type": "string",
+                    "minLength": 10
+                },
+                "is_former_employee": {
+                    "type": "boolean"
+                },
+                "is_recommended": {
+                    "type": "boolean"
+                },
+                "position": {
                     "type": "string",
                     "maxLength": 100,
                     "minLength": 2
+                },
+                "pros": {
+                    "type": "string",
+                    "minLength": 10
                 }
             }
         },
-        "models.RatingCategoryInput": {
+        "models.ReviewFlagInput": {
             "type": "object",
             "required": [
-                "name"
+                "reason"
             ],
             "properties": {
-                "description": {
+                "comment": {
                     "type": "string",
-                    "maxLength": 255
+                    "maxLength": 1000
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
+                "reason": {
+                    "enum": [
+                        "defamation",
+                        "personal_data",
+                        "spam",
+                        "offensive",
+                        "false_information",
+                        "other"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ReviewFlagReason"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.ReviewFlagReason": {
+            "type": "string",
+            "enum": [
+                "defamation",
+                "personal_data",
+                "spam",
+                "offensive",
+                "false_information",
+                "other"
+            ],
+            "x-enum-varnames": [
+                "ReviewFlagReasonDefamation",
+                "ReviewFlagReasonPersonalData",
+                "ReviewFlagReasonSpam",
+                "ReviewFlagReasonOffensive",
+                "ReviewFlagReasonFalseInformation",
+                "ReviewFlagReasonOther"
+            ]
+        },
+        "models.ReviewFlagResolutionInput": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
                 }
             }
         },
@@ -4281,17 +9052,48 @@ const docTemplate = `{
                 }
             }
         },
+        "models.ReviewResponseInput": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "text": {
+                    "type": "string",
+                    "maxLength": 5000,
+                    "minLength": 10
+                }
+            }
+        },
+        "models.ReviewResponseModerationInput": {
+            "type": "object",
+            "properties": {
+                "moderation_comment": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ReviewRevisionModerationInput": {
+            "type": "object",
+            "properties": {
+                "moderation_comment": {
+                    "type": "string"
+                }
+            }
+        },
         "models.ReviewStatus": {
             "type": "string",
             "enum": [
                 "pending",
                 "approved",
-                "rejected"
+                "rejected",
+                "withdrawn"
             ],
             "x-enum-varnames": [
                 "ReviewStatusPending",
                 "ReviewStatusApproved",
-                "ReviewStatusRejected"
+                "ReviewStatusRejected",
+                "ReviewStatusWithdrawn"
             ]
         },
         "models.SuggestionInput": {
@@ -4330,6 +9132,58 @@ const docTemplate = `{
                 "SuggestionTypeImprovement"
             ]
         },
+        "models.TwoFactorChallengeInput": {
+            "type": "object",
+            "required": [
+                "challenge_token"
+            ],
+            "properties": {
+                "challenge_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TwoFactorCodeInput": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TwoFactorDisableInput": {
+            "type": "object",
+            "required": [
+                "code",
+                "password"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TwoFactorLoginInput": {
+            "type": "object",
+            "required": [
+                "challenge_token",
+                "code"
+            ],
+            "properties": {
+                "challenge_token": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
         "models.UserLoginInput": {
             "type": "object",
             "required": [
@@ -4363,8 +9217,7 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "password": {
-                    "type": "string",
-                    "minLength": 8
+                    "type": "string"
                 },
                 "password_confirm": {
                     "type": "string"
@@ -4417,8 +9270,7 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "password": {
-                    "type": "string",
-                    "minLength": 8
+                    "type": "string"
                 },
                 "password_confirm": {
                     "type": "string"
@@ -4428,6 +9280,17 @@ const docTemplate = `{
                 }
             }
         },
+        "models.VerifyEmailInput": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
         "utils.ErrorResponseDTO": {
             "type": "object",
             "properties": {
@@ -4450,6 +9313,46 @@ const docTemplate = `{
                 }
             }
         },
+        "utils.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.JWKSet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/utils.JWK"
+                    }
+                }
+            }
+        },
         "utils.ResponseDTO": {
             "type": "object",
             "properties": {
@@ -4462,6 +9365,12 @@ const docTemplate = `{
         }
     },
     "securityDefinitions": {
+        "APIKeyAuth": {
+            "description": "API ключ партнера или внутреннего сервиса, выдается администратором. Открывает доступ на чтение согласно областям ключа",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "BearerAuth": {
             "description": "Используйте JWT токен с префиксом \"Bearer \". Пример: \"Bearer eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9...\"",
             "type": "apiKey",
@@ -1,25 +1,32 @@
 package config
 
 import (
+	"crypto"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server     ServerConfig
-	PostgreSQL PostgreSQLConfig
-	JWT        JWTConfig
-	Security   SecurityConfig
-	RateLimit  RateLimitConfig
+	Server       ServerConfig
+	PostgreSQL   PostgreSQLConfig
+	JWT          JWTConfig
+	Security     SecurityConfig
+	RateLimit    RateLimitConfig
+	Moderation   ModerationConfig
+	ReviewDrafts ReviewDraftConfig
+	Mail         MailConfig
+	OIDC         OIDCConfig
 }
 
 type ServerConfig struct {
-	Port string
-	Mode string
+	Port        string
+	Mode        string
+	FrontendURL string
 }
 
 type PostgreSQLConfig struct {
@@ -35,13 +42,49 @@ type PostgreSQLConfig struct {
 }
 
 type JWTConfig struct {
+	Algorithm        string
 	Secret           string
+	KeyID            string
+	SigningKey       crypto.PrivateKey
+	VerificationKeys map[string]crypto.PublicKey
 	ExpiresIn        time.Duration
 	RefreshExpiresIn time.Duration
+	StateCacheTTL    time.Duration
 }
 
 type SecurityConfig struct {
-	PasswordSalt string
+	PasswordSalt                  string
+	PasswordResetExpiresIn        time.Duration
+	EmailVerificationExpiresIn    time.Duration
+	MagicLinkExpiresIn            time.Duration
+	EmailChangeExpiresIn          time.Duration
+	AccountDeletion               AccountDeletionConfig
+	DataExportExpiresIn           time.Duration
+	RepresentativeCodeExpires     time.Duration
+	IdempotencyKeyTTL             time.Duration
+	IdempotencyKeyCleanupInterval time.Duration
+	Require2FAForStaff            bool
+	TwoFactorChallengeExpires     time.Duration
+	TOTPIssuer                    string
+	LoginLockout                  LoginLockoutConfig
+	PasswordPolicy                PasswordPolicyConfig
+}
+
+// LoginLockoutConfig задает защиту входа от перебора паролей. После Threshold неудачных попыток подряд
+// вход блокируется на BaseDuration, и каждая следующая неудача удваивает блокировку вплоть до MaxDuration.
+type LoginLockoutConfig struct {
+	Threshold    int
+	IPThreshold  int
+	BaseDuration time.Duration
+	MaxDuration  time.Duration
+	Window       time.Duration
+}
+
+// AccountDeletionConfig задает срок, в течение которого пользователь может отменить удаление аккаунта,
+// и период, с которым фоновая задача удаляет аккаунты с истекшим сроком.
+type AccountDeletionConfig struct {
+	GracePeriod   time.Duration
+	CheckInterval time.Duration
 }
 
 type RateLimitConfig struct {
@@ -49,11 +92,74 @@ type RateLimitConfig struct {
 	Duration time.Duration
 }
 
+// ModerationConfig задает правила модерации отзывов. FlagHideThreshold - число открытых жалоб,
+// после которого отзыв скрывается из публичной выдачи до решения модератора, 0 - не скрывать автоматически.
+// ReviewCooldown - через сколько после предыдущего отзыва о той же компании можно оставить новый,
+// 0 - не больше одного действующего отзыва. DuplicateThreshold - сходство текста (от 0 до 1), начиная
+// с которого отзыв считается почти дубликатом, 0 - не проверять. AutoModeration включает автоматическую
+// предмодерацию: отзыв с суммарным баллом правил не выше AutoApproveScore публикуется, не ниже
+// AutoRejectScore - отклоняется, остальные ждут модератора. По умолчанию она выключена и все отзывы ждут
+// модератора; включается через AUTO_MODERATION_ENABLED=true после подбора порогов
+// AUTO_MODERATION_APPROVE_SCORE и AUTO_MODERATION_REJECT_SCORE. TrustedAuthorMinApproved - сколько одобренных
+// отзывов без отклоненных нужно, чтобы автор считался проверенным. PIIPersonNames - имена и фамилии,
+// которые скрываются в публичном тексте отзывов вместе с телефонами, адресами почты и номерами документов.
+type ModerationConfig struct {
+	FlagHideThreshold        int
+	ReviewCooldown           time.Duration
+	DuplicateThreshold       float64
+	AutoModeration           bool
+	AutoApproveScore         float64
+	AutoRejectScore          float64
+	TrustedAuthorMinApproved int
+	PIIPersonNames           []string
+}
+
+// ReviewDraftConfig задает, сколько хранится черновик отзыва после последнего изменения,
+// и период, с которым фоновая задача удаляет устаревшие черновики.
+type ReviewDraftConfig struct {
+	TTL           time.Duration
+	PurgeInterval time.Duration
+}
+
+type OIDCConfig struct {
+	StateExpiresIn time.Duration
+	Providers      []OIDCProviderConfig
+}
+
+// OIDCProviderConfig описывает внешнего провайдера входа (OpenID Connect / OAuth2).
+// Поля *Claim задают, из каких полей ответа userinfo брать данные пользователя.
+type OIDCProviderConfig struct {
+	Name               string
+	ClientID           string
+	ClientSecret       string
+	AuthURL            string
+	TokenURL           string
+	UserInfoURL        string
+	RedirectURL        string
+	Scopes             []string
+	SubjectClaim       string
+	EmailClaim         string
+	EmailVerifiedClaim string
+	FirstNameClaim     string
+	LastNameClaim      string
+}
+
+type MailConfig struct {
+	Driver       string
+	From         string
+	OutboxDir    string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUser     string
+	SMTPPassword string
+}
+
 func Load() (*Config, error) {
 	godotenv.Load()
 
 	serverPort := getEnv("SERVER_PORT", "8080")
 	serverMode := getEnv("SERVER_MODE", "debug")
+	frontendURL := getEnv("FRONTEND_URL", "http://localhost:3000")
 
 	pgHost := getEnv("POSTGRES_HOST", "localhost")
 	pgPort := getEnv("POSTGRES_PORT", "5432")
@@ -86,8 +192,99 @@ func Load() (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid JWT_REFRESH_EXPIRES_IN: %w", err)
 	}
+	jwtStateCacheTTL, err := time.ParseDuration(getEnv("JWT_STATE_CACHE_TTL", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT_STATE_CACHE_TTL: %w", err)
+	}
+
+	jwtConfig := JWTConfig{
+		Algorithm:        getEnv("JWT_ALGORITHM", JWTAlgorithmHS256),
+		Secret:           jwtSecret,
+		KeyID:            getEnv("JWT_KEY_ID", ""),
+		ExpiresIn:        jwtExpiresIn,
+		RefreshExpiresIn: jwtRefreshExpiresIn,
+		StateCacheTTL:    jwtStateCacheTTL,
+	}
+	if err := loadJWTKeys(&jwtConfig); err != nil {
+		return nil, err
+	}
 
 	passwordSalt := getEnv("PASSWORD_SALT", "default_password_salt")
+	passwordResetExpiresIn, err := time.ParseDuration(getEnv("PASSWORD_RESET_EXPIRES_IN", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid PASSWORD_RESET_EXPIRES_IN: %w", err)
+	}
+	emailVerificationExpiresIn, err := time.ParseDuration(getEnv("EMAIL_VERIFICATION_EXPIRES_IN", "48h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid EMAIL_VERIFICATION_EXPIRES_IN: %w", err)
+	}
+	magicLinkExpiresIn, err := time.ParseDuration(getEnv("MAGIC_LINK_EXPIRES_IN", "15m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAGIC_LINK_EXPIRES_IN: %w", err)
+	}
+	emailChangeExpiresIn, err := time.ParseDuration(getEnv("EMAIL_CHANGE_EXPIRES_IN", "24h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid EMAIL_CHANGE_EXPIRES_IN: %w", err)
+	}
+	accountDeletionGracePeriod, err := time.ParseDuration(getEnv("ACCOUNT_DELETION_GRACE_PERIOD", "720h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ACCOUNT_DELETION_GRACE_PERIOD: %w", err)
+	}
+	accountDeletionCheckInterval, err := time.ParseDuration(getEnv("ACCOUNT_DELETION_CHECK_INTERVAL", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ACCOUNT_DELETION_CHECK_INTERVAL: %w", err)
+	}
+	dataExportExpiresIn, err := time.ParseDuration(getEnv("DATA_EXPORT_EXPIRES_IN", "72h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DATA_EXPORT_EXPIRES_IN: %w", err)
+	}
+	representativeCodeExpires, err := time.ParseDuration(getEnv("REPRESENTATIVE_CODE_EXPIRES_IN", "30m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid REPRESENTATIVE_CODE_EXPIRES_IN: %w", err)
+	}
+	idempotencyKeyTTL, err := time.ParseDuration(getEnv("IDEMPOTENCY_KEY_TTL", "24h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid IDEMPOTENCY_KEY_TTL: %w", err)
+	}
+	idempotencyKeyCleanupInterval, err := time.ParseDuration(getEnv("IDEMPOTENCY_KEY_CLEANUP_INTERVAL", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid IDEMPOTENCY_KEY_CLEANUP_INTERVAL: %w", err)
+	}
+
+	require2FAForStaff, err := strconv.ParseBool(getEnv("REQUIRE_2FA_FOR_STAFF", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid REQUIRE_2FA_FOR_STAFF: %w", err)
+	}
+	twoFactorChallengeExpires, err := time.ParseDuration(getEnv("TWO_FACTOR_CHALLENGE_EXPIRES_IN", "5m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid TWO_FACTOR_CHALLENGE_EXPIRES_IN: %w", err)
+	}
+
+	loginLockoutThreshold, err := strconv.Atoi(getEnv("LOGIN_LOCKOUT_THRESHOLD", "5"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOGIN_LOCKOUT_THRESHOLD: %w", err)
+	}
+	loginLockoutIPThreshold, err := strconv.Atoi(getEnv("LOGIN_LOCKOUT_IP_THRESHOLD", "20"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOGIN_LOCKOUT_IP_THRESHOLD: %w", err)
+	}
+	loginLockoutBase, err := time.ParseDuration(getEnv("LOGIN_LOCKOUT_BASE_DURATION", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOGIN_LOCKOUT_BASE_DURATION: %w", err)
+	}
+	loginLockoutMax, err := time.ParseDuration(getEnv("LOGIN_LOCKOUT_MAX_DURATION", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOGIN_LOCKOUT_MAX_DURATION: %w", err)
+	}
+	loginLockoutWindow, err := time.ParseDuration(getEnv("LOGIN_LOCKOUT_WINDOW", "24h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOGIN_LOCKOUT_WINDOW: %w", err)
+	}
+
+	passwordPolicy, err := loadPasswordPolicy()
+	if err != nil {
+		return nil, err
+	}
 
 	rateLimitRequests, err := strconv.Atoi(getEnv("RATE_LIMIT_REQUESTS", "100"))
 	if err != nil {
@@ -98,10 +295,73 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("invalid RATE_LIMIT_DURATION: %w", err)
 	}
 
+	flagHideThreshold, err := strconv.Atoi(getEnv("REVIEW_FLAG_HIDE_THRESHOLD", "5"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid REVIEW_FLAG_HIDE_THRESHOLD: %w", err)
+	}
+	reviewCooldown, err := time.ParseDuration(getEnv("REVIEW_COOLDOWN", "0s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid REVIEW_COOLDOWN: %w", err)
+	}
+	duplicateThreshold, err := strconv.ParseFloat(getEnv("REVIEW_DUPLICATE_THRESHOLD", "0.6"), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid REVIEW_DUPLICATE_THRESHOLD: %w", err)
+	}
+	autoModeration, err := strconv.ParseBool(getEnv("AUTO_MODERATION_ENABLED", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid AUTO_MODERATION_ENABLED: %w", err)
+	}
+	autoApproveScore, err := strconv.ParseFloat(getEnv("AUTO_MODERATION_APPROVE_SCORE", "-1"), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid AUTO_MODERATION_APPROVE_SCORE: %w", err)
+	}
+	autoRejectScore, err := strconv.ParseFloat(getEnv("AUTO_MODERATION_REJECT_SCORE", "8"), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid AUTO_MODERATION_REJECT_SCORE: %w", err)
+	}
+	if autoApproveScore >= autoRejectScore {
+		return nil, fmt.Errorf("invalid AUTO_MODERATION_APPROVE_SCORE: must be less than AUTO_MODERATION_REJECT_SCORE")
+	}
+	trustedAuthorMinApproved, err := strconv.Atoi(getEnv("TRUSTED_AUTHOR_MIN_APPROVED", "3"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid TRUSTED_AUTHOR_MIN_APPROVED: %w", err)
+	}
+	reviewDraftTTL, err := time.ParseDuration(getEnv("REVIEW_DRAFT_TTL", "720h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid REVIEW_DRAFT_TTL: %w", err)
+	}
+	reviewDraftPurgeInterval, err := time.ParseDuration(getEnv("REVIEW_DRAFT_PURGE_INTERVAL", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid REVIEW_DRAFT_PURGE_INTERVAL: %w", err)
+	}
+
+	var piiPersonNames []string
+	for _, name := range strings.Split(getEnv("PII_PERSON_NAMES", ""), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			piiPersonNames = append(piiPersonNames, name)
+		}
+	}
+
+	mailDriver := getEnv("MAIL_DRIVER", "file")
+	if mailDriver != "file" && mailDriver != "smtp" {
+		return nil, fmt.Errorf("invalid MAIL_DRIVER: %s", mailDriver)
+	}
+
+	oidcStateExpiresIn, err := time.ParseDuration(getEnv("OIDC_STATE_EXPIRES_IN", "10m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid OIDC_STATE_EXPIRES_IN: %w", err)
+	}
+
+	oidcProviders, err := loadOIDCProviders(frontendURL)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		Server: ServerConfig{
-			Port: serverPort,
-			Mode: serverMode,
+			Port:        serverPort,
+			Mode:        serverMode,
+			FrontendURL: frontendURL,
 		},
 		PostgreSQL: PostgreSQLConfig{
 			Host:            pgHost,
@@ -114,21 +374,106 @@ func Load() (*Config, error) {
 			MaxIdleConns:    pgMaxIdleConns,
 			ConnMaxLifetime: pgConnMaxLifetime,
 		},
-		JWT: JWTConfig{
-			Secret:           jwtSecret,
-			ExpiresIn:        jwtExpiresIn,
-			RefreshExpiresIn: jwtRefreshExpiresIn,
-		},
+		JWT: jwtConfig,
 		Security: SecurityConfig{
-			PasswordSalt: passwordSalt,
+			PasswordSalt:               passwordSalt,
+			PasswordResetExpiresIn:     passwordResetExpiresIn,
+			EmailVerificationExpiresIn: emailVerificationExpiresIn,
+			MagicLinkExpiresIn:         magicLinkExpiresIn,
+			EmailChangeExpiresIn:       emailChangeExpiresIn,
+			AccountDeletion: AccountDeletionConfig{
+				GracePeriod:   accountDeletionGracePeriod,
+				CheckInterval: accountDeletionCheckInterval,
+			},
+			DataExportExpiresIn:           dataExportExpiresIn,
+			RepresentativeCodeExpires:     representativeCodeExpires,
+			IdempotencyKeyTTL:             idempotencyKeyTTL,
+			IdempotencyKeyCleanupInterval: idempotencyKeyCleanupInterval,
+			Require2FAForStaff:            require2FAForStaff,
+			TwoFactorChallengeExpires:     twoFactorChallengeExpires,
+			TOTPIssuer:                    getEnv("TOTP_ISSUER", "JobSolution"),
+			LoginLockout: LoginLockoutConfig{
+				Threshold:    loginLockoutThreshold,
+				IPThreshold:  loginLockoutIPThreshold,
+				BaseDuration: loginLockoutBase,
+				MaxDuration:  loginLockoutMax,
+				Window:       loginLockoutWindow,
+			},
+			PasswordPolicy: passwordPolicy,
 		},
 		RateLimit: RateLimitConfig{
 			Requests: rateLimitRequests,
 			Duration: rateLimitDuration,
 		},
+		Moderation: ModerationConfig{
+			FlagHideThreshold:        flagHideThreshold,
+			ReviewCooldown:           reviewCooldown,
+			DuplicateThreshold:       duplicateThreshold,
+			AutoModeration:           autoModeration,
+			AutoApproveScore:         autoApproveScore,
+			AutoRejectScore:          autoRejectScore,
+			TrustedAuthorMinApproved: trustedAuthorMinApproved,
+			PIIPersonNames:           piiPersonNames,
+		},
+		ReviewDrafts: ReviewDraftConfig{
+			TTL:           reviewDraftTTL,
+			PurgeInterval: reviewDraftPurgeInterval,
+		},
+		Mail: MailConfig{
+			Driver:       mailDriver,
+			From:         getEnv("MAIL_FROM", "no-reply@jobsolution.kz"),
+			OutboxDir:    getEnv("MAIL_OUTBOX_DIR", "outbox"),
+			SMTPHost:     getEnv("SMTP_HOST", ""),
+			SMTPPort:     getEnv("SMTP_PORT", "587"),
+			SMTPUser:     getEnv("SMTP_USER", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		},
+		OIDC: OIDCConfig{
+			StateExpiresIn: oidcStateExpiresIn,
+			Providers:      oidcProviders,
+		},
 	}, nil
 }
 
+// loadOIDCProviders читает список провайдеров из OIDC_PROVIDERS (через запятую)
+// и настройки каждого из переменных OIDC_<ИМЯ>_*.
+func loadOIDCProviders(frontendURL string) ([]OIDCProviderConfig, error) {
+	var providers []OIDCProviderConfig
+
+	for _, name := range strings.Split(getEnv("OIDC_PROVIDERS", ""), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		provider := OIDCProviderConfig{
+			Name:               name,
+			ClientID:           getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret:       getEnv(prefix+"CLIENT_SECRET", ""),
+			AuthURL:            getEnv(prefix+"AUTH_URL", ""),
+			TokenURL:           getEnv(prefix+"TOKEN_URL", ""),
+			UserInfoURL:        getEnv(prefix+"USERINFO_URL", ""),
+			RedirectURL:        getEnv(prefix+"REDIRECT_URL", frontendURL+"/auth/oidc/"+name+"/callback"),
+			Scopes:             strings.Fields(getEnv(prefix+"SCOPES", "openid email profile")),
+			SubjectClaim:       getEnv(prefix+"SUBJECT_CLAIM", "sub"),
+			EmailClaim:         getEnv(prefix+"EMAIL_CLAIM", "email"),
+			EmailVerifiedClaim: getEnv(prefix+"EMAIL_VERIFIED_CLAIM", "email_verified"),
+			FirstNameClaim:     getEnv(prefix+"FIRST_NAME_CLAIM", "given_name"),
+			LastNameClaim:      getEnv(prefix+"LAST_NAME_CLAIM", "family_name"),
+		}
+
+		if provider.ClientID == "" || provider.AuthURL == "" || provider.TokenURL == "" || provider.UserInfoURL == "" {
+			return nil, fmt.Errorf("invalid OIDC provider %s: %sCLIENT_ID, %sAUTH_URL, %sTOKEN_URL and %sUSERINFO_URL are required",
+				name, prefix, prefix, prefix, prefix)
+		}
+
+		providers = append(providers, provider)
+	}
+
+	return providers, nil
+}
+
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
 	if value == "" {
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	JWTAlgorithmHS256 = "HS256"
+	JWTAlgorithmRS256 = "RS256"
+	JWTAlgorithmEdDSA = "EdDSA"
+)
+
+// loadJWTKeys читает ключи подписи для асимметричных алгоритмов.
+// JWT_PRIVATE_KEY_FILE - закрытый ключ (PEM, PKCS#8 или PKCS#1 для RSA), которым подписываются новые токены.
+// JWT_PUBLIC_KEY_FILES - дополнительные открытые ключи в формате "kid=путь,kid=путь", которыми еще можно
+// проверять ранее выданные токены во время ротации ключей.
+func loadJWTKeys(cfg *JWTConfig) error {
+	switch cfg.Algorithm {
+	case JWTAlgorithmHS256:
+		return nil
+	case JWTAlgorithmRS256, JWTAlgorithmEdDSA:
+	default:
+		return fmt.Errorf("invalid JWT_ALGORITHM: %s", cfg.Algorithm)
+	}
+
+	privateKeyFile := getEnv("JWT_PRIVATE_KEY_FILE", "")
+	if privateKeyFile == "" {
+		return fmt.Errorf("JWT_PRIVATE_KEY_FILE is required for JWT_ALGORITHM=%s", cfg.Algorithm)
+	}
+
+	signingKey, err := readPrivateKey(privateKeyFile)
+	if err != nil {
+		return fmt.Errorf("invalid JWT_PRIVATE_KEY_FILE: %w", err)
+	}
+
+	publicKey, err := publicKeyFor(cfg.Algorithm, signingKey)
+	if err != nil {
+		return fmt.Errorf("invalid JWT_PRIVATE_KEY_FILE: %w", err)
+	}
+
+	if cfg.KeyID == "" {
+		cfg.KeyID, err = keyThumbprint(publicKey)
+		if err != nil {
+			return fmt.Errorf("invalid JWT_PRIVATE_KEY_FILE: %w", err)
+		}
+	}
+
+	cfg.SigningKey = signingKey
+	cfg.VerificationKeys = map[string]crypto.PublicKey{
+		cfg.KeyID: publicKey,
+	}
+
+	for _, entry := range strings.Split(getEnv("JWT_PUBLIC_KEY_FILES", ""), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		kid, path, ok := strings.Cut(entry, "=")
+		if !ok || kid == "" || path == "" {
+			return fmt.Errorf("invalid JWT_PUBLIC_KEY_FILES entry %q: expected kid=path", entry)
+		}
+
+		key, err := readPublicKey(path)
+		if err != nil {
+			return fmt.Errorf("invalid JWT_PUBLIC_KEY_FILES entry %q: %w", entry, err)
+		}
+
+		if !keyMatchesAlgorithm(cfg.Algorithm, key) {
+			return fmt.Errorf("invalid JWT_PUBLIC_KEY_FILES entry %q: key type does not match %s", entry, cfg.Algorithm)
+		}
+
+		cfg.VerificationKeys[kid] = key
+	}
+
+	return nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data in %s", path)
+	}
+
+	return block, nil
+}
+
+func readPrivateKey(path string) (crypto.PrivateKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
+
+func readPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	return x509.ParsePKCS1PublicKey(block.Bytes)
+}
+
+func publicKeyFor(algorithm string, privateKey crypto.PrivateKey) (crypto.PublicKey, error) {
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		if algorithm == JWTAlgorithmRS256 {
+			return &key.PublicKey, nil
+		}
+	case ed25519.PrivateKey:
+		if algorithm == JWTAlgorithmEdDSA {
+			return key.Public(), nil
+		}
+	}
+
+	return nil, fmt.Errorf("key type %T does not match %s", privateKey, algorithm)
+}
+
+func keyMatchesAlgorithm(algorithm string, key crypto.PublicKey) bool {
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return algorithm == JWTAlgorithmRS256
+	case ed25519.PublicKey:
+		return algorithm == JWTAlgorithmEdDSA
+	default:
+		return false
+	}
+}
+
+// keyThumbprint - идентификатор ключа по умолчанию: начало SHA-256 от DER представления открытого ключа.
+func keyThumbprint(key crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:8]), nil
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// PasswordPolicyConfig задает требования к паролю. HistorySize - сколько последних паролей нельзя
+// использовать повторно (0 отключает проверку). BreachedPasswords - пароли из утечек, загруженные
+// из PASSWORD_BREACHED_LIST_FILE, в нижнем регистре.
+type PasswordPolicyConfig struct {
+	MinLength         int
+	RequireUpper      bool
+	RequireLower      bool
+	RequireDigit      bool
+	RequireSpecial    bool
+	HistorySize       int
+	BreachedPasswords map[string]struct{}
+}
+
+func loadPasswordPolicy() (PasswordPolicyConfig, error) {
+	var policy PasswordPolicyConfig
+	var err error
+
+	policy.MinLength, err = strconv.Atoi(getEnv("PASSWORD_MIN_LENGTH", "8"))
+	if err != nil {
+		return policy, fmt.Errorf("invalid PASSWORD_MIN_LENGTH: %w", err)
+	}
+	policy.RequireUpper, err = strconv.ParseBool(getEnv("PASSWORD_REQUIRE_UPPER", "true"))
+	if err != nil {
+		return policy, fmt.Errorf("invalid PASSWORD_REQUIRE_UPPER: %w", err)
+	}
+	policy.RequireLower, err = strconv.ParseBool(getEnv("PASSWORD_REQUIRE_LOWER", "true"))
+	if err != nil {
+		return policy, fmt.Errorf("invalid PASSWORD_REQUIRE_LOWER: %w", err)
+	}
+	policy.RequireDigit, err = strconv.ParseBool(getEnv("PASSWORD_REQUIRE_DIGIT", "true"))
+	if err != nil {
+		return policy, fmt.Errorf("invalid PASSWORD_REQUIRE_DIGIT: %w", err)
+	}
+	policy.RequireSpecial, err = strconv.ParseBool(getEnv("PASSWORD_REQUIRE_SPECIAL", "false"))
+	if err != nil {
+		return policy, fmt.Errorf("invalid PASSWORD_REQUIRE_SPECIAL: %w", err)
+	}
+	policy.HistorySize, err = strconv.Atoi(getEnv("PASSWORD_HISTORY_SIZE", "5"))
+	if err != nil {
+		return policy, fmt.Errorf("invalid PASSWORD_HISTORY_SIZE: %w", err)
+	}
+
+	policy.BreachedPasswords = map[string]struct{}{}
+	if path := getEnv("PASSWORD_BREACHED_LIST_FILE", ""); path != "" {
+		policy.BreachedPasswords, err = readBreachedPasswords(path)
+		if err != nil {
+			return policy, fmt.Errorf("invalid PASSWORD_BREACHED_LIST_FILE: %w", err)
+		}
+	}
+
+	return policy, nil
+}
+
+// readBreachedPasswords читает список паролей по одному на строку. Пустые строки и строки,
+// начинающиеся с #, пропускаются.
+func readBreachedPasswords(path string) (map[string]struct{}, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	passwords := map[string]struct{}{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		passwords[strings.ToLower(line)] = struct{}{}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return passwords, nil
+}
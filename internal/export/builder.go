@@ -0,0 +1,204 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"job_solition/internal/models"
+	"job_solition/internal/repository"
+)
+
+const (
+	buildTimeout = 5 * time.Minute
+	saveTimeout  = 30 * time.Second
+	pageSize     = 100
+	timeLayout   = time.RFC3339
+)
+
+// PendingTimeout - время, после которого незавершенная сборка архива считается неудавшейся.
+const PendingTimeout = buildTimeout + saveTimeout
+
+// Builder собирает архив с персональными данными пользователя: каждый раздел выгружается
+// в JSON и в CSV.
+type Builder struct {
+	repo      *repository.Repository
+	expiresIn time.Duration
+}
+
+func NewBuilder(repo *repository.Repository, expiresIn time.Duration) *Builder {
+	return &Builder{
+		repo:      repo,
+		expiresIn: expiresIn,
+	}
+}
+
+// Run собирает архив для выгрузки и сохраняет его. Предназначен для запуска в отдельной горутине.
+func (b *Builder) Run(export models.DataExport) {
+	ctx, cancel := context.WithTimeout(context.Background(), buildTimeout)
+	defer cancel()
+
+	archive, err := b.Build(ctx, export.UserID)
+
+	// Результат сохраняется с отдельным контекстом: контекст сборки к этому моменту может истечь.
+	saveCtx, saveCancel := context.WithTimeout(context.Background(), saveTimeout)
+	defer saveCancel()
+
+	if err != nil {
+		log.Printf("Ошибка при сборке выгрузки данных %d пользователя %d: %v", export.ID, export.UserID, err)
+		if err := b.repo.DataExports.MarkFailed(saveCtx, export.ID, err.Error()); err != nil {
+			log.Printf("Ошибка при обновлении статуса выгрузки данных %d: %v", export.ID, err)
+		}
+		return
+	}
+
+	if err := b.repo.DataExports.MarkReady(saveCtx, export.ID, archive, time.Now().Add(b.expiresIn)); err != nil {
+		log.Printf("Ошибка при сохранении выгрузки данных %d: %v", export.ID, err)
+	}
+}
+
+type section struct {
+	name   string
+	data   interface{}
+	header []string
+	rows   [][]string
+}
+
+func (b *Builder) Build(ctx context.Context, userID int) ([]byte, error) {
+	user, err := b.repo.Users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := b.reviews(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	marks, err := b.repo.Reviews.GetUsefulMarksByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions, err := b.repo.Suggestions.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := b.repo.RefreshTokens.GetSessionsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := b.loginHistory(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := []section{
+		profileSection(user.ToProfile()),
+		reviewsSection(reviews),
+		reviewCategoryRatingsSection(reviews),
+		reviewBenefitsSection(reviews),
+		usefulMarksSection(marks),
+		suggestionsSection(suggestions),
+		sessionsSection(sessions),
+		loginHistorySection(attempts),
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	for _, s := range sections {
+		if err := writeSection(zw, s); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("ошибка при сборке архива: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (b *Builder) reviews(ctx context.Context, userID int) ([]models.ReviewWithDetails, error) {
+	var all []models.ReviewWithDetails
+	for page := 1; ; page++ {
+		reviews, total, err := b.repo.Reviews.GetByUser(ctx, userID, models.ReviewFilter{Page: page, Limit: pageSize})
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, reviews...)
+		if len(reviews) == 0 || len(all) >= total {
+			return all, nil
+		}
+	}
+}
+
+func (b *Builder) loginHistory(ctx context.Context, userID int) ([]models.LoginAttempt, error) {
+	var all []models.LoginAttempt
+	for page := 1; ; page++ {
+		attempts, total, err := b.repo.LoginAttempts.GetByUser(ctx, userID, page, pageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, attempts...)
+		if len(attempts) == 0 || len(all) >= total {
+			return all, nil
+		}
+	}
+}
+
+func writeSection(zw *zip.Writer, s section) error {
+	if s.data != nil {
+		w, err := zw.Create(s.name + ".json")
+		if err != nil {
+			return fmt.Errorf("ошибка при добавлении файла в архив: %w", err)
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(s.data); err != nil {
+			return fmt.Errorf("ошибка при записи раздела %s: %w", s.name, err)
+		}
+	}
+
+	w, err := zw.Create(s.name + ".csv")
+	if err != nil {
+		return fmt.Errorf("ошибка при добавлении файла в архив: %w", err)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(s.header); err != nil {
+		return fmt.Errorf("ошибка при записи раздела %s: %w", s.name, err)
+	}
+	if err := cw.WriteAll(s.rows); err != nil {
+		return fmt.Errorf("ошибка при записи раздела %s: %w", s.name, err)
+	}
+
+	return nil
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(timeLayout)
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatTime(*t)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,257 @@
+package export
+
+import (
+	"strconv"
+	"time"
+
+	"job_solition/internal/models"
+)
+
+// reviewRecord - отзыв в выгрузке: вместо идентификаторов справочников указаны их названия.
+type reviewRecord struct {
+	ID                int                           `json:"id"`
+	CompanyID         int                           `json:"company_id"`
+	Company           string                        `json:"company,omitempty"`
+	Position          string                        `json:"position"`
+	EmploymentType    string                        `json:"employment_type,omitempty"`
+	EmploymentPeriod  string                        `json:"employment_period,omitempty"`
+	City              string                        `json:"city,omitempty"`
+	Rating            float64                       `json:"rating"`
+	Pros              string                        `json:"pros"`
+	Cons              string                        `json:"cons"`
+	IsFormerEmployee  bool                          `json:"is_former_employee"`
+	IsRecommended     bool                          `json:"is_recommended"`
+	Status            models.ReviewStatus           `json:"status"`
+	ModerationComment string                        `json:"moderation_comment,omitempty"`
+	UsefulCount       int                           `json:"useful_count"`
+	CategoryRatings   []models.ReviewCategoryRating `json:"category_ratings"`
+	Benefits          []models.ReviewBenefit        `json:"benefits"`
+	CreatedAt         time.Time                     `json:"created_at"`
+	UpdatedAt         time.Time                     `json:"updated_at"`
+	ApprovedAt        *time.Time                    `json:"approved_at,omitempty"`
+}
+
+func newReviewRecord(details models.ReviewWithDetails) reviewRecord {
+	review := details.Review
+	record := reviewRecord{
+		ID:               review.ID,
+		CompanyID:        review.CompanyID,
+		Position:         review.Position,
+		Rating:           review.Rating,
+		Pros:             review.Pros,
+		Cons:             review.Cons,
+		IsFormerEmployee: review.IsFormerEmployee,
+		IsRecommended:    review.IsRecommended,
+		Status:           review.Status,
+		UsefulCount:      review.UsefulCount,
+		CategoryRatings:  details.CategoryRatings,
+		Benefits:         details.Benefits,
+		CreatedAt:        review.CreatedAt,
+		UpdatedAt:        review.UpdatedAt,
+	}
+
+	if details.Company != nil {
+		record.Company = details.Company.Company.Name
+	}
+	if details.EmploymentType != nil {
+		record.EmploymentType = details.EmploymentType.Name
+	}
+	if details.EmploymentPeriod != nil {
+		record.EmploymentPeriod = details.EmploymentPeriod.Name
+	}
+	if details.City != nil {
+		record.City = details.City.Name
+	}
+	if review.ModerationComment.Valid {
+		record.ModerationComment = review.ModerationComment.String
+	}
+	if review.ApprovedAt.Valid {
+		approvedAt := review.ApprovedAt.Time
+		record.ApprovedAt = &approvedAt
+	}
+
+	return record
+}
+
+func profileSection(profile models.UserProfile) section {
+	return section{
+		name:   "profile",
+		data:   profile,
+		header: []string{"id", "email", "email_verified", "two_factor_enabled", "phone", "first_name", "last_name", "role", "created_at"},
+		rows: [][]string{{
+			strconv.Itoa(profile.ID),
+			profile.Email,
+			strconv.FormatBool(profile.EmailVerified),
+			strconv.FormatBool(profile.TwoFactorEnabled),
+			profile.Phone,
+			profile.FirstName,
+			profile.LastName,
+			string(profile.Role),
+			formatTime(profile.CreatedAt),
+		}},
+	}
+}
+
+func reviewsSection(reviews []models.ReviewWithDetails) section {
+	records := make([]reviewRecord, 0, len(reviews))
+	rows := make([][]string, 0, len(reviews))
+	for _, details := range reviews {
+		r := newReviewRecord(details)
+		records = append(records, r)
+		rows = append(rows, []string{
+			strconv.Itoa(r.ID),
+			strconv.Itoa(r.CompanyID),
+			r.Company,
+			r.Position,
+			r.EmploymentType,
+			r.EmploymentPeriod,
+			r.City,
+			formatFloat(r.Rating),
+			r.Pros,
+			r.Cons,
+			strconv.FormatBool(r.IsFormerEmployee),
+			strconv.FormatBool(r.IsRecommended),
+			string(r.Status),
+			r.ModerationComment,
+			strconv.Itoa(r.UsefulCount),
+			formatTime(r.CreatedAt),
+			formatTime(r.UpdatedAt),
+			formatOptionalTime(r.ApprovedAt),
+		})
+	}
+
+	return section{
+		name: "reviews",
+		data: records,
+		header: []string{
+			"id", "company_id", "company", "position", "employment_type", "employment_period", "city", "rating",
+			"pros", "cons", "is_former_employee", "is_recommended", "status", "moderation_comment", "useful_count",
+			"created_at", "updated_at", "approved_at",
+		},
+		rows: rows,
+	}
+}
+
+// reviewCategoryRatingsSection и reviewBenefitsSection выгружаются только в CSV:
+// в JSON оценки и льготы вложены в отзывы.
+func reviewCategoryRatingsSection(reviews []models.ReviewWithDetails) section {
+	rows := [][]string{}
+	for _, details := range reviews {
+		for _, rating := range details.CategoryRatings {
+			rows = append(rows, []string{
+				strconv.Itoa(details.Review.ID),
+				strconv.Itoa(rating.CategoryID),
+				rating.Category,
+				formatFloat(rating.Rating),
+			})
+		}
+	}
+
+	return section{
+		name:   "review_category_ratings",
+		header: []string{"review_id", "category_id", "category", "rating"},
+		rows:   rows,
+	}
+}
+
+func reviewBenefitsSection(reviews []models.ReviewWithDetails) section {
+	rows := [][]string{}
+	for _, details := range reviews {
+		for _, benefit := range details.Benefits {
+			rows = append(rows, []string{
+				strconv.Itoa(details.Review.ID),
+				strconv.Itoa(benefit.BenefitTypeID),
+				benefit.Benefit,
+			})
+		}
+	}
+
+	return section{
+		name:   "review_benefits",
+		header: []string{"review_id", "benefit_type_id", "benefit"},
+		rows:   rows,
+	}
+}
+
+func usefulMarksSection(marks []models.UsefulMark) section {
+	rows := make([][]string, 0, len(marks))
+	for _, mark := range marks {
+		rows = append(rows, []string{
+			strconv.Itoa(mark.ReviewID),
+			formatTime(mark.CreatedAt),
+		})
+	}
+
+	return section{
+		name:   "useful_marks",
+		data:   marks,
+		header: []string{"review_id", "created_at"},
+		rows:   rows,
+	}
+}
+
+func suggestionsSection(suggestions []models.Suggestion) section {
+	rows := make([][]string, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		rows = append(rows, []string{
+			strconv.Itoa(suggestion.ID),
+			string(suggestion.Type),
+			suggestion.Text,
+			formatTime(suggestion.CreatedAt),
+		})
+	}
+
+	return section{
+		name:   "suggestions",
+		data:   suggestions,
+		header: []string{"id", "type", "text", "created_at"},
+		rows:   rows,
+	}
+}
+
+func sessionsSection(sessions []models.Session) section {
+	rows := make([][]string, 0, len(sessions))
+	for _, session := range sessions {
+		rows = append(rows, []string{
+			session.ID,
+			session.UserAgent,
+			session.IPAddress,
+			formatTime(session.CreatedAt),
+			formatTime(session.LastUsedAt),
+			formatTime(session.ExpiresAt),
+		})
+	}
+
+	return section{
+		name:   "sessions",
+		data:   sessions,
+		header: []string{"id", "user_agent", "ip_address", "created_at", "last_used_at", "expires_at"},
+		rows:   rows,
+	}
+}
+
+func loginHistorySection(attempts []models.LoginAttempt) section {
+	rows := make([][]string, 0, len(attempts))
+	for _, attempt := range attempts {
+		reason := ""
+		if attempt.FailureReason != nil {
+			reason = string(*attempt.FailureReason)
+		}
+
+		rows = append(rows, []string{
+			attempt.Email,
+			attempt.IPAddress,
+			attempt.UserAgent,
+			strconv.FormatBool(attempt.Success),
+			reason,
+			formatTime(attempt.CreatedAt),
+		})
+	}
+
+	return section{
+		name:   "login_history",
+		data:   attempts,
+		header: []string{"email", "ip_address", "user_agent", "success", "failure_reason", "created_at"},
+		rows:   rows,
+	}
+}
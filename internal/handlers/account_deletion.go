@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"job_solition/internal/middleware"
+	"job_solition/internal/models"
+	"job_solition/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Удаление аккаунта
+// @Description Назначает удаление аккаунта текущего пользователя после льготного периода, в течение которого удаление можно отменить. После удаления персональные данные стираются, а одобренные отзывы остаются опубликованными от имени анонимного пользователя
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body models.AccountDeletionInput true "Текущий пароль"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 409 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me [delete]
+func (h *UserHandler) DeleteAccount(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	var input models.AccountDeletionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	user, err := h.repo.Users.GetByID(c, userID.(int))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Пользователь не найден", nil)
+		return
+	}
+
+	if user.IsSystem {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Служебную учетную запись нельзя удалить", nil)
+		return
+	}
+
+	if !user.ComparePassword(input.Password) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Неверный пароль", nil)
+		return
+	}
+
+	if user.DeletionDueAt != nil {
+		utils.ErrorResponse(c, http.StatusConflict, "Удаление аккаунта уже назначено", nil)
+		return
+	}
+
+	if user.Role == models.RoleAdmin {
+		adminsCount, err := h.repo.Users.CountByRole(c, models.RoleAdmin)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке количества администраторов", err)
+			return
+		}
+
+		if adminsCount <= 1 {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Невозможно удалить последнего администратора", nil)
+			return
+		}
+	}
+
+	dueAt := time.Now().Add(h.cfg.Security.AccountDeletion.GracePeriod)
+	if err := h.repo.Users.ScheduleDeletion(c, user.ID, dueAt); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при назначении удаления аккаунта", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message":         "Аккаунт будет удален по истечении льготного периода",
+		"deletion_due_at": dueAt,
+	})
+}
+
+// @Summary Отмена удаления аккаунта
+// @Description Отменяет назначенное удаление аккаунта текущего пользователя, если льготный период еще не истек
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/restore [post]
+func (h *UserHandler) CancelAccountDeletion(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	user, err := h.repo.Users.GetByID(c, userID.(int))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Пользователь не найден", nil)
+		return
+	}
+
+	if user.DeletionDueAt == nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Удаление аккаунта не назначено", nil)
+		return
+	}
+
+	if err := h.repo.Users.CancelDeletion(c, user.ID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отмене удаления аккаунта", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message": "Удаление аккаунта отменено",
+	})
+}
@@ -8,23 +8,27 @@ import (
 	"time"
 
 	"job_solition/internal/config"
+	"job_solition/internal/export"
 	"job_solition/internal/middleware"
 	"job_solition/internal/models"
 	"job_solition/internal/repository"
 	"job_solition/internal/utils"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type AdminHandler struct {
-	repo *repository.Repository
-	cfg  *config.Config
+	repo     *repository.Repository
+	cfg      *config.Config
+	exporter *export.Builder
 }
 
 func NewAdminHandler(repo *repository.Repository, cfg *config.Config) *AdminHandler {
 	return &AdminHandler{
-		repo: repo,
-		cfg:  cfg,
+		repo:     repo,
+		cfg:      cfg,
+		exporter: export.NewBuilder(repo, cfg.Security.DataExportExpiresIn),
 	}
 }
 
@@ -235,7 +239,7 @@ func (h *AdminHandler) GetUser(c *gin.Context) {
 }
 
 // @Summary Обновление роли пользователя
-// @Description Обновляет роль пользователя (admin, moderator, user)
+// @Description Обновляет роль пользователя (admin, moderator, user). Выданные пользователю access токены сразу перестают действовать
 // @Tags admin
 // @Accept json
 // @Produce json
@@ -299,6 +303,8 @@ func (h *AdminHandler) UpdateUserRole(c *gin.Context) {
 		}
 	}
 
+	roleChanged := user.Role != input.Role
+
 	user.Role = input.Role
 	user.UpdatedAt = time.Now()
 
@@ -307,11 +313,19 @@ func (h *AdminHandler) UpdateUserRole(c *gin.Context) {
 		return
 	}
 
+	if roleChanged {
+		if err := h.repo.Users.IncrementTokenVersion(c, user.ID); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отзыве access токенов", err)
+			return
+		}
+		middleware.InvalidateTokenState(user.ID)
+	}
+
 	utils.Response(c, http.StatusOK, user)
 }
 
 // @Summary Удаление пользователя
-// @Description Удаляет пользователя по ID
+// @Description Удаляет пользователя по ID. Одобренные отзывы пользователя остаются опубликованными от имени анонимного пользователя, остальные удаляются
 // @Tags admin
 // @Accept json
 // @Produce json
@@ -350,6 +364,11 @@ func (h *AdminHandler) DeleteUser(c *gin.Context) {
 		return
 	}
 
+	if user.IsSystem {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Служебную учетную запись нельзя удалить", nil)
+		return
+	}
+
 	if user.Role == models.RoleAdmin {
 		adminsCount, err := h.repo.Users.CountByRole(c, models.RoleAdmin)
 		if err != nil {
@@ -363,14 +382,410 @@ func (h *AdminHandler) DeleteUser(c *gin.Context) {
 		}
 	}
 
-	if err := h.repo.Users.Delete(c, id); err != nil {
+	if err := h.repo.Users.Purge(c, id); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при удалении пользователя", err)
 		return
 	}
+	middleware.InvalidateTokenState(id)
 
 	utils.Response(c, http.StatusOK, gin.H{"message": "Пользователь успешно удален"})
 }
 
+// @Summary Разблокировка входа
+// @Description Снимает блокировку входа в аккаунт пользователя после неудачных попыток. Неудачные попытки до этого момента больше не учитываются
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID пользователя"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/users/{id}/unlock [post]
+func (h *AdminHandler) UnlockUserLogin(c *gin.Context) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || roleValue.(models.UserRole) != models.RoleAdmin {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав", nil)
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Неверный формат ID", err)
+		return
+	}
+
+	if _, err := h.repo.Users.GetByID(c, id); err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Пользователь не найден", err)
+		return
+	}
+
+	if err := h.repo.Users.UnlockLogin(c, id); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при разблокировке входа", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message": "Вход пользователя разблокирован",
+	})
+}
+
+// @Summary Попытки входа пользователя
+// @Description Возвращает журнал успешных и неудачных попыток входа пользователя
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID пользователя"
+// @Param page query int false "Номер страницы"
+// @Param limit query int false "Количество записей на странице"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/users/{id}/login-attempts [get]
+func (h *AdminHandler) GetUserLoginAttempts(c *gin.Context) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || roleValue.(models.UserRole) != models.RoleAdmin {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав", nil)
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Неверный формат ID", err)
+		return
+	}
+
+	if _, err := h.repo.Users.GetByID(c, id); err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Пользователь не найден", err)
+		return
+	}
+
+	var page, limit int
+
+	if pageStr := c.Query("page"); pageStr != "" {
+		pageVal, err := strconv.Atoi(pageStr)
+		if err == nil && pageVal > 0 {
+			page = pageVal
+		}
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limitVal, err := strconv.Atoi(limitStr)
+		if err == nil && limitVal > 0 {
+			limit = limitVal
+		}
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+
+	if limit <= 0 {
+		limit = 20
+	}
+
+	attempts, total, err := h.repo.LoginAttempts.GetByUser(c, id, page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении попыток входа", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"attempts": attempts,
+		"pagination": gin.H{
+			"total": total,
+			"page":  page,
+			"limit": limit,
+			"pages": (total + limit - 1) / limit,
+		},
+	})
+}
+
+// @Summary Сессии пользователя
+// @Description Возвращает активные сессии пользователя
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID пользователя"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/users/{id}/sessions [get]
+func (h *AdminHandler) GetUserSessions(c *gin.Context) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || roleValue.(models.UserRole) != models.RoleAdmin {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав", nil)
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Неверный формат ID", err)
+		return
+	}
+
+	if _, err := h.repo.Users.GetByID(c, id); err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Пользователь не найден", err)
+		return
+	}
+
+	sessions, err := h.repo.RefreshTokens.GetSessionsByUser(c, id)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении сессий", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"sessions": sessions,
+	})
+}
+
+// @Summary Завершение сессии пользователя
+// @Description Завершает одну из сессий пользователя
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID пользователя"
+// @Param sessionId path string true "ID сессии"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/users/{id}/sessions/{sessionId} [delete]
+func (h *AdminHandler) RevokeUserSession(c *gin.Context) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || roleValue.(models.UserRole) != models.RoleAdmin {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав", nil)
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Неверный формат ID", err)
+		return
+	}
+
+	sessionID := c.Param("sessionId")
+	if _, err := uuid.Parse(sessionID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Неверный формат ID сессии", err)
+		return
+	}
+
+	deleted, err := h.repo.RefreshTokens.DeleteSession(c, id, sessionID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при завершении сессии", err)
+		return
+	}
+
+	if !deleted {
+		utils.ErrorResponse(c, http.StatusNotFound, "Сессия не найдена", nil)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message": "Сессия завершена",
+	})
+}
+
+// @Summary Завершение всех сессий пользователя
+// @Description Завершает все сессии пользователя и отзывает его access токены, после чего ему потребуется войти заново
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID пользователя"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/users/{id}/sessions [delete]
+func (h *AdminHandler) RevokeUserSessions(c *gin.Context) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || roleValue.(models.UserRole) != models.RoleAdmin {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав", nil)
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Неверный формат ID", err)
+		return
+	}
+
+	if _, err := h.repo.Users.GetByID(c, id); err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Пользователь не найден", err)
+		return
+	}
+
+	if err := h.repo.RefreshTokens.DeleteByUserID(c, id); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при завершении сессий", err)
+		return
+	}
+
+	if err := h.repo.Users.IncrementTokenVersion(c, id); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отзыве access токенов", err)
+		return
+	}
+	middleware.InvalidateTokenState(id)
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message": "Все сессии пользователя завершены",
+	})
+}
+
+// @Summary Список API ключей
+// @Description Возвращает все выданные API ключи, включая отозванные. Сами ключи не возвращаются, только их префиксы
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/api-keys [get]
+func (h *AdminHandler) GetAPIKeys(c *gin.Context) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || roleValue.(models.UserRole) != models.RoleAdmin {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав", nil)
+		return
+	}
+
+	keys, err := h.repo.APIKeys.GetAll(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении API ключей", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"api_keys": keys,
+	})
+}
+
+// @Summary Выдача API ключа
+// @Description Создает API ключ с указанными областями доступа (companies:read, reviews:read), суточной квотой (0 - без ограничения) и сроком действия. Ключ возвращается только в этом ответе
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body models.APIKeyCreateInput true "Параметры ключа"
+// @Success 201 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/api-keys [post]
+func (h *AdminHandler) CreateAPIKey(c *gin.Context) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || roleValue.(models.UserRole) != models.RoleAdmin {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав", nil)
+		return
+	}
+
+	var input models.APIKeyCreateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	for _, scope := range input.Scopes {
+		if !scope.IsValid() {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Некорректная область доступа. Допустимые значения: companies:read, reviews:read", nil)
+			return
+		}
+	}
+
+	if input.ExpiresAt != nil && input.ExpiresAt.Before(time.Now()) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Срок действия ключа должен быть в будущем", nil)
+		return
+	}
+
+	rawKey, prefix, err := utils.GenerateAPIKey()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании API ключа", err)
+		return
+	}
+
+	currentUserID, _ := c.Get(middleware.UserIDKey)
+	key := models.NewAPIKey(input, prefix, utils.HashAPIKey(rawKey), currentUserID.(int))
+
+	key.ID, err = h.repo.APIKeys.Create(c, key)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при сохранении API ключа", err)
+		return
+	}
+
+	utils.Response(c, http.StatusCreated, gin.H{
+		"api_key": key,
+		"key":     rawKey,
+	})
+}
+
+// @Summary Отзыв API ключа
+// @Description Отзывает API ключ, после чего запросы с ним отклоняются
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID ключа"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/api-keys/{id} [delete]
+func (h *AdminHandler) RevokeAPIKey(c *gin.Context) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || roleValue.(models.UserRole) != models.RoleAdmin {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав", nil)
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Неверный формат ID", err)
+		return
+	}
+
+	revoked, err := h.repo.APIKeys.Revoke(c, id)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отзыве API ключа", err)
+		return
+	}
+
+	if !revoked {
+		utils.ErrorResponse(c, http.StatusNotFound, "API ключ не найден или уже отозван", nil)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message": "API ключ отозван",
+	})
+}
+
 // @Summary Создание категории рейтинга
 // @Description Создает новую категорию рейтинга
 // @Tags admin
@@ -567,6 +982,7 @@ func (h *AdminHandler) UpdateReview(c *gin.Context) {
 	}
 
 	review := reviewDetails.Review
+	previousStatus := review.Status
 
 	if input.Position != nil {
 		review.Position = *input.Position
@@ -612,7 +1028,7 @@ func (h *AdminHandler) UpdateReview(c *gin.Context) {
 		return
 	}
 
-	if review.Status == "approved" {
+	if review.Status == models.ReviewStatusApproved || previousStatus == models.ReviewStatusApproved {
 		if err := h.repo.Companies.UpdateRating(c, review.CompanyID); err != nil {
 			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при обновлении рейтинга компании", err)
 			return
@@ -1,10 +1,15 @@
 package handlers
 
 import (
+	"fmt"
+	"log"
 	"net/http"
+	"net/url"
 	"time"
 
 	"job_solition/internal/config"
+	"job_solition/internal/mailer"
+	"job_solition/internal/middleware"
 	"job_solition/internal/models"
 	"job_solition/internal/repository"
 	"job_solition/internal/utils"
@@ -14,23 +19,41 @@ import (
 )
 
 type AuthHandler struct {
-	repo repository.Repository
-	jwt  *utils.JWT
+	repo     repository.Repository
+	cfg      *config.Config
+	jwt      *utils.JWT
+	totp     *utils.TOTP
+	mailer   mailer.Mailer
+	password *utils.PasswordPolicy
 }
 
 func NewAuthHandler(repo *repository.Repository, cfg *config.Config) *AuthHandler {
 	return &AuthHandler{
-		repo: *repo,
-		jwt: utils.NewJWT(
-			cfg.JWT.Secret,
-			cfg.JWT.ExpiresIn,
-			cfg.JWT.RefreshExpiresIn,
-		),
+		repo:     *repo,
+		cfg:      cfg,
+		jwt:      utils.NewJWT(cfg.JWT),
+		totp:     utils.NewTOTP(cfg.Security.TOTPIssuer),
+		mailer:   mailer.New(cfg.Mail),
+		password: utils.NewPasswordPolicy(cfg.Security.PasswordPolicy),
 	}
 }
 
+func (h *AuthHandler) frontendLink(path, token string) string {
+	return fmt.Sprintf("%s%s?token=%s", h.cfg.Server.FrontendURL, path, url.QueryEscape(token))
+}
+
+func (h *AuthHandler) sendVerificationEmail(c *gin.Context, user *models.User) error {
+	verificationToken := models.NewEmailVerificationToken(user.ID, h.cfg.Security.EmailVerificationExpiresIn)
+	if _, err := h.repo.EmailVerifications.Create(c, &verificationToken); err != nil {
+		return err
+	}
+
+	link := h.frontendLink("/verify-email", verificationToken.Token)
+	return h.mailer.Send(c, mailer.EmailVerificationMessage(user.Email, link, h.cfg.Security.EmailVerificationExpiresIn))
+}
+
 // @Summary Регистрация нового пользователя
-// @Description Регистрирует нового пользователя
+// @Description Регистрирует нового пользователя. Пароль проверяется по политике паролей, при нарушении в error.errors перечисляются все невыполненные правила
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -61,6 +84,11 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
+	candidate := &models.User{Email: input.Email, FirstName: input.FirstName, LastName: input.LastName}
+	if !validateNewPassword(c, &h.repo, h.password, input.Password, candidate) {
+		return
+	}
+
 	user, err := models.NewUser(input)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании пользователя", err)
@@ -74,31 +102,26 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	}
 
 	user.ID = userID
+	rememberPassword(c, &h.repo, h.password, user)
 
-	token, err := h.jwt.GenerateToken(user)
-	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании токена", err)
-		return
+	if err := h.sendVerificationEmail(c, user); err != nil {
+		log.Printf("Ошибка при отправке письма подтверждения email пользователю %d: %v", user.ID, err)
 	}
 
-	refreshToken := models.NewRefreshToken(user.ID, h.jwt.RefreshExpiresIn)
-	_, err = h.repo.RefreshTokens.Create(c, &refreshToken)
+	tokens, err := h.issueTokens(c, user, false)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании refresh токена", err)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании токенов", err)
 		return
 	}
 
 	utils.Response(c, http.StatusCreated, gin.H{
-		"user": user.ToProfile(),
-		"tokens": gin.H{
-			"access_token":  token,
-			"refresh_token": refreshToken.Token,
-		},
+		"user":   user.ToProfile(),
+		"tokens": tokens,
 	})
 }
 
 // @Summary Вход в систему
-// @Description Аутентифицирует пользователя и выдает токены
+// @Description Аутентифицирует пользователя и выдает токены. Если у пользователя включена двухфакторная аутентификация, вместо токенов возвращается challenge_token для /auth/login/2fa
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -106,6 +129,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Success 200 {object} utils.ResponseDTO
 // @Failure 400 {object} utils.ErrorResponseDTO
 // @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 429 {object} utils.ErrorResponseDTO
 // @Failure 500 {object} utils.ErrorResponseDTO
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -116,40 +140,91 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	user, err := h.repo.Users.GetByEmail(c, input.Email)
+	if err != nil && err.Error() != "пользователь не найден" {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при поиске пользователя", err)
+		return
+	}
+
+	lockedUntil, err := h.loginLockedUntil(c, input.Email, user)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusUnauthorized, "Неверный email или пароль", nil)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке блокировки входа", err)
 		return
 	}
 
-	if !user.ComparePassword(input.Password) {
-		utils.ErrorResponse(c, http.StatusUnauthorized, "Неверный email или пароль", nil)
+	if lockedUntil != nil {
+		h.respondLoginLocked(c, *lockedUntil)
 		return
 	}
 
-	token, err := h.jwt.GenerateToken(user)
+	if user == nil || !user.ComparePassword(input.Password) {
+		h.rejectLogin(c, input.Email, user, models.LoginFailureInvalidCredentials, "Неверный email или пароль")
+		return
+	}
+
+	h.continueLogin(c, user)
+}
+
+// continueLogin вызывается после успешной проверки первого фактора (пароля, внешнего провайдера и т.п.).
+// Если нужен второй фактор, вместо токенов отдается challenge токен.
+func (h *AuthHandler) continueLogin(c *gin.Context, user *models.User) {
+	if user.IsTwoFactorEnabled() {
+		h.respondTwoFactorChallenge(c, user, utils.ChallengePurposeTwoFactorLogin)
+		return
+	}
+
+	if h.twoFactorRequired(user) {
+		h.respondTwoFactorChallenge(c, user, utils.ChallengePurposeTwoFactorSetup)
+		return
+	}
+
+	h.completeLogin(c, user, false, nil)
+}
+
+// completeLogin начинает новую сессию пользователя и отдает профиль вместе с парой токенов
+// и дополнительными полями extra.
+func (h *AuthHandler) completeLogin(c *gin.Context, user *models.User, twoFactorVerified bool, extra gin.H) {
+	tokens, err := h.issueTokens(c, user, twoFactorVerified)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании токена", err)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании токенов", err)
 		return
 	}
 
+	h.recordLoginAttempt(c, user.Email, user, true, "")
+
+	response := gin.H{
+		"user":   user.ToProfile(),
+		"tokens": tokens,
+	}
+	for key, value := range extra {
+		response[key] = value
+	}
+
+	utils.Response(c, http.StatusOK, response)
+}
+
+// issueTokens создает новое семейство refresh токенов (сессию) и выпускает для него access токен.
+func (h *AuthHandler) issueTokens(c *gin.Context, user *models.User, twoFactorVerified bool) (gin.H, error) {
 	refreshToken := models.NewRefreshToken(user.ID, h.jwt.RefreshExpiresIn)
-	_, err = h.repo.RefreshTokens.Create(c, &refreshToken)
+	refreshToken.SetClient(c.Request.UserAgent(), c.ClientIP())
+	refreshToken.TwoFactorVerified = twoFactorVerified
+
+	if _, err := h.repo.RefreshTokens.Create(c, &refreshToken); err != nil {
+		return nil, err
+	}
+
+	token, err := h.jwt.GenerateToken(user, &refreshToken)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании refresh токена", err)
-		return
+		return nil, err
 	}
 
-	utils.Response(c, http.StatusOK, gin.H{
-		"user": user.ToProfile(),
-		"tokens": gin.H{
-			"access_token":  token,
-			"refresh_token": refreshToken.Token,
-		},
-	})
+	return gin.H{
+		"access_token":  token,
+		"refresh_token": refreshToken.Token,
+	}, nil
 }
 
 // @Summary Обновление токенов
-// @Description Обновляет пару access/refresh токенов
+// @Description Обновляет пару access/refresh токенов. Повторное использование уже замененного refresh токена отзывает все токены этого входа
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -169,12 +244,17 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	}
 
 	refreshToken, err := h.repo.RefreshTokens.GetByToken(c, input.RefreshToken)
-	if err != nil {
+	if err != nil || refreshToken.IsRevoked() {
 		utils.ErrorResponse(c, http.StatusUnauthorized, "Недействительный refresh токен", nil)
 		return
 	}
 
-	if refreshToken.ExpiresAt.Before(time.Now()) {
+	if refreshToken.IsRotated() {
+		h.handleRefreshTokenReuse(c, refreshToken)
+		return
+	}
+
+	if refreshToken.IsExpired() {
 		utils.ErrorResponse(c, http.StatusUnauthorized, "Refresh токен просрочен", nil)
 		return
 	}
@@ -185,25 +265,31 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	err = h.repo.RefreshTokens.DeleteByToken(c, input.RefreshToken)
+	rotated, err := h.repo.RefreshTokens.MarkRotated(c, refreshToken.ID)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при удалении старого refresh токена", err)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при ротации refresh токена", err)
 		return
 	}
 
-	token, err := h.jwt.GenerateToken(user)
-	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании токена", err)
+	if !rotated {
+		h.handleRefreshTokenReuse(c, refreshToken)
 		return
 	}
 
-	newRefreshToken := models.NewRefreshToken(user.ID, h.jwt.RefreshExpiresIn)
+	newRefreshToken := refreshToken.Rotate(h.jwt.RefreshExpiresIn)
+	newRefreshToken.SetClient(c.Request.UserAgent(), c.ClientIP())
 	_, err = h.repo.RefreshTokens.Create(c, &newRefreshToken)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании refresh токена", err)
 		return
 	}
 
+	token, err := h.jwt.GenerateToken(user, &newRefreshToken)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании токена", err)
+		return
+	}
+
 	utils.Response(c, http.StatusOK, gin.H{
 		"tokens": gin.H{
 			"access_token":  token,
@@ -212,6 +298,29 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	})
 }
 
+// handleRefreshTokenReuse вызывается, когда предъявлен уже ротированный refresh токен.
+// Это признак кражи токена, поэтому отзывается все семейство и фиксируется событие безопасности.
+func (h *AuthHandler) handleRefreshTokenReuse(c *gin.Context, refreshToken *models.RefreshToken) {
+	if err := h.repo.RefreshTokens.RevokeFamily(c, refreshToken.FamilyID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отзыве refresh токенов", err)
+		return
+	}
+
+	h.recordSecurityEvent(c, refreshToken.UserID, models.SecurityEventRefreshTokenReuse,
+		fmt.Sprintf("token_id=%d family_id=%s", refreshToken.ID, refreshToken.FamilyID))
+
+	log.Printf("Обнаружено повторное использование refresh токена: пользователь %d, семейство %s", refreshToken.UserID, refreshToken.FamilyID)
+
+	utils.ErrorResponse(c, http.StatusUnauthorized, "Недействительный refresh токен", nil)
+}
+
+func (h *AuthHandler) recordSecurityEvent(c *gin.Context, userID int, eventType models.SecurityEventType, details string) {
+	event := models.NewSecurityEvent(userID, eventType, c.ClientIP(), c.Request.UserAgent(), details)
+	if _, err := h.repo.SecurityEvents.Create(c, event); err != nil {
+		log.Printf("Ошибка при записи события безопасности для пользователя %d: %v", userID, err)
+	}
+}
+
 // @Summary Выход из системы
 // @Description Выход из системы, удаление refresh токена
 // @Tags auth
@@ -231,10 +340,12 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 		return
 	}
 
-	err := h.repo.RefreshTokens.DeleteByToken(c, input.RefreshToken)
-	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при выходе из системы", err)
-		return
+	refreshToken, err := h.repo.RefreshTokens.GetByToken(c, input.RefreshToken)
+	if err == nil {
+		if err := h.repo.RefreshTokens.DeleteByFamily(c, refreshToken.FamilyID); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при выходе из системы", err)
+			return
+		}
 	}
 
 	utils.Response(c, http.StatusOK, gin.H{
@@ -242,15 +353,14 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	})
 }
 
-// @Summary Восстановление пароля
-// @Description Позволяет пользователю сбросить пароль, указав email и новый пароль
+// @Summary Запрос на восстановление пароля
+// @Description Отправляет на email пользователя одноразовую ссылку для сброса пароля. Ответ не зависит от того, существует ли пользователь
 // @Tags auth
 // @Accept json
 // @Produce json
-// @Param input body models.ForgotPasswordInput true "Email пользователя и новый пароль"
+// @Param input body models.ForgotPasswordInput true "Email пользователя"
 // @Success 200 {object} utils.ResponseDTO
 // @Failure 400 {object} utils.ErrorResponseDTO
-// @Failure 404 {object} utils.ErrorResponseDTO
 // @Failure 500 {object} utils.ErrorResponseDTO
 // @Router /auth/forgot-password [post]
 func (h *AuthHandler) ForgotPassword(c *gin.Context) {
@@ -260,14 +370,80 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 		return
 	}
 
+	response := gin.H{
+		"message": "Если пользователь с таким email существует, на него отправлена ссылка для восстановления пароля",
+	}
+
+	user, err := h.repo.Users.GetByEmail(c, input.Email)
+	if err != nil {
+		if err.Error() != "пользователь не найден" {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при поиске пользователя", err)
+			return
+		}
+		utils.Response(c, http.StatusOK, response)
+		return
+	}
+
+	resetToken := models.NewPasswordResetToken(user.ID, h.cfg.Security.PasswordResetExpiresIn)
+	if _, err := h.repo.PasswordResetTokens.Create(c, &resetToken); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании токена сброса пароля", err)
+		return
+	}
+
+	link := h.frontendLink("/reset-password", resetToken.Token)
+	msg := mailer.PasswordResetMessage(user.Email, link, h.cfg.Security.PasswordResetExpiresIn)
+	if err := h.mailer.Send(c, msg); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отправке письма", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, response)
+}
+
+// @Summary Сброс пароля
+// @Description Устанавливает новый пароль по одноразовому токену из письма и завершает все активные сессии пользователя. Новый пароль проверяется по политике паролей и не должен совпадать с недавними
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body models.ResetPasswordInput true "Токен сброса и новый пароль"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /auth/reset-password [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var input models.ResetPasswordInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
 	if input.Password != input.PasswordConfirm {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Пароли не совпадают", nil)
 		return
 	}
 
-	user, err := h.repo.Users.GetByEmail(c, input.Email)
+	resetToken, err := h.repo.PasswordResetTokens.GetByToken(c, input.Token)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Недействительный или просроченный токен", nil)
+		return
+	}
+
+	if resetToken.IsExpired() {
+		if err := h.repo.PasswordResetTokens.DeleteByToken(c, input.Token); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при удалении токена сброса пароля", err)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusBadRequest, "Недействительный или просроченный токен", nil)
+		return
+	}
+
+	user, err := h.repo.Users.GetByID(c, resetToken.UserID)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "Пользователь с указанным email не найден", nil)
+		utils.ErrorResponse(c, http.StatusBadRequest, "Недействительный или просроченный токен", nil)
+		return
+	}
+
+	if !validateNewPassword(c, &h.repo, h.password, input.Password, user) {
 		return
 	}
 
@@ -277,6 +453,17 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 		return
 	}
 
+	// Токен погашается только после проверки нового пароля, чтобы ошибка в пароле не сжигала ссылку.
+	// Из параллельных запросов с одним токеном пароль меняет только тот, кто погасил токен.
+	if _, err := h.repo.PasswordResetTokens.Consume(c, input.Token); err != nil {
+		if err.Error() == "токен сброса пароля не найден" {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Недействительный или просроченный токен", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при удалении токена сброса пароля", err)
+		}
+		return
+	}
+
 	user.PasswordHash = string(passwordHash)
 	user.UpdatedAt = time.Now()
 
@@ -284,13 +471,102 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при обновлении пароля", err)
 		return
 	}
+	rememberPassword(c, &h.repo, h.password, user)
+
+	if err := h.repo.PasswordResetTokens.DeleteByUserID(c, user.ID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при удалении токенов сброса пароля", err)
+		return
+	}
 
 	if err := h.repo.RefreshTokens.DeleteByUserID(c, user.ID); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при удалении refresh токенов", err)
 		return
 	}
 
+	if err := h.repo.Users.IncrementTokenVersion(c, user.ID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отзыве access токенов", err)
+		return
+	}
+	middleware.InvalidateTokenState(user.ID)
+
 	utils.Response(c, http.StatusOK, gin.H{
 		"message": "Пароль успешно изменен",
 	})
 }
+
+// @Summary Подтверждение email
+// @Description Подтверждает email пользователя по токену из письма
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body models.VerifyEmailInput true "Токен подтверждения"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /auth/verify-email [post]
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var input models.VerifyEmailInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	verificationToken, err := h.repo.EmailVerifications.GetByToken(c, input.Token)
+	if err != nil || verificationToken.IsExpired() {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Недействительный или просроченный токен", nil)
+		return
+	}
+
+	if err := h.repo.Users.MarkEmailVerified(c, verificationToken.UserID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при подтверждении email", err)
+		return
+	}
+
+	if err := h.repo.EmailVerifications.DeleteByUserID(c, verificationToken.UserID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при удалении токенов подтверждения email", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message": "Email успешно подтвержден",
+	})
+}
+
+// @Summary Повторная отправка письма подтверждения
+// @Description Повторно отправляет письмо для подтверждения email текущего пользователя
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /auth/resend-verification [post]
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	user, err := h.repo.Users.GetByID(c, userID.(int))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Пользователь не найден", nil)
+		return
+	}
+
+	if user.IsEmailVerified() {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Email уже подтвержден", nil)
+		return
+	}
+
+	if err := h.sendVerificationEmail(c, user); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отправке письма", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message": "Письмо для подтверждения email отправлено",
+	})
+}
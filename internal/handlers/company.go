@@ -8,6 +8,7 @@ import (
 
 	"job_solition/internal/config"
 	"job_solition/internal/db"
+	"job_solition/internal/mailer"
 	"job_solition/internal/middleware"
 	"job_solition/internal/models"
 	"job_solition/internal/repository"
@@ -17,15 +18,17 @@ import (
 )
 
 type CompanyHandler struct {
-	repo *repository.Repository
-	cfg  *config.Config
+	repo   *repository.Repository
+	cfg    *config.Config
+	mailer mailer.Mailer
 }
 
 func NewCompanyHandler(postgres *db.PostgreSQL, cfg *config.Config) *CompanyHandler {
 	repo := repository.NewRepository(postgres)
 	return &CompanyHandler{
-		repo: repo,
-		cfg:  cfg,
+		repo:   repo,
+		cfg:    cfg,
+		mailer: mailer.New(cfg.Mail),
 	}
 }
 
@@ -34,6 +37,7 @@ func NewCompanyHandler(postgres *db.PostgreSQL, cfg *config.Config) *CompanyHand
 // @Tags companies
 // @Accept json
 // @Produce json
+// @Security APIKeyAuth
 // @Param search query string false "Поисковый запрос"
 // @Param industries query []int false "Фильтр по индустриям (может содержать несколько ID индустрий через запятую, например: industries=1,2,3)"
 // @Param size query string false "Фильтр по размеру компании" Enums(small, medium, large, enterprise)
@@ -142,6 +146,7 @@ func (h *CompanyHandler) GetCompanies(c *gin.Context) {
 // @Tags companies
 // @Accept json
 // @Produce json
+// @Security APIKeyAuth
 // @Param id_or_slug path string true "ID или slug компании"
 // @Success 200 {object} utils.ResponseDTO
 // @Failure 400 {object} utils.ErrorResponseDTO
@@ -0,0 +1,637 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"job_solition/internal/mailer"
+	"job_solition/internal/middleware"
+	"job_solition/internal/models"
+	"job_solition/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxClaimCodeAttempts - число неверных вводов кода, после которого заявку нужно подать заново.
+const maxClaimCodeAttempts = 5
+
+func validRepresentativePermissions(permissions []models.RepresentativePermission) bool {
+	for _, permission := range permissions {
+		if !permission.IsValid() {
+			return false
+		}
+	}
+	return true
+}
+
+// @Summary Заявка на представительство компании
+// @Description Создает заявку пользователя на представительство компании. Корпоративный email должен принадлежать домену сайта компании, на него отправляется код подтверждения. После подтверждения кода заявку рассматривает администратор
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID компании"
+// @Param input body models.CompanyClaimInput true "Корпоративный email и комментарий"
+// @Success 201 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 409 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /companies/{id}/claims [post]
+func (h *CompanyHandler) SubmitCompanyClaim(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	var input models.CompanyClaimInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	company, err := h.repo.Companies.GetByID(c, companyID)
+	if err != nil {
+		if err.Error() == "компания не найдена" {
+			utils.ErrorResponse(c, http.StatusNotFound, "Компания не найдена", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении компании", err)
+		}
+		return
+	}
+
+	domain := utils.WebsiteDomain(company.Company.Website)
+	if domain == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "У компании не указан сайт, заявку можно подать только через администратора", nil)
+		return
+	}
+
+	if !utils.EmailMatchesDomain(input.CorporateEmail, domain) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Корпоративный email должен принадлежать домену "+domain, nil)
+		return
+	}
+
+	code, err := utils.GenerateVerificationCode()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании кода подтверждения", err)
+		return
+	}
+
+	claim := models.NewCompanyClaim(userID.(int), companyID, input, utils.HashVerificationCode(code), h.cfg.Security.RepresentativeCodeExpires)
+	if err := h.repo.Representatives.SubmitClaim(c, &claim); err != nil {
+		if err.Error() == "заявка уже подана" {
+			utils.ErrorResponse(c, http.StatusConflict, "Заявка уже на рассмотрении или вы уже представляете компанию", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при сохранении заявки", err)
+		}
+		return
+	}
+
+	msg := mailer.RepresentativeClaimCodeMessage(*claim.CorporateEmail, company.Company.Name, code, h.cfg.Security.RepresentativeCodeExpires)
+	if err := h.mailer.Send(c, msg); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отправке письма", err)
+		return
+	}
+
+	utils.Response(c, http.StatusCreated, gin.H{
+		"message": "На корпоративный email отправлен код подтверждения",
+		"claim":   claim,
+	})
+}
+
+// @Summary Подтверждение корпоративного email заявки
+// @Description Проверяет код, отправленный на корпоративный email, и передает заявку на рассмотрение администратору. После нескольких неверных попыток заявку нужно подать заново
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID компании"
+// @Param input body models.CompanyClaimConfirmInput true "Код подтверждения"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /companies/{id}/claims/confirm [post]
+func (h *CompanyHandler) ConfirmCompanyClaim(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	var input models.CompanyClaimConfirmInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	claim, err := h.repo.Representatives.GetByUserAndCompany(c, userID.(int), companyID)
+	if err != nil {
+		if err.Error() == "заявка не найдена" {
+			utils.ErrorResponse(c, http.StatusNotFound, "Заявка не найдена", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении заявки", err)
+		}
+		return
+	}
+
+	if claim.Status != models.CompanyRepresentativeStatusUnconfirmed {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Заявка не ожидает подтверждения email", nil)
+		return
+	}
+
+	if claim.IsCodeExpired() || claim.CodeAttempts >= maxClaimCodeAttempts {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Код подтверждения больше не действует, подайте заявку заново", nil)
+		return
+	}
+
+	// Проверка кода и смена статуса выполняются одним запросом, чтобы параллельные запросы
+	// не могли подобрать код сверх лимита попыток.
+	confirmed, err := h.repo.Representatives.ConfirmEmail(c, claim.ID, utils.HashVerificationCode(input.Code), maxClaimCodeAttempts)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при подтверждении заявки", err)
+		return
+	}
+
+	if !confirmed {
+		attempts, err := h.repo.Representatives.RecordCodeAttempt(c, claim.ID)
+		if err != nil {
+			if err.Error() == "заявка не ожидает подтверждения email" {
+				utils.ErrorResponse(c, http.StatusBadRequest, "Заявка не ожидает подтверждения email", nil)
+			} else {
+				utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке кода", err)
+			}
+			return
+		}
+
+		utils.ErrorResponseWithDetails(c, http.StatusBadRequest, "Неверный код подтверждения", gin.H{
+			"attempts_left": max(maxClaimCodeAttempts-attempts, 0),
+		})
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message": "Корпоративный email подтвержден, заявка передана на рассмотрение администратору",
+	})
+}
+
+// @Summary Обновление профиля компании представителем
+// @Description Меняет логотип и контактные данные компании. Доступно подтвержденным представителям компании с правом company:update
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID компании"
+// @Param input body models.CompanyProfileUpdateInput true "Изменяемые поля"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /companies/{id}/profile [put]
+func (h *CompanyHandler) UpdateCompanyProfile(c *gin.Context) {
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	if !h.requireRepresentative(c, companyID, models.RepresentativePermissionCompanyUpdate) {
+		return
+	}
+
+	var input models.CompanyProfileUpdateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	company, err := h.repo.Companies.GetByID(c, companyID)
+	if err != nil {
+		if err.Error() == "компания не найдена" {
+			utils.ErrorResponse(c, http.StatusNotFound, "Компания не найдена", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении компании", err)
+		}
+		return
+	}
+
+	if input.Logo != nil {
+		company.Company.Logo = *input.Logo
+	}
+	if input.Email != nil {
+		company.Company.Email = *input.Email
+	}
+	if input.Phone != nil {
+		company.Company.Phone = *input.Phone
+	}
+	if input.Address != nil {
+		company.Company.Address = *input.Address
+	}
+
+	company.Company.UpdatedAt = time.Now()
+
+	if err := h.repo.Companies.Update(c, &company.Company); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при обновлении компании", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, company)
+}
+
+// requireRepresentative проверяет, что текущий пользователь - представитель компании с правом permission.
+// При отказе ответ уже отправлен.
+func (h *CompanyHandler) requireRepresentative(c *gin.Context, companyID int, permission models.RepresentativePermission) bool {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return false
+	}
+
+	allowed, err := h.repo.Representatives.HasPermission(c, userID.(int), companyID, permission)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке прав представителя компании", err)
+		return false
+	}
+
+	if !allowed {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав представителя компании", nil)
+		return false
+	}
+
+	return true
+}
+
+// @Summary Мои заявки на представительство компаний
+// @Description Возвращает заявки текущего пользователя на представительство компаний с их статусами и выданными правами
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/companies [get]
+func (h *UserHandler) GetCompanyClaims(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	claims, err := h.repo.Representatives.GetByUser(c, userID.(int))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении заявок", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, claims)
+}
+
+// @Summary Заявки на представительство компаний
+// @Description Возвращает заявки на представительство компаний. По умолчанию - ожидающие решения администратора
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Статус заявки" Enums(unconfirmed, pending, verified, rejected, revoked)
+// @Param company_id query int false "ID компании"
+// @Param page query int false "Номер страницы"
+// @Param limit query int false "Количество записей на странице"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/representatives [get]
+func (h *AdminHandler) GetCompanyClaims(c *gin.Context) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || roleValue.(models.UserRole) != models.RoleAdmin {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав", nil)
+		return
+	}
+
+	var filter models.CompanyClaimFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	if filter.Status == nil {
+		status := models.CompanyRepresentativeStatusPending
+		filter.Status = &status
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 10
+	}
+
+	claims, total, err := h.repo.Representatives.GetAll(c, filter)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении заявок", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"claims": claims,
+		"pagination": gin.H{
+			"total": total,
+			"page":  filter.Page,
+			"limit": filter.Limit,
+			"pages": (total + filter.Limit - 1) / filter.Limit,
+		},
+	})
+}
+
+// @Summary Одобрение заявки на представительство
+// @Description Одобряет заявку с подтвержденным корпоративным email. Если права не переданы, представитель получает все права в рамках компании
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param claimId path int true "ID заявки"
+// @Param input body models.CompanyClaimModerationInput false "Права и комментарий"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/representatives/{claimId}/approve [put]
+func (h *AdminHandler) ApproveCompanyClaim(c *gin.Context) {
+	h.reviewCompanyClaim(c, models.CompanyRepresentativeStatusVerified)
+}
+
+// @Summary Отклонение заявки на представительство
+// @Description Отклоняет заявку на представительство компании. Пользователь может подать новую заявку
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param claimId path int true "ID заявки"
+// @Param input body models.CompanyClaimModerationInput false "Причина отклонения"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/representatives/{claimId}/reject [put]
+func (h *AdminHandler) RejectCompanyClaim(c *gin.Context) {
+	h.reviewCompanyClaim(c, models.CompanyRepresentativeStatusRejected)
+}
+
+func (h *AdminHandler) reviewCompanyClaim(c *gin.Context, status models.CompanyRepresentativeStatus) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || roleValue.(models.UserRole) != models.RoleAdmin {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав", nil)
+		return
+	}
+
+	id, err := utils.ParseIDParam(c, "claimId")
+	if err != nil {
+		return
+	}
+
+	var input models.CompanyClaimModerationInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+			return
+		}
+	}
+
+	if !validRepresentativePermissions(input.Permissions) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Недопустимые права представителя", nil)
+		return
+	}
+
+	claim, err := h.repo.Representatives.GetByID(c, id)
+	if err != nil {
+		if err.Error() == "заявка не найдена" {
+			utils.ErrorResponse(c, http.StatusNotFound, "Заявка не найдена", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении заявки", err)
+		}
+		return
+	}
+
+	if claim.Status != models.CompanyRepresentativeStatusPending {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Заявка не ожидает рассмотрения", nil)
+		return
+	}
+
+	adminID := c.GetInt(middleware.UserIDKey)
+
+	if status == models.CompanyRepresentativeStatusRejected {
+		claim, err = h.repo.Representatives.Reject(c, id, adminID, input.Comment)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отклонении заявки", err)
+			return
+		}
+
+		utils.Response(c, http.StatusOK, gin.H{
+			"message": "Заявка отклонена",
+			"claim":   claim,
+		})
+		return
+	}
+
+	claim, err = h.repo.Representatives.Approve(c, id, adminID, models.NewRepresentativePermissions(input.Permissions), input.Comment)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при одобрении заявки", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message": "Заявка одобрена",
+		"claim":   claim,
+	})
+}
+
+// @Summary Представители компании
+// @Description Возвращает заявки и представителей компании во всех статусах
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID компании"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/companies/{id}/representatives [get]
+func (h *AdminHandler) GetCompanyRepresentatives(c *gin.Context) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || roleValue.(models.UserRole) != models.RoleAdmin {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав", nil)
+		return
+	}
+
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	if _, err := h.repo.Companies.GetByID(c, companyID); err != nil {
+		if err.Error() == "компания не найдена" {
+			utils.ErrorResponse(c, http.StatusNotFound, "Компания не найдена", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении компании", err)
+		}
+		return
+	}
+
+	representatives, err := h.repo.Representatives.GetByCompany(c, companyID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении представителей компании", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, representatives)
+}
+
+// @Summary Назначение представителя компании
+// @Description Делает пользователя подтвержденным представителем компании без заявки и подтверждения корпоративного email. Если права не переданы, выдаются все
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID компании"
+// @Param input body models.CompanyRepresentativeInput true "Пользователь"
+// @Success 201 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/companies/{id}/representatives [post]
+func (h *AdminHandler) AddCompanyRepresentative(c *gin.Context) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || roleValue.(models.UserRole) != models.RoleAdmin {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав", nil)
+		return
+	}
+
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	var input models.CompanyRepresentativeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	if _, err := h.repo.Companies.GetByID(c, companyID); err != nil {
+		if err.Error() == "компания не найдена" {
+			utils.ErrorResponse(c, http.StatusNotFound, "Компания не найдена", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении компании", err)
+		}
+		return
+	}
+
+	user, err := h.repo.Users.GetByID(c, input.UserID)
+	if err != nil {
+		if err.Error() == "пользователь не найден" {
+			utils.ErrorResponse(c, http.StatusNotFound, "Пользователь не найден", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении пользователя", err)
+		}
+		return
+	}
+
+	if user.IsSystem {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Служебную учетную запись нельзя назначить представителем", nil)
+		return
+	}
+
+	if !validRepresentativePermissions(input.Permissions) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Недопустимые права представителя", nil)
+		return
+	}
+
+	now := time.Now()
+	adminID := c.GetInt(middleware.UserIDKey)
+	representative := models.CompanyRepresentative{
+		UserID:      user.ID,
+		CompanyID:   companyID,
+		Permissions: models.NewRepresentativePermissions(input.Permissions),
+		VerifiedBy:  &adminID,
+		CreatedAt:   now,
+		VerifiedAt:  &now,
+	}
+
+	if _, err := h.repo.Representatives.Grant(c, &representative); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при назначении представителя компании", err)
+		return
+	}
+
+	utils.Response(c, http.StatusCreated, representative)
+}
+
+// @Summary Отзыв полномочий представителя компании
+// @Description Лишает пользователя статуса представителя компании. Опубликованные им ответы на отзывы сохраняются
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID компании"
+// @Param userId path int true "ID пользователя"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/companies/{id}/representatives/{userId} [delete]
+func (h *AdminHandler) RevokeCompanyRepresentative(c *gin.Context) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || roleValue.(models.UserRole) != models.RoleAdmin {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав", nil)
+		return
+	}
+
+	companyID, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	userID, err := utils.ParseIDParam(c, "userId")
+	if err != nil {
+		return
+	}
+
+	revoked, err := h.repo.Representatives.Revoke(c, companyID, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отзыве полномочий представителя компании", err)
+		return
+	}
+
+	if !revoked {
+		utils.ErrorResponse(c, http.StatusNotFound, "Представитель компании не найден", nil)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{"message": "Полномочия представителя компании отозваны"})
+}
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"job_solition/internal/export"
+	"job_solition/internal/middleware"
+	"job_solition/internal/models"
+	"job_solition/internal/repository"
+	"job_solition/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const dataExportDownloadPath = "/api/users/exports/"
+
+// startDataExport создает запрос на выгрузку данных пользователя userID и запускает сборку архива в фоне.
+// Пока предыдущий архив собирается, новый запрос не принимается. Сборка, не завершившаяся за
+// export.PendingTimeout, считается неудавшейся и не мешает новому запросу.
+func startDataExport(c *gin.Context, repo *repository.Repository, exporter *export.Builder, userID, requestedBy int) (*models.DataExport, bool) {
+	if err := repo.DataExports.FailStale(c, time.Now().Add(-export.PendingTimeout)); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке выгрузок данных", err)
+		return nil, false
+	}
+
+	dataExport := models.NewDataExport(userID, requestedBy)
+	if _, err := repo.DataExports.Create(c, &dataExport); err != nil {
+		if err.Error() == "выгрузка данных уже готовится" {
+			utils.ErrorResponse(c, http.StatusConflict, "Выгрузка данных уже готовится", nil)
+			return nil, false
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании выгрузки данных", err)
+		return nil, false
+	}
+
+	details := ""
+	if requestedBy != userID {
+		details = fmt.Sprintf("запрошено администратором %d", requestedBy)
+	}
+	event := models.NewSecurityEvent(userID, models.SecurityEventDataExported, c.ClientIP(), c.Request.UserAgent(), details)
+	if _, err := repo.SecurityEvents.Create(c, event); err != nil {
+		log.Printf("Ошибка при записи события безопасности для пользователя %d: %v", userID, err)
+	}
+
+	go exporter.Run(dataExport)
+
+	return &dataExport, true
+}
+
+// withDownloadURLs проставляет ссылки на скачивание готовым выгрузкам с действующим сроком.
+func withDownloadURLs(exports []models.DataExport) []models.DataExport {
+	for i := range exports {
+		if exports[i].IsDownloadable() {
+			exports[i].DownloadURL = dataExportDownloadPath + exports[i].Token
+		}
+	}
+	return exports
+}
+
+// @Summary Запрос выгрузки данных
+// @Description Запускает сборку архива со всеми персональными данными текущего пользователя: профиль, отзывы с оценками по категориям и льготами, отметки полезности, предложения, сессии и история входов. Каждый раздел выгружается в JSON и CSV. Статус и ссылка на скачивание доступны в /users/me/exports
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 202 {object} utils.ResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 409 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/export [post]
+func (h *UserHandler) RequestDataExport(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	dataExport, ok := startDataExport(c, h.repo, h.exporter, userID.(int), userID.(int))
+	if !ok {
+		return
+	}
+
+	utils.Response(c, http.StatusAccepted, gin.H{
+		"message": "Архив с данными готовится",
+		"export":  dataExport,
+	})
+}
+
+// @Summary Выгрузки данных пользователя
+// @Description Возвращает запросы на выгрузку данных текущего пользователя. У готовых архивов указана ссылка на скачивание, которая действует ограниченное время
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/exports [get]
+func (h *UserHandler) GetDataExports(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	exports, err := h.repo.DataExports.GetByUser(c, userID.(int))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении выгрузок данных", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, withDownloadURLs(exports))
+}
+
+// @Summary Скачивание выгрузки данных
+// @Description Отдает zip-архив с данными пользователя по токену из ссылки на скачивание
+// @Tags users
+// @Produce application/zip
+// @Param token path string true "Токен ссылки на скачивание"
+// @Success 200 {file} file
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 410 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/exports/{token} [get]
+func (h *UserHandler) DownloadDataExport(c *gin.Context) {
+	dataExport, err := h.repo.DataExports.GetByToken(c, c.Param("token"))
+	if err != nil {
+		if err.Error() == "выгрузка не найдена" {
+			utils.ErrorResponse(c, http.StatusNotFound, "Выгрузка не найдена", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении выгрузки данных", err)
+		}
+		return
+	}
+
+	if dataExport.Status != models.DataExportStatusReady {
+		utils.ErrorResponse(c, http.StatusNotFound, "Архив еще не готов", nil)
+		return
+	}
+
+	if !dataExport.IsDownloadable() {
+		utils.ErrorResponse(c, http.StatusGone, "Срок действия ссылки истек", nil)
+		return
+	}
+
+	filename := fmt.Sprintf("jobsolution-data-%d-%s.zip", dataExport.UserID, dataExport.CreatedAt.Format("20060102"))
+	c.Header("Content-Disposition", "attachment; filename="+strconv.Quote(filename))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "application/zip", dataExport.Archive)
+}
+
+// @Summary Выгрузка данных пользователя
+// @Description Запускает сборку архива с персональными данными указанного пользователя. Запрос фиксируется в журнале событий безопасности пользователя
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID пользователя"
+// @Success 202 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 409 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/users/{id}/export [post]
+func (h *AdminHandler) RequestUserDataExport(c *gin.Context) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || roleValue.(models.UserRole) != models.RoleAdmin {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав", nil)
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Неверный формат ID", err)
+		return
+	}
+
+	if _, err := h.repo.Users.GetByID(c, id); err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Пользователь не найден", err)
+		return
+	}
+
+	adminID, _ := c.Get(middleware.UserIDKey)
+	dataExport, ok := startDataExport(c, h.repo, h.exporter, id, adminID.(int))
+	if !ok {
+		return
+	}
+
+	utils.Response(c, http.StatusAccepted, gin.H{
+		"message": "Архив с данными готовится",
+		"export":  dataExport,
+	})
+}
+
+// @Summary Выгрузки данных пользователя
+// @Description Возвращает запросы на выгрузку данных указанного пользователя со ссылками на скачивание готовых архивов
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID пользователя"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/users/{id}/exports [get]
+func (h *AdminHandler) GetUserDataExports(c *gin.Context) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || roleValue.(models.UserRole) != models.RoleAdmin {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав", nil)
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Неверный формат ID", err)
+		return
+	}
+
+	exports, err := h.repo.DataExports.GetByUser(c, id)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении выгрузок данных", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, withDownloadURLs(exports))
+}
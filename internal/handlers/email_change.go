@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"job_solition/internal/mailer"
+	"job_solition/internal/middleware"
+	"job_solition/internal/models"
+	"job_solition/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// emailTaken сообщает, занят ли email другой учетной записью.
+func (h *AuthHandler) emailTaken(c *gin.Context, email string) (bool, error) {
+	if _, err := h.repo.Users.GetByEmail(c, email); err != nil {
+		if err.Error() == "пользователь не найден" {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// @Summary Запрос на смену email
+// @Description Отправляет ссылку подтверждения на новый адрес и уведомление со ссылкой отмены на текущий. Email меняется только после подтверждения с нового адреса
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body models.EmailChangeInput true "Новый email и текущий пароль"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 409 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/email [post]
+func (h *AuthHandler) RequestEmailChange(c *gin.Context) {
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	var input models.EmailChangeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	if !user.ComparePassword(input.Password) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Неверный пароль", nil)
+		return
+	}
+
+	if strings.EqualFold(input.NewEmail, user.Email) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Новый email совпадает с текущим", nil)
+		return
+	}
+
+	taken, err := h.emailTaken(c, input.NewEmail)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке существующего пользователя", err)
+		return
+	}
+	if taken {
+		utils.ErrorResponse(c, http.StatusConflict, "Пользователь с таким email уже существует", nil)
+		return
+	}
+
+	request := models.NewEmailChangeRequest(user.ID, input.NewEmail, h.cfg.Security.EmailChangeExpiresIn)
+	if _, err := h.repo.EmailChanges.Create(c, &request); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании запроса на смену email", err)
+		return
+	}
+
+	confirmLink := h.frontendLink("/confirm-email-change", request.Token)
+	msg := mailer.EmailChangeConfirmationMessage(request.NewEmail, confirmLink, h.cfg.Security.EmailChangeExpiresIn)
+	if err := h.mailer.Send(c, msg); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отправке письма", err)
+		return
+	}
+
+	cancelLink := h.frontendLink("/cancel-email-change", request.CancelToken)
+	if err := h.mailer.Send(c, mailer.EmailChangeNoticeMessage(user.Email, request.NewEmail, cancelLink)); err != nil {
+		log.Printf("Ошибка при отправке уведомления о смене email пользователю %d: %v", user.ID, err)
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message": "На новый email отправлена ссылка для подтверждения",
+	})
+}
+
+// @Summary Подтверждение смены email
+// @Description Меняет email пользователя по токену из письма на новый адрес. Все сессии пользователя завершаются, выданные access токены перестают действовать
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body models.EmailChangeTokenInput true "Токен подтверждения"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 409 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /auth/confirm-email-change [post]
+func (h *AuthHandler) ConfirmEmailChange(c *gin.Context) {
+	var input models.EmailChangeTokenInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	request, err := h.repo.EmailChanges.ConsumeByToken(c, input.Token)
+	if err != nil {
+		if err.Error() != "запрос на смену email не найден" {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке токена", err)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusBadRequest, "Недействительный или просроченный токен", nil)
+		return
+	}
+
+	if request.IsExpired() {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Недействительный или просроченный токен", nil)
+		return
+	}
+
+	user, err := h.repo.Users.GetByID(c, request.UserID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Недействительный или просроченный токен", nil)
+		return
+	}
+
+	taken, err := h.emailTaken(c, request.NewEmail)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке существующего пользователя", err)
+		return
+	}
+	if taken {
+		utils.ErrorResponse(c, http.StatusConflict, "Пользователь с таким email уже существует", nil)
+		return
+	}
+
+	if err := h.repo.Users.UpdateEmail(c, user.ID, request.NewEmail); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при изменении email", err)
+		return
+	}
+
+	if err := h.repo.RefreshTokens.DeleteByUserID(c, user.ID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при удалении refresh токенов", err)
+		return
+	}
+
+	if err := h.repo.Users.IncrementTokenVersion(c, user.ID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отзыве access токенов", err)
+		return
+	}
+	middleware.InvalidateTokenState(user.ID)
+
+	// Ссылки, отправленные на прежний адрес, больше не должны работать.
+	if err := h.repo.PasswordResetTokens.DeleteByUserID(c, user.ID); err != nil {
+		log.Printf("Ошибка при удалении токенов сброса пароля пользователя %d: %v", user.ID, err)
+	}
+	if err := h.repo.MagicLinkTokens.DeleteByUserID(c, user.ID); err != nil {
+		log.Printf("Ошибка при удалении токенов входа пользователя %d: %v", user.ID, err)
+	}
+	if err := h.repo.EmailVerifications.DeleteByUserID(c, user.ID); err != nil {
+		log.Printf("Ошибка при удалении токенов подтверждения email пользователя %d: %v", user.ID, err)
+	}
+
+	h.recordSecurityEvent(c, user.ID, models.SecurityEventEmailChanged, fmt.Sprintf("%s -> %s", user.Email, request.NewEmail))
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message": "Email успешно изменен. Войдите заново с новым адресом",
+	})
+}
+
+// @Summary Отмена смены email
+// @Description Отменяет запрос на смену email по токену из уведомления, отправленного на прежний адрес
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body models.EmailChangeTokenInput true "Токен отмены"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /auth/cancel-email-change [post]
+func (h *AuthHandler) CancelEmailChange(c *gin.Context) {
+	var input models.EmailChangeTokenInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	if _, err := h.repo.EmailChanges.ConsumeByCancelToken(c, input.Token); err != nil {
+		if err.Error() != "запрос на смену email не найден" {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отмене смены email", err)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusBadRequest, "Запрос на смену email не найден или уже выполнен", nil)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message": "Смена email отменена",
+	})
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+
+	"job_solition/internal/config"
+	"job_solition/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JWKSHandler struct {
+	jwt *utils.JWT
+}
+
+func NewJWKSHandler(cfg *config.Config) *JWKSHandler {
+	return &JWKSHandler{
+		jwt: utils.NewJWT(cfg.JWT),
+	}
+}
+
+// @Summary Открытые ключи JWT
+// @Description Возвращает открытые ключи для проверки подписи access токенов в формате JWKS (RFC 7517). Access токены содержат aud job_solition:access: токены с другой аудиторией (например, job_solition:challenge у промежуточного шага входа) нельзя принимать как access токены. Ответ не оборачивается в стандартный формат API
+// @Tags auth
+// @Produce json
+// @Success 200 {object} utils.JWKSet
+// @Router /.well-known/jwks.json [get]
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.jwt.JWKS())
+}
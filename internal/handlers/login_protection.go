@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"job_solition/internal/models"
+	"job_solition/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// loginLockedUntil возвращает время окончания блокировки входа по email или по IP-адресу клиента.
+// nil означает, что вход разрешен. user может быть nil, если аккаунта с таким email нет.
+func (h *AuthHandler) loginLockedUntil(c *gin.Context, email string, user *models.User) (*time.Time, error) {
+	lockout := h.cfg.Security.LoginLockout
+	now := time.Now()
+	since := now.Add(-lockout.Window)
+
+	accountSince := since
+	if user != nil && user.LoginUnlockedAt != nil && user.LoginUnlockedAt.After(since) {
+		accountSince = *user.LoginUnlockedAt
+	}
+
+	accountStats, err := h.repo.LoginAttempts.GetFailureStatsByEmail(c, email, accountSince)
+	if err != nil {
+		return nil, err
+	}
+
+	ipStats, err := h.repo.LoginAttempts.GetFailureStatsByIP(c, c.ClientIP(), since)
+	if err != nil {
+		return nil, err
+	}
+
+	var lockedUntil *time.Time
+	for _, until := range []*time.Time{
+		accountStats.LockedUntil(lockout.Threshold, lockout.BaseDuration, lockout.MaxDuration),
+		ipStats.LockedUntil(lockout.IPThreshold, lockout.BaseDuration, lockout.MaxDuration),
+	} {
+		if until != nil && until.After(now) && (lockedUntil == nil || until.After(*lockedUntil)) {
+			lockedUntil = until
+		}
+	}
+
+	return lockedUntil, nil
+}
+
+func (h *AuthHandler) respondLoginLocked(c *gin.Context, lockedUntil time.Time) {
+	retryAfter := int(math.Ceil(time.Until(lockedUntil).Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	utils.ErrorResponseWithDetails(c, http.StatusTooManyRequests, "Слишком много неудачных попыток входа. Вход временно заблокирован", gin.H{
+		"locked_until": lockedUntil,
+		"retry_after":  retryAfter,
+	})
+}
+
+func (h *AuthHandler) recordLoginAttempt(c *gin.Context, email string, user *models.User, success bool, reason models.LoginFailureReason) {
+	var userID *int
+	if user != nil {
+		userID = &user.ID
+	}
+
+	attempt := models.NewLoginAttempt(userID, email, c.ClientIP(), c.Request.UserAgent(), success, reason)
+	if _, err := h.repo.LoginAttempts.Create(c, attempt); err != nil {
+		log.Printf("Ошибка при записи попытки входа для %s: %v", attempt.Email, err)
+	}
+}
+
+// rejectLogin фиксирует неудачную попытку входа и отвечает 401, либо 429, если эта попытка привела к блокировке.
+func (h *AuthHandler) rejectLogin(c *gin.Context, email string, user *models.User, reason models.LoginFailureReason, message string) {
+	h.recordLoginAttempt(c, email, user, false, reason)
+
+	lockedUntil, err := h.loginLockedUntil(c, email, user)
+	if err != nil {
+		log.Printf("Ошибка при проверке блокировки входа для %s: %v", email, err)
+	}
+
+	if lockedUntil != nil {
+		h.respondLoginLocked(c, *lockedUntil)
+		return
+	}
+
+	utils.ErrorResponse(c, http.StatusUnauthorized, message, nil)
+}
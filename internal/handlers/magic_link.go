@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"job_solition/internal/mailer"
+	"job_solition/internal/models"
+	"job_solition/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Запрос ссылки для входа
+// @Description Отправляет на email пользователя одноразовую ссылку для входа без пароля. Ответ не зависит от того, существует ли пользователь
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body models.MagicLinkRequestInput true "Email пользователя"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /auth/magic-link [post]
+func (h *AuthHandler) RequestMagicLink(c *gin.Context) {
+	var input models.MagicLinkRequestInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	response := gin.H{
+		"message": "Если пользователь с таким email существует, на него отправлена ссылка для входа",
+	}
+
+	user, err := h.repo.Users.GetByEmail(c, input.Email)
+	if err != nil {
+		if err.Error() != "пользователь не найден" {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при поиске пользователя", err)
+			return
+		}
+		utils.Response(c, http.StatusOK, response)
+		return
+	}
+
+	magicLinkToken := models.NewMagicLinkToken(user.ID, h.cfg.Security.MagicLinkExpiresIn)
+	if _, err := h.repo.MagicLinkTokens.Create(c, &magicLinkToken); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании токена входа", err)
+		return
+	}
+
+	link := h.frontendLink("/magic-login", magicLinkToken.Token)
+	msg := mailer.MagicLinkMessage(user.Email, link, h.cfg.Security.MagicLinkExpiresIn)
+	if err := h.mailer.Send(c, msg); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отправке письма", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, response)
+}
+
+// @Summary Вход по ссылке
+// @Description Обменивает одноразовый токен из письма на пару токенов, как при обычном входе. Email пользователя считается подтвержденным. Если у пользователя включена двухфакторная аутентификация, вместо токенов возвращается challenge_token для /auth/login/2fa
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body models.MagicLinkLoginInput true "Токен из письма"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /auth/magic-link/login [post]
+func (h *AuthHandler) MagicLinkLogin(c *gin.Context) {
+	var input models.MagicLinkLoginInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	magicLinkToken, err := h.repo.MagicLinkTokens.Consume(c, input.Token)
+	if err != nil {
+		if err.Error() != "токен входа не найден" {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке токена входа", err)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusBadRequest, "Недействительная или просроченная ссылка для входа", nil)
+		return
+	}
+
+	if magicLinkToken.IsExpired() {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Недействительная или просроченная ссылка для входа", nil)
+		return
+	}
+
+	user, err := h.repo.Users.GetByID(c, magicLinkToken.UserID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Недействительная или просроченная ссылка для входа", nil)
+		return
+	}
+
+	if !user.IsEmailVerified() {
+		if err := h.repo.Users.MarkEmailVerified(c, user.ID); err != nil {
+			log.Printf("Ошибка при подтверждении email пользователя %d после входа по ссылке: %v", user.ID, err)
+		} else {
+			verifiedAt := time.Now()
+			user.EmailVerifiedAt = &verifiedAt
+
+			if err := h.repo.EmailVerifications.DeleteByUserID(c, user.ID); err != nil {
+				log.Printf("Ошибка при удалении токенов подтверждения email пользователя %d: %v", user.ID, err)
+			}
+		}
+	}
+
+	h.continueLogin(c, user)
+}
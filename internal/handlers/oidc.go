@@ -0,0 +1,389 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"job_solition/internal/config"
+	"job_solition/internal/middleware"
+	"job_solition/internal/models"
+	"job_solition/internal/oidc"
+	"job_solition/internal/repository"
+	"job_solition/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OIDCHandler struct {
+	repo      repository.Repository
+	cfg       *config.Config
+	providers oidc.Registry
+	auth      *AuthHandler
+}
+
+func NewOIDCHandler(repo *repository.Repository, cfg *config.Config) *OIDCHandler {
+	return &OIDCHandler{
+		repo:      *repo,
+		cfg:       cfg,
+		providers: oidc.NewRegistry(cfg.OIDC),
+		auth:      NewAuthHandler(repo, cfg),
+	}
+}
+
+func (h *OIDCHandler) provider(c *gin.Context) (*oidc.Provider, bool) {
+	provider, ok := h.providers[c.Param("provider")]
+	if !ok {
+		utils.ErrorResponse(c, http.StatusNotFound, "Провайдер входа не найден", nil)
+		return nil, false
+	}
+	return provider, true
+}
+
+// authorizationURL сохраняет state и PKCE verifier и возвращает адрес страницы входа провайдера.
+// Если userID задан, после возврата от провайдера аккаунт будет привязан к этому пользователю.
+func (h *OIDCHandler) authorizationURL(c *gin.Context, provider *oidc.Provider, userID *int) (string, error) {
+	verifier, challenge, err := oidc.NewPKCE()
+	if err != nil {
+		return "", err
+	}
+
+	state := models.NewOAuthState(provider.Name(), verifier, userID, h.cfg.OIDC.StateExpiresIn)
+	if err := h.repo.OAuthStates.Create(c, &state); err != nil {
+		return "", err
+	}
+
+	return provider.AuthCodeURL(state.State, challenge), nil
+}
+
+// @Summary Провайдеры входа
+// @Description Возвращает список настроенных внешних провайдеров входа
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Success 200 {object} utils.ResponseDTO
+// @Router /auth/oidc/providers [get]
+func (h *OIDCHandler) GetProviders(c *gin.Context) {
+	utils.Response(c, http.StatusOK, gin.H{
+		"providers": h.providers.Names(),
+	})
+}
+
+// @Summary Вход через внешнего провайдера
+// @Description Перенаправляет пользователя на страницу входа провайдера. После входа провайдер вернет пользователя на фронтенд с параметрами code и state
+// @Tags auth
+// @Param provider path string true "Имя провайдера"
+// @Success 302
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /auth/oidc/{provider}/authorize [get]
+func (h *OIDCHandler) Authorize(c *gin.Context) {
+	provider, ok := h.provider(c)
+	if !ok {
+		return
+	}
+
+	authURL, err := h.authorizationURL(c, provider, nil)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при начале входа через провайдера", err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// @Summary Завершение входа через внешнего провайдера
+// @Description Обменивает code от провайдера на данные пользователя. Находит привязанного пользователя, привязывает аккаунт по подтвержденному email или создает нового пользователя, после чего выдает токены как при обычном входе. Если вход начат привязкой аккаунта в профиле, запрос должен содержать access токен того же пользователя
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param provider path string true "Имя провайдера"
+// @Param input body models.OIDCCallbackInput true "Code и state из адреса возврата"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 409 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Failure 502 {object} utils.ErrorResponseDTO
+// @Router /auth/oidc/{provider}/callback [post]
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	provider, ok := h.provider(c)
+	if !ok {
+		return
+	}
+
+	var input models.OIDCCallbackInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	state, err := h.repo.OAuthStates.Consume(c, input.State)
+	if err != nil || state.Provider != provider.Name() || state.IsExpired() {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Недействительный или просроченный state", nil)
+		return
+	}
+
+	// Привязку завершает только тот пользователь, который ее начал. Иначе ссылку на вход с чужим state
+	// можно подсунуть жертве и привязать ее внешний аккаунт к аккаунту злоумышленника.
+	if state.UserID != nil && c.GetInt(middleware.UserIDKey) != *state.UserID {
+		utils.ErrorResponse(c, http.StatusForbidden, "Привязку аккаунта может завершить только пользователь, который ее начал", nil)
+		return
+	}
+
+	accessToken, err := provider.Exchange(c, input.Code, state.CodeVerifier)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadGateway, "Ошибка при обращении к провайдеру входа", err)
+		return
+	}
+
+	info, err := provider.UserInfo(c, accessToken)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadGateway, "Ошибка при получении данных от провайдера входа", err)
+		return
+	}
+
+	if state.UserID != nil {
+		h.linkIdentity(c, *state.UserID, provider.Name(), info)
+		return
+	}
+
+	identity, err := h.repo.UserIdentities.GetByProviderSubject(c, provider.Name(), info.Subject)
+	if err != nil && err.Error() != "внешний аккаунт не найден" {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при поиске внешнего аккаунта", err)
+		return
+	}
+
+	var user *models.User
+	if identity != nil {
+		user, err = h.repo.Users.GetByID(c, identity.UserID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении пользователя", err)
+			return
+		}
+	} else {
+		user, ok = h.findOrCreateUser(c, provider.Name(), info)
+		if !ok {
+			return
+		}
+	}
+
+	h.auth.continueLogin(c, user)
+}
+
+// findOrCreateUser привязывает внешний аккаунт к пользователю с тем же email (только если провайдер
+// подтвердил email) или создает нового пользователя. При ошибке ответ уже отправлен.
+func (h *OIDCHandler) findOrCreateUser(c *gin.Context, provider string, info *oidc.UserInfo) (*models.User, bool) {
+	if info.Email == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Провайдер входа не передал email", nil)
+		return nil, false
+	}
+
+	user, err := h.repo.Users.GetByEmail(c, info.Email)
+	if err != nil && err.Error() != "пользователь не найден" {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке существующего пользователя", err)
+		return nil, false
+	}
+
+	if user != nil {
+		if !info.EmailVerified {
+			utils.ErrorResponse(c, http.StatusConflict, "Пользователь с таким email уже существует. Войдите по паролю и привяжите аккаунт в профиле", nil)
+			return nil, false
+		}
+
+		if !user.IsEmailVerified() {
+			if err := h.repo.Users.MarkEmailVerified(c, user.ID); err != nil {
+				utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при подтверждении email", err)
+				return nil, false
+			}
+			now := time.Now()
+			user.EmailVerifiedAt = &now
+		}
+	} else {
+		user, err = models.NewUserFromIdentity(info.Email, info.FirstName, info.LastName, info.EmailVerified)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании пользователя", err)
+			return nil, false
+		}
+
+		user.ID, err = h.repo.Users.Create(c, user)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при сохранении пользователя", err)
+			return nil, false
+		}
+
+		if !user.IsEmailVerified() {
+			if err := h.auth.sendVerificationEmail(c, user); err != nil {
+				log.Printf("Ошибка при отправке письма подтверждения email пользователю %d: %v", user.ID, err)
+			}
+		}
+	}
+
+	identity := &models.UserIdentity{
+		UserID:    user.ID,
+		Provider:  provider,
+		Subject:   info.Subject,
+		Email:     info.Email,
+		CreatedAt: time.Now(),
+	}
+	if _, err := h.repo.UserIdentities.Create(c, identity); err != nil {
+		utils.ErrorResponse(c, http.StatusConflict, "Не удалось привязать внешний аккаунт", err)
+		return nil, false
+	}
+
+	return user, true
+}
+
+func (h *OIDCHandler) linkIdentity(c *gin.Context, userID int, provider string, info *oidc.UserInfo) {
+	existing, err := h.repo.UserIdentities.GetByProviderSubject(c, provider, info.Subject)
+	if err != nil && err.Error() != "внешний аккаунт не найден" {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при поиске внешнего аккаунта", err)
+		return
+	}
+
+	if existing != nil {
+		if existing.UserID != userID {
+			utils.ErrorResponse(c, http.StatusConflict, "Этот внешний аккаунт уже привязан к другому пользователю", nil)
+			return
+		}
+
+		utils.Response(c, http.StatusOK, gin.H{
+			"message":  "Аккаунт уже привязан",
+			"identity": existing,
+		})
+		return
+	}
+
+	identities, err := h.repo.UserIdentities.GetByUser(c, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении внешних аккаунтов", err)
+		return
+	}
+
+	for _, identity := range identities {
+		if identity.Provider == provider {
+			utils.ErrorResponse(c, http.StatusConflict, "К аккаунту уже привязан другой аккаунт этого провайдера", nil)
+			return
+		}
+	}
+
+	identity := &models.UserIdentity{
+		UserID:    userID,
+		Provider:  provider,
+		Subject:   info.Subject,
+		Email:     info.Email,
+		CreatedAt: time.Now(),
+	}
+
+	identity.ID, err = h.repo.UserIdentities.Create(c, identity)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при привязке внешнего аккаунта", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message":  "Аккаунт привязан",
+		"identity": identity,
+	})
+}
+
+// @Summary Привязанные внешние аккаунты
+// @Description Возвращает внешние провайдеры входа, привязанные к текущему пользователю
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/identities [get]
+func (h *OIDCHandler) GetIdentities(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	identities, err := h.repo.UserIdentities.GetByUser(c, userID.(int))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении внешних аккаунтов", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"identities": identities,
+	})
+}
+
+// @Summary Привязка внешнего аккаунта
+// @Description Возвращает адрес страницы входа провайдера. После возврата фронтенд передает code и state в /auth/oidc/{provider}/callback вместе с access токеном, и аккаунт привязывается к текущему пользователю
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param provider path string true "Имя провайдера"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/identities/{provider} [post]
+func (h *OIDCHandler) LinkIdentity(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	provider, ok := h.provider(c)
+	if !ok {
+		return
+	}
+
+	id := userID.(int)
+	authURL, err := h.authorizationURL(c, provider, &id)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при начале привязки аккаунта", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"authorization_url": authURL,
+	})
+}
+
+// @Summary Отвязка внешнего аккаунта
+// @Description Отвязывает провайдера входа от текущего пользователя
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param provider path string true "Имя провайдера"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/identities/{provider} [delete]
+func (h *OIDCHandler) UnlinkIdentity(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	deleted, err := h.repo.UserIdentities.Delete(c, userID.(int), c.Param("provider"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отвязке внешнего аккаунта", err)
+		return
+	}
+
+	if !deleted {
+		utils.ErrorResponse(c, http.StatusNotFound, "Внешний аккаунт не привязан", nil)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message": "Аккаунт отвязан",
+	})
+}
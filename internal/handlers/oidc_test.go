@@ -0,0 +1,483 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"job_solition/internal/config"
+	"job_solition/internal/middleware"
+	"job_solition/internal/models"
+	"job_solition/internal/oidc/oidctest"
+	"job_solition/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// testUserHeader заменяет в тестах middleware авторизации: его значение становится ID текущего пользователя.
+const testUserHeader = "X-Test-User-ID"
+
+type fakeOAuthStates struct {
+	repository.OAuthStateRepository
+
+	mu     sync.Mutex
+	states map[string]models.OAuthState
+}
+
+func (f *fakeOAuthStates) Create(ctx context.Context, state *models.OAuthState) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.states[state.State] = *state
+	return nil
+}
+
+func (f *fakeOAuthStates) Consume(ctx context.Context, state string) (*models.OAuthState, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored, ok := f.states[state]
+	if !ok {
+		return nil, fmt.Errorf("состояние входа не найдено")
+	}
+	delete(f.states, state)
+	return &stored, nil
+}
+
+type fakeUserIdentities struct {
+	repository.UserIdentityRepository
+
+	mu         sync.Mutex
+	identities []models.UserIdentity
+}
+
+func (f *fakeUserIdentities) Create(ctx context.Context, identity *models.UserIdentity) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, existing := range f.identities {
+		if existing.Provider == identity.Provider && existing.Subject == identity.Subject {
+			return 0, fmt.Errorf("ошибка при привязке внешнего аккаунта: дубликат")
+		}
+	}
+
+	stored := *identity
+	stored.ID = len(f.identities) + 1
+	f.identities = append(f.identities, stored)
+	return stored.ID, nil
+}
+
+func (f *fakeUserIdentities) GetByProviderSubject(ctx context.Context, provider, subject string) (*models.UserIdentity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, identity := range f.identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			found := identity
+			return &found, nil
+		}
+	}
+	return nil, fmt.Errorf("внешний аккаунт не найден")
+}
+
+func (f *fakeUserIdentities) GetByUser(ctx context.Context, userID int) ([]models.UserIdentity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var result []models.UserIdentity
+	for _, identity := range f.identities {
+		if identity.UserID == userID {
+			result = append(result, identity)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeUserIdentities) all() []models.UserIdentity {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]models.UserIdentity{}, f.identities...)
+}
+
+type fakeUsers struct {
+	repository.UserRepository
+
+	mu    sync.Mutex
+	users map[int]*models.User
+}
+
+func (f *fakeUsers) Create(ctx context.Context, user *models.User) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := *user
+	stored.ID = len(f.users) + 1
+	f.users[stored.ID] = &stored
+	return stored.ID, nil
+}
+
+func (f *fakeUsers) GetByID(ctx context.Context, id int) (*models.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	user, ok := f.users[id]
+	if !ok {
+		return nil, fmt.Errorf("пользователь не найден")
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (f *fakeUsers) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, user := range f.users {
+		if user.Email == email {
+			copied := *user
+			return &copied, nil
+		}
+	}
+	return nil, fmt.Errorf("пользователь не найден")
+}
+
+func (f *fakeUsers) MarkEmailVerified(ctx context.Context, id int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	f.users[id].EmailVerifiedAt = &now
+	return nil
+}
+
+func (f *fakeUsers) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.users)
+}
+
+type fakeRefreshTokens struct {
+	repository.RefreshTokenRepository
+}
+
+func (f *fakeRefreshTokens) Create(ctx context.Context, token *models.RefreshToken) (int, error) {
+	return 1, nil
+}
+
+type fakeLoginAttempts struct {
+	repository.LoginAttemptRepository
+}
+
+func (f *fakeLoginAttempts) Create(ctx context.Context, attempt *models.LoginAttempt) (int, error) {
+	return 1, nil
+}
+
+type oidcTestEnv struct {
+	provider   *oidctest.Server
+	router     *gin.Engine
+	states     *fakeOAuthStates
+	identities *fakeUserIdentities
+	users      *fakeUsers
+}
+
+func newOIDCTestEnv(t *testing.T, user oidctest.User) *oidcTestEnv {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	env := &oidcTestEnv{
+		provider:   oidctest.NewServer(user),
+		states:     &fakeOAuthStates{states: map[string]models.OAuthState{}},
+		identities: &fakeUserIdentities{},
+		users:      &fakeUsers{users: map[int]*models.User{}},
+	}
+	t.Cleanup(env.provider.Close)
+
+	repo := &repository.Repository{
+		OAuthStates:    env.states,
+		UserIdentities: env.identities,
+		Users:          env.users,
+		RefreshTokens:  &fakeRefreshTokens{},
+		LoginAttempts:  &fakeLoginAttempts{},
+	}
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			Secret:           "test-secret",
+			ExpiresIn:        time.Hour,
+			RefreshExpiresIn: 24 * time.Hour,
+		},
+		OIDC: config.OIDCConfig{
+			StateExpiresIn: 10 * time.Minute,
+			Providers: []config.OIDCProviderConfig{
+				env.provider.ProviderConfig("test", "http://frontend.test/oidc/callback"),
+			},
+		},
+	}
+	h := NewOIDCHandler(repo, cfg)
+
+	env.router = gin.New()
+	env.router.Use(func(c *gin.Context) {
+		if userID, err := strconv.Atoi(c.GetHeader(testUserHeader)); err == nil {
+			c.Set(middleware.UserIDKey, userID)
+		}
+	})
+	env.router.GET("/auth/oidc/:provider/authorize", h.Authorize)
+	env.router.POST("/auth/oidc/:provider/callback", h.Callback)
+	env.router.POST("/users/me/identities/:provider", h.LinkIdentity)
+
+	return env
+}
+
+func (env *oidcTestEnv) addUser(email string, verified bool) *models.User {
+	user := &models.User{Email: email, Role: models.RoleUser}
+	if verified {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+	user.ID, _ = env.users.Create(context.Background(), user)
+	return user
+}
+
+func (env *oidcTestEnv) serve(method, path, body string, userID int) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if userID != 0 {
+		req.Header.Set(testUserHeader, strconv.Itoa(userID))
+	}
+	rec := httptest.NewRecorder()
+	env.router.ServeHTTP(rec, req)
+	return rec
+}
+
+// followProvider проходит страницу входа провайдера и возвращает code и state из адреса возврата.
+func (env *oidcTestEnv) followProvider(t *testing.T, authURL string) (string, string) {
+	t.Helper()
+
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatalf("ошибка при обращении к провайдеру: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("провайдер вернул %d вместо перенаправления", resp.StatusCode)
+	}
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("некорректный адрес возврата: %v", err)
+	}
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func (env *oidcTestEnv) startLogin(t *testing.T) (string, string) {
+	t.Helper()
+
+	rec := env.serve(http.MethodGet, "/auth/oidc/test/authorize", "", 0)
+	if rec.Code != http.StatusFound {
+		t.Fatalf("начало входа вернуло %d %q", rec.Code, rec.Body.String())
+	}
+	return env.followProvider(t, rec.Header().Get("Location"))
+}
+
+func (env *oidcTestEnv) startLink(t *testing.T, userID int) (string, string) {
+	t.Helper()
+
+	rec := env.serve(http.MethodPost, "/users/me/identities/test", "", userID)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("начало привязки вернуло %d %q", rec.Code, rec.Body.String())
+	}
+
+	var body struct {
+		Data struct {
+			AuthorizationURL string `json:"authorization_url"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("некорректный ответ начала привязки: %v", err)
+	}
+	return env.followProvider(t, body.Data.AuthorizationURL)
+}
+
+func (env *oidcTestEnv) callback(code, state string, userID int) *httptest.ResponseRecorder {
+	body := fmt.Sprintf(`{"code":%q,"state":%q}`, code, state)
+	return env.serve(http.MethodPost, "/auth/oidc/test/callback", body, userID)
+}
+
+func loggedInUserID(t *testing.T, rec *httptest.ResponseRecorder) int {
+	t.Helper()
+
+	var body struct {
+		Data struct {
+			User struct {
+				ID int `json:"id"`
+			} `json:"user"`
+			Tokens struct {
+				AccessToken string `json:"access_token"`
+			} `json:"tokens"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("некорректный ответ входа: %v", err)
+	}
+	if body.Data.Tokens.AccessToken == "" {
+		t.Fatalf("ответ входа без access токена: %s", rec.Body.String())
+	}
+	return body.Data.User.ID
+}
+
+var testProviderUser = oidctest.User{
+	Subject:       "subject-1",
+	Email:         "user@example.com",
+	EmailVerified: true,
+	FirstName:     "Иван",
+	LastName:      "Иванов",
+}
+
+func TestOIDCCallbackCreatesUser(t *testing.T) {
+	env := newOIDCTestEnv(t, testProviderUser)
+
+	code, state := env.startLogin(t)
+	rec := env.callback(code, state, 0)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("вход вернул %d %q", rec.Code, rec.Body.String())
+	}
+
+	userID := loggedInUserID(t, rec)
+	identities := env.identities.all()
+	if env.users.count() != 1 || len(identities) != 1 || identities[0].UserID != userID {
+		t.Fatalf("создано пользователей %d, внешних аккаунтов %v, ожидался один аккаунт пользователя %d", env.users.count(), identities, userID)
+	}
+
+	code, state = env.startLogin(t)
+	rec = env.callback(code, state, 0)
+	if rec.Code != http.StatusOK || loggedInUserID(t, rec) != userID {
+		t.Fatalf("повторный вход вернул %d %q, ожидался вход пользователя %d", rec.Code, rec.Body.String(), userID)
+	}
+	if env.users.count() != 1 {
+		t.Fatalf("повторный вход создал нового пользователя")
+	}
+}
+
+func TestOIDCCallbackConsumesState(t *testing.T) {
+	env := newOIDCTestEnv(t, testProviderUser)
+
+	code, state := env.startLogin(t)
+	if rec := env.callback(code, state, 0); rec.Code != http.StatusOK {
+		t.Fatalf("вход вернул %d %q", rec.Code, rec.Body.String())
+	}
+
+	if rec := env.callback(code, state, 0); rec.Code != http.StatusBadRequest {
+		t.Fatalf("повторное использование state вернуло %d, ожидался 400", rec.Code)
+	}
+}
+
+func TestOIDCCallbackSendsStoredVerifier(t *testing.T) {
+	env := newOIDCTestEnv(t, testProviderUser)
+
+	code, state := env.startLogin(t)
+
+	env.states.mu.Lock()
+	stored := env.states.states[state]
+	stored.CodeVerifier = "another-verifier"
+	env.states.states[state] = stored
+	env.states.mu.Unlock()
+
+	if rec := env.callback(code, state, 0); rec.Code != http.StatusBadGateway {
+		t.Fatalf("вход с чужим code_verifier вернул %d, ожидался 502", rec.Code)
+	}
+	if env.users.count() != 0 {
+		t.Fatal("пользователь создан без подтверждения PKCE")
+	}
+}
+
+func TestOIDCCallbackLinksVerifiedEmail(t *testing.T) {
+	env := newOIDCTestEnv(t, testProviderUser)
+	existing := env.addUser(testProviderUser.Email, false)
+
+	code, state := env.startLogin(t)
+	rec := env.callback(code, state, 0)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("вход вернул %d %q", rec.Code, rec.Body.String())
+	}
+
+	if userID := loggedInUserID(t, rec); userID != existing.ID {
+		t.Fatalf("выполнен вход пользователя %d, ожидался %d", userID, existing.ID)
+	}
+	if identities := env.identities.all(); len(identities) != 1 || identities[0].UserID != existing.ID {
+		t.Fatalf("внешние аккаунты %v, ожидалась привязка к пользователю %d", identities, existing.ID)
+	}
+	if user, _ := env.users.GetByID(context.Background(), existing.ID); !user.IsEmailVerified() {
+		t.Fatal("email существующего пользователя не отмечен подтвержденным")
+	}
+}
+
+func TestOIDCCallbackRejectsUnverifiedEmail(t *testing.T) {
+	unverified := testProviderUser
+	unverified.EmailVerified = false
+
+	env := newOIDCTestEnv(t, unverified)
+	env.addUser(unverified.Email, true)
+
+	code, state := env.startLogin(t)
+	if rec := env.callback(code, state, 0); rec.Code != http.StatusConflict {
+		t.Fatalf("вход с неподтвержденным email вернул %d, ожидался 409", rec.Code)
+	}
+	if identities := env.identities.all(); len(identities) != 0 {
+		t.Fatalf("внешний аккаунт привязан без подтвержденного email: %v", identities)
+	}
+}
+
+func TestOIDCCallbackLinksIdentity(t *testing.T) {
+	env := newOIDCTestEnv(t, testProviderUser)
+	owner := env.addUser("owner@example.com", true)
+
+	code, state := env.startLink(t, owner.ID)
+	rec := env.callback(code, state, owner.ID)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("привязка вернула %d %q", rec.Code, rec.Body.String())
+	}
+	if strings.Contains(rec.Body.String(), "access_token") {
+		t.Fatal("привязка аккаунта выдала токены")
+	}
+	if identities := env.identities.all(); len(identities) != 1 || identities[0].UserID != owner.ID {
+		t.Fatalf("внешние аккаунты %v, ожидалась привязка к пользователю %d", identities, owner.ID)
+	}
+	if env.users.count() != 1 {
+		t.Fatal("привязка создала нового пользователя")
+	}
+}
+
+func TestOIDCCallbackLinkRequiresInitiatingUser(t *testing.T) {
+	env := newOIDCTestEnv(t, testProviderUser)
+	attacker := env.addUser("attacker@example.com", true)
+	victim := env.addUser("victim@example.com", true)
+
+	for _, tc := range []struct {
+		name   string
+		userID int
+	}{
+		{name: "без токена", userID: 0},
+		{name: "токен другого пользователя", userID: victim.ID},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			code, state := env.startLink(t, attacker.ID)
+			if rec := env.callback(code, state, tc.userID); rec.Code != http.StatusForbidden {
+				t.Fatalf("завершение чужой привязки вернуло %d %q, ожидался 403", rec.Code, rec.Body.String())
+			}
+			if identities := env.identities.all(); len(identities) != 0 {
+				t.Fatalf("внешний аккаунт привязан по чужому state: %v", identities)
+			}
+		})
+	}
+}
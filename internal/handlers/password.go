@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"job_solition/internal/models"
+	"job_solition/internal/repository"
+	"job_solition/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// validateNewPassword проверяет пароль по политике и при нарушении сам отвечает списком нарушенных правил.
+// Для еще не сохраненного пользователя (ID == 0) история паролей не проверяется.
+func validateNewPassword(c *gin.Context, repo *repository.Repository, policy *utils.PasswordPolicy, password string, user *models.User) bool {
+	var previousHashes []string
+	if user.ID != 0 && policy.HistorySize() > 0 {
+		history, err := repo.PasswordHistory.GetRecent(c, user.ID, policy.HistorySize())
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке истории паролей", err)
+			return false
+		}
+		previousHashes = append([]string{user.PasswordHash}, history...)
+	}
+
+	if errors := policy.Validate(password, user, previousHashes); len(errors) > 0 {
+		utils.ValidationErrorResponse(c, errors)
+		return false
+	}
+
+	return true
+}
+
+// rememberPassword сохраняет текущий хеш пароля пользователя в историю паролей.
+func rememberPassword(c *gin.Context, repo *repository.Repository, policy *utils.PasswordPolicy, user *models.User) {
+	if policy.HistorySize() == 0 {
+		return
+	}
+
+	if err := repo.PasswordHistory.Add(c, user.ID, user.PasswordHash, policy.HistorySize()); err != nil {
+		log.Printf("Ошибка при сохранении истории паролей пользователя %d: %v", user.ID, err)
+	}
+}
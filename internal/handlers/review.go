@@ -6,8 +6,10 @@ import (
 
 	"job_solition/internal/config"
 	"job_solition/internal/db"
+	"job_solition/internal/mailer"
 	"job_solition/internal/middleware"
 	"job_solition/internal/models"
+	"job_solition/internal/moderation"
 	"job_solition/internal/repository"
 	"job_solition/internal/utils"
 
@@ -15,29 +17,38 @@ import (
 )
 
 type ReviewHandler struct {
-	repo *repository.Repository
-	cfg  *config.Config
+	repo      *repository.Repository
+	cfg       *config.Config
+	mailer    mailer.Mailer
+	moderator *moderation.Engine
+	pii       *moderation.PIIDetector
 }
 
 func NewReviewHandler(postgres *db.PostgreSQL, cfg *config.Config) *ReviewHandler {
 	repo := repository.NewRepository(postgres)
 	return &ReviewHandler{
-		repo: repo,
-		cfg:  cfg,
+		repo:      repo,
+		cfg:       cfg,
+		mailer:    mailer.New(cfg.Mail),
+		moderator: moderation.New(cfg.Moderation),
+		pii:       moderation.NewPIIDetector(cfg.Moderation.PIIPersonNames),
 	}
 }
 
 // @Summary Создание отзыва
-// @Description Создает новый отзыв о компании
+// @Description Создает новый отзыв о компании вместе с оценками по категориям и льготами в одной транзакции. Город, период работы, тип занятости, категории и льготы проверяются заранее, все ошибки возвращаются одним ответом. Повтор запроса с тем же заголовком Idempotency-Key возвращает исходный ответ и не создает новый отзыв. Если включена автоматическая предмодерация, отзыв может быть сразу опубликован или отклонен, иначе он отправляется модераторам
 // @Tags reviews
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param Idempotency-Key header string false "Ключ идемпотентности запроса"
 // @Param input body models.ReviewInput true "Данные отзыва"
 // @Success 201 {object} utils.ResponseDTO
 // @Failure 400 {object} utils.ErrorResponseDTO
 // @Failure 401 {object} utils.ErrorResponseDTO
 // @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 409 {object} utils.ErrorResponseDTO
+// @Failure 422 {object} utils.ErrorResponseDTO
 // @Failure 500 {object} utils.ErrorResponseDTO
 // @Router /reviews [post]
 func (h *ReviewHandler) CreateReview(c *gin.Context) {
@@ -53,83 +64,27 @@ func (h *ReviewHandler) CreateReview(c *gin.Context) {
 		return
 	}
 
-	_, err := h.repo.Companies.GetByID(c, input.CompanyID)
-	if err != nil {
-		if err.Error() == "компания не найдена" {
-			utils.ErrorResponse(c, http.StatusNotFound, "Компания не найдена", nil)
-		} else {
-			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке компании", err)
-		}
-		return
-	}
-
-	_, err = h.repo.Cities.GetByID(c, input.CityID)
-	if err != nil {
-		if err.Error() == "город не найден" {
-			utils.ErrorResponse(c, http.StatusNotFound, "Указанный город не найден", nil)
-		} else {
-			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке города", err)
-		}
-		return
-	}
-
-	_, err = h.repo.EmploymentPeriods.GetByID(c, input.EmploymentPeriodID)
-	if err != nil {
-		if err.Error() == "ошибка при получении периода работы по ID: sql: no rows in result set" {
-			utils.ErrorResponse(c, http.StatusNotFound, "Указанный период работы не найден", nil)
-		} else {
-			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке периода работы", err)
-		}
-		return
-	}
-
-	_, err = h.repo.EmploymentTypes.GetByID(c, input.EmploymentTypeID)
-	if err != nil {
-		if err.Error() == "ошибка при получении типа занятости по ID: sql: no rows in result set" {
-			utils.ErrorResponse(c, http.StatusNotFound, "Указанный тип занятости не найден", nil)
-		} else {
-			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке типа занятости", err)
-		}
-		return
-	}
-
-	review := models.NewReview(userID.(int), input)
-
-	id, err := h.repo.Reviews.Create(c, review)
-	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при сохранении отзыва", err)
-		return
-	}
-
-	for categoryID, rating := range input.CategoryRatings {
-		err = h.repo.Reviews.AddCategoryRating(c, id, categoryID, rating)
-		if err != nil {
-			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при сохранении рейтингов по категориям", err)
-			return
-		}
-	}
+	h.submitReview(c, userID.(int), input)
+}
 
-	for _, benefitTypeID := range input.BenefitTypeIDs {
-		err = h.repo.Reviews.AddBenefit(c, id, benefitTypeID)
-		if err != nil {
-			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при сохранении льгот", err)
-			return
-		}
+// reviewStatusMessage возвращает сообщение для автора о состоянии отзыва после сохранения.
+func reviewStatusMessage(status models.ReviewStatus) string {
+	switch status {
+	case models.ReviewStatusApproved:
+		return "Отзыв опубликован"
+	case models.ReviewStatusRejected:
+		return "Отзыв отклонен автоматической проверкой"
+	default:
+		return "Отзыв отправлен на модерацию"
 	}
-
-	review.ID = id
-
-	utils.Response(c, http.StatusCreated, gin.H{
-		"review": review,
-		"status": "Отзыв отправлен на модерацию",
-	})
 }
 
 // @Summary Получение отзыва
-// @Description Возвращает отзыв по его ID
+// @Description Возвращает отзыв по его ID. Телефоны, адреса почты, номера документов и имена людей в тексте отзыва скрываются
 // @Tags reviews
 // @Accept json
 // @Produce json
+// @Security APIKeyAuth
 // @Param id path int true "ID отзыва"
 // @Success 200 {object} utils.ResponseDTO
 // @Failure 400 {object} utils.ErrorResponseDTO
@@ -152,19 +107,22 @@ func (h *ReviewHandler) GetReview(c *gin.Context) {
 		return
 	}
 
-	if review.Review.Status != models.ReviewStatusApproved {
+	if review.Review.Status != models.ReviewStatusApproved || review.Review.IsHidden {
 		utils.ErrorResponse(c, http.StatusNotFound, "Отзыв не найден или ожидает модерации", nil)
 		return
 	}
 
+	h.redactPII(&review.Review)
+
 	utils.Response(c, http.StatusOK, review)
 }
 
 // @Summary Отзывы о компании
-// @Description Возвращает список отзывов о компании
+// @Description Возвращает список отзывов о компании. Телефоны, адреса почты, номера документов и имена людей в тексте отзывов скрываются
 // @Tags reviews
 // @Accept json
 // @Produce json
+// @Security APIKeyAuth
 // @Param companyId path int true "ID компании"
 // @Param sort_by query string false "Поле для сортировки (rating, created_at)"
 // @Param sort_order query string false "Порядок сортировки (asc, desc)"
@@ -216,6 +174,7 @@ func (h *ReviewHandler) GetCompanyReviews(c *gin.Context) {
 
 	status := models.ReviewStatusApproved
 	filter.Status = &status
+	filter.ExcludeHidden = true
 
 	filter.CompanyID = &companyID
 
@@ -225,6 +184,10 @@ func (h *ReviewHandler) GetCompanyReviews(c *gin.Context) {
 		return
 	}
 
+	for i := range reviews {
+		h.redactPII(&reviews[i].Review)
+	}
+
 	utils.Response(c, http.StatusOK, gin.H{
 		"reviews": reviews,
 		"pagination": gin.H{
@@ -237,7 +200,7 @@ func (h *ReviewHandler) GetCompanyReviews(c *gin.Context) {
 }
 
 // @Summary Отзывы на модерации
-// @Description Возвращает список отзывов, ожидающих модерации
+// @Description Возвращает список отзывов, ожидающих модерации. В moderation_signals передается результат автоматической предмодерации (решение, балл и сработавшие правила), найденные в тексте персональные данные, а для отзывов, помеченных подозрительными, - отзывы с почти совпадающим текстом
 // @Tags admin
 // @Accept json
 // @Produce json
@@ -284,6 +247,11 @@ func (h *ReviewHandler) GetPendingReviews(c *gin.Context) {
 		return
 	}
 
+	if err := h.attachModerationSignals(c, reviews); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении результатов автоматических проверок", err)
+		return
+	}
+
 	utils.Response(c, http.StatusOK, gin.H{
 		"reviews": reviews,
 		"pagination": gin.H{
@@ -610,6 +578,11 @@ func (h *ReviewHandler) MarkReviewAsUseful(c *gin.Context) {
 		return
 	}
 
+	if review.Review.IsHidden {
+		utils.ErrorResponse(c, http.StatusNotFound, "Отзыв не найден", nil)
+		return
+	}
+
 	isMarked, err := h.repo.Reviews.HasUserMarkedReviewAsUseful(c, userID.(int), id)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке наличия отметки", err)
@@ -686,3 +659,73 @@ func (h *ReviewHandler) RemoveUsefulMark(c *gin.Context) {
 		"message": "Отметка 'полезно' удалена",
 	})
 }
+
+// @Summary Отзыв отзыва автором
+// @Description Снимает собственный отзыв с публикации. Отзыв перестает отображаться публично, рейтинг компании пересчитывается, а ожидающие модерации правки отменяются
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID отзыва"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /reviews/{id}/withdraw [post]
+func (h *ReviewHandler) WithdrawReview(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	id, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	reviewDetails, err := h.repo.Reviews.GetByID(c, id)
+	if err != nil {
+		if err.Error() == "отзыв не найден" {
+			utils.ErrorResponse(c, http.StatusNotFound, "Отзыв не найден", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении отзыва", err)
+		}
+		return
+	}
+
+	review := reviewDetails.Review
+	if review.UserID != userID.(int) {
+		utils.ErrorResponse(c, http.StatusForbidden, "Можно отозвать только собственный отзыв", nil)
+		return
+	}
+
+	previous, withdrawn, err := h.repo.Reviews.Withdraw(c, id)
+	if err != nil {
+		if err.Error() == "отзыв не найден" {
+			utils.ErrorResponse(c, http.StatusNotFound, "Отзыв не найден", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отзыве отзыва", err)
+		}
+		return
+	}
+
+	if !withdrawn {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Отзыв уже отозван или отклонен", nil)
+		return
+	}
+
+	// Статус берется из Withdraw, а не из прочитанного ранее отзыва: его могли одобрить между чтением и снятием.
+	if previous == models.ReviewStatusApproved {
+		if err := h.repo.Companies.UpdateRating(c, review.CompanyID); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при обновлении рейтинга компании", err)
+			return
+		}
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message": "Отзыв снят с публикации",
+	})
+}
@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"job_solition/internal/middleware"
+	"job_solition/internal/models"
+	"job_solition/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// maxReviewDrafts - сколько черновиков отзывов может хранить один пользователь.
+const maxReviewDrafts = 20
+
+// @Summary Мои черновики отзывов
+// @Description Возвращает черновики отзывов текущего пользователя, начиная с последних измененных
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/drafts [get]
+func (h *ReviewHandler) GetReviewDrafts(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	drafts, err := h.repo.ReviewDrafts.GetByUser(c, userID.(int))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении черновиков отзывов", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, drafts)
+}
+
+// @Summary Создание черновика отзыва
+// @Description Сохраняет частично заполненную форму отзыва. Все поля необязательны, полная проверка выполняется при отправке черновика. Черновик удаляется автоматически, если долго не меняется
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body models.ReviewDraftInput true "Данные черновика"
+// @Success 201 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/drafts [post]
+func (h *ReviewHandler) CreateReviewDraft(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	var input models.ReviewDraftInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	count, err := h.repo.ReviewDrafts.CountByUser(c, userID.(int))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при подсчете черновиков отзывов", err)
+		return
+	}
+	if count >= maxReviewDrafts {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Достигнуто максимальное количество черновиков, удалите ненужные", nil)
+		return
+	}
+
+	draft := models.NewReviewDraft(userID.(int), input)
+	id, err := h.repo.ReviewDrafts.Create(c, draft)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при сохранении черновика отзыва", err)
+		return
+	}
+	draft.ID = id
+
+	utils.Response(c, http.StatusCreated, draft)
+}
+
+// @Summary Черновик отзыва
+// @Description Возвращает черновик отзыва текущего пользователя
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID черновика"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/drafts/{id} [get]
+func (h *ReviewHandler) GetReviewDraft(c *gin.Context) {
+	draft := h.loadOwnDraft(c)
+	if draft == nil {
+		return
+	}
+
+	utils.Response(c, http.StatusOK, draft)
+}
+
+// @Summary Автосохранение черновика отзыва
+// @Description Заменяет содержимое черновика отзыва текущим состоянием формы
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID черновика"
+// @Param input body models.ReviewDraftInput true "Данные черновика"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/drafts/{id} [put]
+func (h *ReviewHandler) UpdateReviewDraft(c *gin.Context) {
+	draft := h.loadOwnDraft(c)
+	if draft == nil {
+		return
+	}
+
+	var input models.ReviewDraftInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	draft.Payload = input
+	draft.UpdatedAt = time.Now()
+
+	if err := h.repo.ReviewDrafts.Update(c, draft); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при сохранении черновика отзыва", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, draft)
+}
+
+// @Summary Удаление черновика отзыва
+// @Description Удаляет черновик отзыва текущего пользователя
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID черновика"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/drafts/{id} [delete]
+func (h *ReviewHandler) DeleteReviewDraft(c *gin.Context) {
+	draft := h.loadOwnDraft(c)
+	if draft == nil {
+		return
+	}
+
+	if err := h.repo.ReviewDrafts.Delete(c, draft.ID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при удалении черновика отзыва", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message": "Черновик удален",
+	})
+}
+
+// @Summary Отправка черновика отзыва
+// @Description Создает отзыв из черновика так же, как при создании отзыва: черновик проходит полную проверку, а после успешной отправки удаляется. Повтор запроса с тем же заголовком Idempotency-Key возвращает исходный ответ
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Ключ идемпотентности запроса"
+// @Param id path int true "ID черновика"
+// @Success 201 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 409 {object} utils.ErrorResponseDTO
+// @Failure 422 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/drafts/{id}/submit [post]
+func (h *ReviewHandler) SubmitReviewDraft(c *gin.Context) {
+	draft := h.loadOwnDraft(c)
+	if draft == nil {
+		return
+	}
+
+	input := draft.Payload.ReviewInput()
+	if err := binding.Validator.ValidateStruct(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Черновик заполнен не полностью", err)
+		return
+	}
+
+	if review := h.submitReview(c, draft.UserID, input); review == nil {
+		return
+	}
+
+	if err := h.repo.ReviewDrafts.Delete(c, draft.ID); err != nil {
+		log.Printf("Ошибка при удалении отправленного черновика %d: %v", draft.ID, err)
+	}
+}
+
+// loadOwnDraft возвращает черновик из параметра id, если он принадлежит текущему пользователю.
+// Иначе пишет ответ с ошибкой и возвращает nil.
+func (h *ReviewHandler) loadOwnDraft(c *gin.Context) *models.ReviewDraft {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return nil
+	}
+
+	id, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return nil
+	}
+
+	draft, err := h.repo.ReviewDrafts.GetByID(c, id)
+	if err != nil {
+		if err.Error() == "черновик не найден" {
+			utils.ErrorResponse(c, http.StatusNotFound, "Черновик не найден", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении черновика отзыва", err)
+		}
+		return nil
+	}
+
+	if draft.UserID != userID.(int) {
+		utils.ErrorResponse(c, http.StatusNotFound, "Черновик не найден", nil)
+		return nil
+	}
+
+	return draft
+}
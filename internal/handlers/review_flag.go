@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"job_solition/internal/mailer"
+	"job_solition/internal/middleware"
+	"job_solition/internal/models"
+	"job_solition/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Жалоба на отзыв
+// @Description Отправляет жалобу на опубликованный отзыв: клевета, персональные данные, спам, оскорбления, ложная информация или другое. От одного пользователя принимается одна жалоба на отзыв. При достижении порога жалоб отзыв скрывается до решения модератора и не учитывается в рейтинге компании
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID отзыва"
+// @Param input body models.ReviewFlagInput true "Причина и комментарий"
+// @Success 201 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 409 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /reviews/{id}/flags [post]
+func (h *ReviewHandler) FlagReview(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	id, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	var input models.ReviewFlagInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	if input.Reason == models.ReviewFlagReasonOther && input.Comment == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Опишите причину жалобы в комментарии", nil)
+		return
+	}
+
+	reviewDetails, err := h.repo.Reviews.GetByID(c, id)
+	if err != nil {
+		if err.Error() == "отзыв не найден" {
+			utils.ErrorResponse(c, http.StatusNotFound, "Отзыв не найден", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении отзыва", err)
+		}
+		return
+	}
+
+	review := reviewDetails.Review
+	if review.Status != models.ReviewStatusApproved {
+		utils.ErrorResponse(c, http.StatusNotFound, "Отзыв не найден", nil)
+		return
+	}
+
+	if review.UserID == userID.(int) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Нельзя пожаловаться на собственный отзыв", nil)
+		return
+	}
+
+	flag := models.NewReviewFlag(review.ID, userID.(int), input)
+	hidden, err := h.repo.ReviewFlags.Create(c, &flag, h.cfg.Moderation.FlagHideThreshold)
+	if err != nil {
+		if err.Error() == "жалоба уже подана" {
+			utils.ErrorResponse(c, http.StatusConflict, "Вы уже пожаловались на этот отзыв", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при сохранении жалобы", err)
+		}
+		return
+	}
+
+	if hidden {
+		if err := h.repo.Companies.UpdateRating(c, review.CompanyID); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при обновлении рейтинга компании", err)
+			return
+		}
+	}
+
+	utils.Response(c, http.StatusCreated, gin.H{
+		"message": "Жалоба отправлена модераторам, о решении мы сообщим по email",
+		"flag":    flag,
+	})
+}
+
+// @Summary Отзывы с жалобами
+// @Description Возвращает отзывы с открытыми жалобами читателей: сначала с наибольшим числом жалоб. Для каждого отзыва приводятся жалобы и их количество по причинам
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Номер страницы"
+// @Param limit query int false "Количество записей на странице"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/reviews/moderation/flags [get]
+func (h *ReviewHandler) GetFlaggedReviews(c *gin.Context) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || (roleValue.(models.UserRole) != models.RoleModerator && roleValue.(models.UserRole) != models.RoleAdmin) {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав для просмотра жалоб", nil)
+		return
+	}
+
+	var page, limit int
+
+	if pageStr := c.Query("page"); pageStr != "" {
+		pageVal, err := strconv.Atoi(pageStr)
+		if err == nil && pageVal > 0 {
+			page = pageVal
+		}
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limitVal, err := strconv.Atoi(limitStr)
+		if err == nil && limitVal > 0 {
+			limit = limitVal
+		}
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+
+	if limit <= 0 {
+		limit = 10
+	}
+
+	summaries, total, err := h.repo.ReviewFlags.GetFlagged(c, page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении жалоб", err)
+		return
+	}
+
+	flagged := make([]models.FlaggedReview, 0, len(summaries))
+	for _, summary := range summaries {
+		review, err := h.repo.Reviews.GetByID(c, summary.ReviewID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении отзыва", err)
+			return
+		}
+
+		flags, err := h.repo.ReviewFlags.GetOpenByReview(c, summary.ReviewID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении жалоб", err)
+			return
+		}
+
+		flagged = append(flagged, models.NewFlaggedReview(review, flags))
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"reviews": flagged,
+		"pagination": gin.H{
+			"total": total,
+			"page":  page,
+			"limit": limit,
+			"pages": (total + limit - 1) / limit,
+		},
+	})
+}
+
+// @Summary Отклонение жалоб на отзыв
+// @Description Закрывает открытые жалобы на отзыв без мер: отзыв остается опубликованным и снова показывается, если был скрыт. Авторы жалоб получают уведомление
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID отзыва"
+// @Param input body models.ReviewFlagResolutionInput false "Комментарий модератора"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/reviews/{id}/flags/dismiss [put]
+func (h *ReviewHandler) DismissReviewFlags(c *gin.Context) {
+	h.resolveReviewFlags(c, models.ReviewFlagStatusDismissed)
+}
+
+// @Summary Принятие мер по жалобам на отзыв
+// @Description Подтверждает жалобы на отзыв: отзыв отклоняется с комментарием модератора и снимается с публикации, рейтинг компании пересчитывается. Авторы жалоб получают уведомление
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID отзыва"
+// @Param input body models.ReviewFlagResolutionInput true "Причина снятия отзыва"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/reviews/{id}/flags/act [put]
+func (h *ReviewHandler) ActOnReviewFlags(c *gin.Context) {
+	h.resolveReviewFlags(c, models.ReviewFlagStatusActioned)
+}
+
+func (h *ReviewHandler) resolveReviewFlags(c *gin.Context, status models.ReviewFlagStatus) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || (roleValue.(models.UserRole) != models.RoleModerator && roleValue.(models.UserRole) != models.RoleAdmin) {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав для модерации отзывов", nil)
+		return
+	}
+
+	id, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	var input models.ReviewFlagResolutionInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+			return
+		}
+	}
+
+	if status == models.ReviewFlagStatusActioned && input.Comment == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Необходимо указать причину снятия отзыва", nil)
+		return
+	}
+
+	reviewDetails, err := h.repo.Reviews.GetByID(c, id)
+	if err != nil {
+		if err.Error() == "отзыв не найден" {
+			utils.ErrorResponse(c, http.StatusNotFound, "Отзыв не найден", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении отзыва", err)
+		}
+		return
+	}
+
+	flags, err := h.repo.ReviewFlags.Resolve(c, id, status, c.GetInt(middleware.UserIDKey), input.Comment)
+	if err != nil {
+		if err.Error() == "нет открытых жалоб" {
+			utils.ErrorResponse(c, http.StatusBadRequest, "У отзыва нет открытых жалоб", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при закрытии жалоб", err)
+		}
+		return
+	}
+
+	review := reviewDetails.Review
+	if review.Status == models.ReviewStatusApproved && (status == models.ReviewFlagStatusActioned || review.IsHidden) {
+		if err := h.repo.Companies.UpdateRating(c, review.CompanyID); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при обновлении рейтинга компании", err)
+			return
+		}
+	}
+
+	h.notifyReporters(c, reviewDetails, flags, status == models.ReviewFlagStatusActioned, input.Comment)
+
+	message := "Жалобы отклонены, отзыв остается опубликованным"
+	if status == models.ReviewFlagStatusActioned {
+		message = "Жалобы подтверждены, отзыв снят с публикации"
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message": message,
+		"flags":   flags,
+	})
+}
+
+// notifyReporters сообщает авторам жалоб о решении модератора. Ошибки отправки не прерывают модерацию.
+func (h *ReviewHandler) notifyReporters(c *gin.Context, review *models.ReviewWithDetails, flags []models.ReviewFlag, actioned bool, comment string) {
+	companyName := ""
+	if company, err := h.repo.Companies.GetByID(c, review.Review.CompanyID); err == nil {
+		companyName = company.Company.Name
+	}
+
+	for _, flag := range flags {
+		if flag.UserID == nil {
+			continue
+		}
+
+		user, err := h.repo.Users.GetByID(c, *flag.UserID)
+		if err != nil {
+			log.Printf("Ошибка при получении автора жалобы %d: %v", flag.ID, err)
+			continue
+		}
+
+		if err := h.mailer.Send(c, mailer.ReviewFlagResolvedMessage(user.Email, companyName, actioned, comment)); err != nil {
+			log.Printf("Ошибка при отправке уведомления о жалобе %d пользователю %d: %v", flag.ID, user.ID, err)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	"job_solition/internal/models"
+	"job_solition/internal/moderation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// authorStats возвращает историю модерации отзывов автора для правил предмодерации.
+func (h *ReviewHandler) authorStats(c *gin.Context, userID int) (moderation.AuthorStats, error) {
+	counts, err := h.repo.Reviews.CountByUser(c, userID)
+	if err != nil {
+		return moderation.AuthorStats{}, err
+	}
+
+	return moderation.AuthorStats{
+		ApprovedReviews: counts[models.ReviewStatusApproved],
+		RejectedReviews: counts[models.ReviewStatusRejected],
+	}, nil
+}
+
+// preModerateReview прогоняет еще не прошедший модерацию отзыв через правила предмодерации и применяет
+// решение: отзыв публикуется, отклоняется или остается в очереди модерации. Результат сохраняется
+// для модераторов. Ошибки записываются в лог, а отзыв в этом случае просто ждет модератора.
+func (h *ReviewHandler) preModerateReview(c *gin.Context, review *models.Review) *models.ModerationTrace {
+	if !h.cfg.Moderation.AutoModeration {
+		return nil
+	}
+
+	author, err := h.authorStats(c, review.UserID)
+	if err != nil {
+		log.Printf("Ошибка при предмодерации отзыва %d: %v", review.ID, err)
+		return nil
+	}
+
+	trace := h.moderator.Evaluate(moderation.Input{
+		Position:   review.Position,
+		Pros:       review.Pros,
+		Cons:       review.Cons,
+		Author:     author,
+		Suspicious: review.IsSuspicious,
+	})
+
+	status, err := h.repo.Reviews.ApplyModeration(c, review.ID, trace)
+	if err != nil {
+		log.Printf("Ошибка при предмодерации отзыва %d: %v", review.ID, err)
+		return nil
+	}
+
+	if status != review.Status {
+		now := time.Now()
+		review.Status = status
+		review.UpdatedAt = now
+
+		switch status {
+		case models.ReviewStatusApproved:
+			review.ApprovedAt = sql.NullTime{Time: now, Valid: true}
+			if err := h.repo.Companies.UpdateRating(c, review.CompanyID); err != nil {
+				log.Printf("Ошибка при обновлении рейтинга компании %d: %v", review.CompanyID, err)
+			}
+		case models.ReviewStatusRejected:
+			review.ModerationComment = sql.NullString{String: trace.RejectionComment(), Valid: true}
+		}
+	}
+
+	return &trace
+}
+
+// preModerateRevision прогоняет ожидающую модерации правку одобренного отзыва через правила предмодерации.
+// При решении approve правка применяется к отзыву, при reject - отклоняется, иначе ждет модератора.
+func (h *ReviewHandler) preModerateRevision(c *gin.Context, review *models.Review, revision *models.ReviewRevision) *models.ModerationTrace {
+	if !h.cfg.Moderation.AutoModeration {
+		return nil
+	}
+
+	author, err := h.authorStats(c, review.UserID)
+	if err != nil {
+		log.Printf("Ошибка при предмодерации правки %d: %v", revision.ID, err)
+		return nil
+	}
+
+	trace := h.moderator.Evaluate(moderation.Input{
+		Position:   revision.Content.Position,
+		Pros:       revision.Content.Pros,
+		Cons:       revision.Content.Cons,
+		Author:     author,
+		Suspicious: review.IsSuspicious,
+	})
+	trace.RevisionID = &revision.ID
+
+	if err := h.repo.Reviews.SaveModerationTrace(c, review.ID, trace); err != nil {
+		log.Printf("Ошибка при предмодерации правки %d: %v", revision.ID, err)
+		return nil
+	}
+
+	var moderated *models.ReviewRevision
+	switch trace.Outcome {
+	case models.ModerationOutcomeApprove:
+		moderated, err = h.repo.ReviewRevisions.Approve(c, revision.ID, nil, "")
+		if err == nil {
+			if err := h.repo.Companies.UpdateRating(c, review.CompanyID); err != nil {
+				log.Printf("Ошибка при обновлении рейтинга компании %d: %v", review.CompanyID, err)
+			}
+			h.detectDuplicates(c, review)
+		}
+	case models.ModerationOutcomeReject:
+		moderated, err = h.repo.ReviewRevisions.Reject(c, revision.ID, nil, trace.RejectionComment())
+	default:
+		return &trace
+	}
+
+	if err != nil {
+		log.Printf("Ошибка при предмодерации правки %d: %v", revision.ID, err)
+		return &trace
+	}
+	*revision = *moderated
+
+	return &trace
+}
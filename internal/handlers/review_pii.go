@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"job_solition/internal/models"
+)
+
+// redactPII скрывает персональные данные в тексте отзыва перед публичной выдачей. Исходный текст
+// в базе не меняется и доступен модераторам.
+func (h *ReviewHandler) redactPII(review *models.Review) {
+	review.Pros = h.pii.Redact(review.Pros)
+	review.Cons = h.pii.Redact(review.Cons)
+}
+
+// detectPII возвращает фрагменты с персональными данными в тексте отзыва для подсветки модераторам.
+func (h *ReviewHandler) detectPII(review models.Review) []models.PIISpan {
+	return append(h.pii.Detect("pros", review.Pros), h.pii.Detect("cons", review.Cons)...)
+}
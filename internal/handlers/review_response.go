@@ -0,0 +1,369 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"job_solition/internal/middleware"
+	"job_solition/internal/models"
+	"job_solition/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Ответ компании на отзыв
+// @Description Создает официальный ответ компании на одобренный отзыв или заменяет текст существующего. Доступно только подтвержденным представителям компании с правом reviews:respond. Каждая версия ответа проходит модерацию, до ее одобрения вместе с отзывом показывается прежняя опубликованная версия
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID отзыва"
+// @Param input body models.ReviewResponseInput true "Текст ответа"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /reviews/{id}/response [put]
+func (h *ReviewHandler) SaveReviewResponse(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	id, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	var input models.ReviewResponseInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	reviewDetails, err := h.repo.Reviews.GetByID(c, id)
+	if err != nil {
+		if err.Error() == "отзыв не найден" {
+			utils.ErrorResponse(c, http.StatusNotFound, "Отзыв не найден", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении отзыва", err)
+		}
+		return
+	}
+
+	review := reviewDetails.Review
+	if review.Status != models.ReviewStatusApproved {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ответить можно только на опубликованный отзыв", nil)
+		return
+	}
+
+	isRepresentative, err := h.repo.Representatives.HasPermission(c, userID.(int), review.CompanyID, models.RepresentativePermissionReviewsRespond)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке представителя компании", err)
+		return
+	}
+
+	if !isRepresentative {
+		utils.ErrorResponse(c, http.StatusForbidden, "Отвечать на отзывы могут только подтвержденные представители компании с правом на ответы", nil)
+		return
+	}
+
+	existing, err := h.repo.ReviewResponses.GetByReview(c, review.ID)
+	if err != nil && err.Error() != "ответ не найден" {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении ответа на отзыв", err)
+		return
+	}
+
+	if existing != nil && existing.Text == input.Text && existing.Status != models.ReviewResponseStatusRejected {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Нет изменений", nil)
+		return
+	}
+
+	authorID := userID.(int)
+	response := models.ReviewResponse{
+		ReviewID:  review.ID,
+		CompanyID: review.CompanyID,
+		AuthorID:  &authorID,
+		Text:      input.Text,
+		UpdatedAt: time.Now(),
+	}
+
+	if err := h.repo.ReviewResponses.Save(c, &response); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при сохранении ответа на отзыв", err)
+		return
+	}
+
+	message := "Ответ отправлен на модерацию"
+	if response.PublishedText != nil {
+		message = "Изменения ответа отправлены на модерацию, до их одобрения опубликована прежняя версия"
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message":  message,
+		"response": response,
+	})
+}
+
+// @Summary Ответ компании на отзыв с состоянием модерации
+// @Description Возвращает последнюю версию ответа компании на отзыв вместе со статусом модерации. Доступно представителям компании с правом reviews:respond, модераторам и администраторам
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID отзыва"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /reviews/{id}/response [get]
+func (h *ReviewHandler) GetReviewResponse(c *gin.Context) {
+	response, ok := h.loadManagedResponse(c)
+	if !ok {
+		return
+	}
+
+	utils.Response(c, http.StatusOK, response)
+}
+
+// @Summary Удаление ответа компании на отзыв
+// @Description Удаляет ответ компании на отзыв, включая опубликованную версию. Доступно представителям компании, модераторам и администраторам
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID отзыва"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /reviews/{id}/response [delete]
+func (h *ReviewHandler) DeleteReviewResponse(c *gin.Context) {
+	response, ok := h.loadManagedResponse(c)
+	if !ok {
+		return
+	}
+
+	deleted, err := h.repo.ReviewResponses.DeleteByReview(c, response.ReviewID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при удалении ответа на отзыв", err)
+		return
+	}
+
+	if !deleted {
+		utils.ErrorResponse(c, http.StatusNotFound, "Ответ не найден", nil)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{"message": "Ответ на отзыв удален"})
+}
+
+// loadManagedResponse возвращает ответ на отзыв из параметра id, если текущий пользователь
+// представляет компанию или является модератором.
+func (h *ReviewHandler) loadManagedResponse(c *gin.Context) (*models.ReviewResponse, bool) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return nil, false
+	}
+
+	id, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return nil, false
+	}
+
+	response, err := h.repo.ReviewResponses.GetByReview(c, id)
+	if err != nil {
+		if err.Error() == "ответ не найден" {
+			utils.ErrorResponse(c, http.StatusNotFound, "Ответ не найден", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении ответа на отзыв", err)
+		}
+		return nil, false
+	}
+
+	role, _ := c.Get(middleware.RoleKey)
+	if !role.(models.UserRole).IsStaff() {
+		isRepresentative, err := h.repo.Representatives.HasPermission(c, userID.(int), response.CompanyID, models.RepresentativePermissionReviewsRespond)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке представителя компании", err)
+			return nil, false
+		}
+
+		if !isRepresentative {
+			utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав для управления ответом компании", nil)
+			return nil, false
+		}
+	}
+
+	return response, true
+}
+
+// @Summary Ответы компаний на модерации
+// @Description Возвращает версии ответов компаний на отзывы, ожидающие модерации
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Номер страницы"
+// @Param limit query int false "Количество записей на странице"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/reviews/moderation/responses [get]
+func (h *ReviewHandler) GetPendingResponses(c *gin.Context) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || (roleValue.(models.UserRole) != models.RoleModerator && roleValue.(models.UserRole) != models.RoleAdmin) {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав для просмотра ответов на модерации", nil)
+		return
+	}
+
+	var page, limit int
+
+	if pageStr := c.Query("page"); pageStr != "" {
+		pageVal, err := strconv.Atoi(pageStr)
+		if err == nil && pageVal > 0 {
+			page = pageVal
+		}
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limitVal, err := strconv.Atoi(limitStr)
+		if err == nil && limitVal > 0 {
+			limit = limitVal
+		}
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+
+	if limit <= 0 {
+		limit = 10
+	}
+
+	responses, total, err := h.repo.ReviewResponses.GetPending(c, page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении ответов на отзывы", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"responses": responses,
+		"pagination": gin.H{
+			"total": total,
+			"page":  page,
+			"limit": limit,
+			"pages": (total + limit - 1) / limit,
+		},
+	})
+}
+
+// @Summary Одобрение ответа компании
+// @Description Публикует ожидающую модерации версию ответа компании на отзыв
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param responseId path int true "ID ответа"
+// @Param input body models.ReviewResponseModerationInput false "Комментарий модератора"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/reviews/responses/{responseId}/approve [put]
+func (h *ReviewHandler) ApproveResponse(c *gin.Context) {
+	h.moderateResponse(c, models.ReviewResponseStatusApproved)
+}
+
+// @Summary Отклонение ответа компании
+// @Description Отклоняет ожидающую модерации версию ответа компании на отзыв. Опубликованная ранее версия ответа сохраняется
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param responseId path int true "ID ответа"
+// @Param input body models.ReviewResponseModerationInput false "Причина отклонения"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/reviews/responses/{responseId}/reject [put]
+func (h *ReviewHandler) RejectResponse(c *gin.Context) {
+	h.moderateResponse(c, models.ReviewResponseStatusRejected)
+}
+
+func (h *ReviewHandler) moderateResponse(c *gin.Context, status models.ReviewResponseStatus) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || (roleValue.(models.UserRole) != models.RoleModerator && roleValue.(models.UserRole) != models.RoleAdmin) {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав для модерации ответов", nil)
+		return
+	}
+
+	id, err := utils.ParseIDParam(c, "responseId")
+	if err != nil {
+		return
+	}
+
+	var input models.ReviewResponseModerationInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+			return
+		}
+	}
+
+	response, err := h.repo.ReviewResponses.GetByID(c, id)
+	if err != nil {
+		if err.Error() == "ответ не найден" {
+			utils.ErrorResponse(c, http.StatusNotFound, "Ответ не найден", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении ответа на отзыв", err)
+		}
+		return
+	}
+
+	if response.Status != models.ReviewResponseStatusPending {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ответ не ожидает модерации", nil)
+		return
+	}
+
+	moderatorID := c.GetInt(middleware.UserIDKey)
+
+	if status == models.ReviewResponseStatusRejected {
+		response, err = h.repo.ReviewResponses.Reject(c, id, moderatorID, input.ModerationComment)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отклонении ответа на отзыв", err)
+			return
+		}
+
+		utils.Response(c, http.StatusOK, gin.H{
+			"message":  "Ответ на отзыв отклонен",
+			"response": response,
+		})
+		return
+	}
+
+	response, err = h.repo.ReviewResponses.Approve(c, id, moderatorID, input.ModerationComment)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при одобрении ответа на отзыв", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message":  "Ответ на отзыв одобрен",
+		"response": response,
+	})
+}
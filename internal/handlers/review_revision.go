@@ -0,0 +1,379 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"job_solition/internal/middleware"
+	"job_solition/internal/models"
+	"job_solition/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Редактирование отзыва автором
+// @Description Изменяет текст, оценки по категориям и льготы собственного отзыва. Каждая правка сохраняется в истории. Неодобренный отзыв меняется сразу, правка одобренного отзыва отправляется на модерацию, а до ее одобрения опубликованной остается прежняя версия. Если включена автоматическая предмодерация, отзыв и правка могут быть сразу одобрены или отклонены
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID отзыва"
+// @Param input body models.ReviewEditInput true "Изменяемые поля"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /reviews/{id} [put]
+func (h *ReviewHandler) UpdateReview(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	id, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	var input models.ReviewEditInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	reviewDetails, err := h.repo.Reviews.GetByID(c, id)
+	if err != nil {
+		if err.Error() == "отзыв не найден" {
+			utils.ErrorResponse(c, http.StatusNotFound, "Отзыв не найден", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении отзыва", err)
+		}
+		return
+	}
+
+	review := reviewDetails.Review
+	if review.UserID != userID.(int) {
+		utils.ErrorResponse(c, http.StatusForbidden, "Можно изменять только собственные отзывы", nil)
+		return
+	}
+
+	if review.Status != models.ReviewStatusPending && review.Status != models.ReviewStatusApproved {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Отклоненный или отозванный отзыв нельзя изменить", nil)
+		return
+	}
+
+	validationErrors, err := h.validateReviewOptions(c, input.CategoryRatings, input.BenefitTypeIDs)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке данных отзыва", err)
+		return
+	}
+	if len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	published := models.NewReviewContent(reviewDetails)
+	current := published
+
+	if review.Status == models.ReviewStatusApproved {
+		pendingRevision, err := h.repo.ReviewRevisions.GetPendingByReview(c, review.ID)
+		if err != nil && err.Error() != "правка не найдена" {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении правки отзыва", err)
+			return
+		}
+		if pendingRevision != nil {
+			current = pendingRevision.Content
+		}
+	}
+
+	content := current.Apply(input)
+	if len(current.Diff(content)) == 0 {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Нет изменений", nil)
+		return
+	}
+
+	changes := published.Diff(content)
+	if len(changes) == 0 {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Правка совпадает с опубликованной версией отзыва", nil)
+		return
+	}
+
+	editorID := userID.(int)
+	revision := models.ReviewRevision{
+		ReviewID:  review.ID,
+		EditorID:  &editorID,
+		Content:   content,
+		Changes:   changes,
+		CreatedAt: time.Now(),
+	}
+
+	if _, err := h.repo.ReviewRevisions.Create(c, &revision); err != nil {
+		if err.Error() == "отзыв нельзя изменить" {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Отклоненный или отозванный отзыв нельзя изменить", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при сохранении правки отзыва", err)
+		}
+		return
+	}
+
+	message := "Отзыв обновлен"
+	if revision.Status == models.ReviewRevisionStatusApplied {
+		review.Position = content.Position
+		review.Pros = content.Pros
+		review.Cons = content.Cons
+		review.IsFormerEmployee = content.IsFormerEmployee
+		review.IsRecommended = content.IsRecommended
+		review.Rating = content.Rating()
+
+		h.detectDuplicates(c, &review)
+		h.preModerateReview(c, &review)
+		message = reviewStatusMessage(review.Status)
+	} else {
+		h.preModerateRevision(c, &review, &revision)
+
+		switch revision.Status {
+		case models.ReviewRevisionStatusApproved:
+			message = "Изменения опубликованы"
+		case models.ReviewRevisionStatusRejected:
+			message = "Изменения отклонены автоматической проверкой, опубликована прежняя версия отзыва"
+		default:
+			message = "Изменения отправлены на модерацию, до их одобрения опубликована прежняя версия отзыва"
+		}
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message":  message,
+		"revision": revision,
+	})
+}
+
+// @Summary История правок отзыва
+// @Description Возвращает правки отзыва с изменениями относительно опубликованной версии. Доступно автору отзыва, модераторам и администраторам
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID отзыва"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /reviews/{id}/revisions [get]
+func (h *ReviewHandler) GetReviewRevisions(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	id, err := utils.ParseIDParam(c, "id")
+	if err != nil {
+		return
+	}
+
+	reviewDetails, err := h.repo.Reviews.GetByID(c, id)
+	if err != nil {
+		if err.Error() == "отзыв не найден" {
+			utils.ErrorResponse(c, http.StatusNotFound, "Отзыв не найден", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении отзыва", err)
+		}
+		return
+	}
+
+	role, _ := c.Get(middleware.RoleKey)
+	if reviewDetails.Review.UserID != userID.(int) && !role.(models.UserRole).IsStaff() {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав для просмотра истории отзыва", nil)
+		return
+	}
+
+	revisions, err := h.repo.ReviewRevisions.GetByReview(c, id)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении правок отзыва", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, revisions)
+}
+
+// @Summary Правки отзывов на модерации
+// @Description Возвращает правки одобренных отзывов, ожидающие модерации, с изменениями относительно опубликованной версии
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Номер страницы"
+// @Param limit query int false "Количество записей на странице"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/reviews/moderation/revisions [get]
+func (h *ReviewHandler) GetPendingRevisions(c *gin.Context) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || (roleValue.(models.UserRole) != models.RoleModerator && roleValue.(models.UserRole) != models.RoleAdmin) {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав для просмотра отзывов на модерации", nil)
+		return
+	}
+
+	var page, limit int
+
+	if pageStr := c.Query("page"); pageStr != "" {
+		pageVal, err := strconv.Atoi(pageStr)
+		if err == nil && pageVal > 0 {
+			page = pageVal
+		}
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limitVal, err := strconv.Atoi(limitStr)
+		if err == nil && limitVal > 0 {
+			limit = limitVal
+		}
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+
+	if limit <= 0 {
+		limit = 10
+	}
+
+	revisions, total, err := h.repo.ReviewRevisions.GetPending(c, page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении правок отзывов", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"revisions": revisions,
+		"pagination": gin.H{
+			"total": total,
+			"page":  page,
+			"limit": limit,
+			"pages": (total + limit - 1) / limit,
+		},
+	})
+}
+
+// @Summary Одобрение правки отзыва
+// @Description Публикует правку одобренного отзыва и пересчитывает рейтинг компании
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param revisionId path int true "ID правки"
+// @Param input body models.ReviewRevisionModerationInput false "Комментарий модератора"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/reviews/revisions/{revisionId}/approve [put]
+func (h *ReviewHandler) ApproveRevision(c *gin.Context) {
+	h.moderateRevision(c, models.ReviewRevisionStatusApproved)
+}
+
+// @Summary Отклонение правки отзыва
+// @Description Отклоняет правку одобренного отзыва. Опубликованная версия отзыва не меняется
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param revisionId path int true "ID правки"
+// @Param input body models.ReviewRevisionModerationInput false "Причина отклонения"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /admin/reviews/revisions/{revisionId}/reject [put]
+func (h *ReviewHandler) RejectRevision(c *gin.Context) {
+	h.moderateRevision(c, models.ReviewRevisionStatusRejected)
+}
+
+func (h *ReviewHandler) moderateRevision(c *gin.Context, status models.ReviewRevisionStatus) {
+	roleValue, exists := c.Get(middleware.RoleKey)
+	if !exists || (roleValue.(models.UserRole) != models.RoleModerator && roleValue.(models.UserRole) != models.RoleAdmin) {
+		utils.ErrorResponse(c, http.StatusForbidden, "Недостаточно прав для модерации отзывов", nil)
+		return
+	}
+
+	id, err := utils.ParseIDParam(c, "revisionId")
+	if err != nil {
+		return
+	}
+
+	var input models.ReviewRevisionModerationInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+			return
+		}
+	}
+
+	revision, err := h.repo.ReviewRevisions.GetByID(c, id)
+	if err != nil {
+		if err.Error() == "правка не найдена" {
+			utils.ErrorResponse(c, http.StatusNotFound, "Правка не найдена", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении правки отзыва", err)
+		}
+		return
+	}
+
+	if revision.Status != models.ReviewRevisionStatusPending {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Правка не ожидает модерации", nil)
+		return
+	}
+
+	moderatorID := c.GetInt(middleware.UserIDKey)
+
+	if status == models.ReviewRevisionStatusRejected {
+		revision, err = h.repo.ReviewRevisions.Reject(c, id, &moderatorID, input.ModerationComment)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отклонении правки отзыва", err)
+			return
+		}
+
+		utils.Response(c, http.StatusOK, gin.H{
+			"message":  "Правка отзыва отклонена",
+			"revision": revision,
+		})
+		return
+	}
+
+	revision, err = h.repo.ReviewRevisions.Approve(c, id, &moderatorID, input.ModerationComment)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при одобрении правки отзыва", err)
+		return
+	}
+
+	reviewDetails, err := h.repo.Reviews.GetByID(c, revision.ReviewID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении отзыва", err)
+		return
+	}
+
+	if err := h.repo.Companies.UpdateRating(c, reviewDetails.Review.CompanyID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при обновлении рейтинга компании", err)
+		return
+	}
+
+	h.detectDuplicates(c, &reviewDetails.Review)
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message":  "Правка отзыва одобрена",
+		"revision": revision,
+		"review":   reviewDetails,
+	})
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"job_solition/internal/models"
+	"job_solition/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// checkReviewAllowed проверяет, может ли пользователь оставить новый отзыв о компании. Без периода ожидания
+// у пользователя может быть только один ожидающий модерации или опубликованный отзыв о компании, с периодом
+// ожидания новый отзыв разрешается после его истечения. При отказе пишет ответ и возвращает false.
+func (h *ReviewHandler) checkReviewAllowed(c *gin.Context, userID, companyID int) bool {
+	latest, err := h.repo.Reviews.GetLatestActive(c, userID, companyID)
+	if err != nil {
+		if err.Error() == "отзыв не найден" {
+			return true
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке отзывов пользователя", err)
+		return false
+	}
+
+	cooldown := h.cfg.Moderation.ReviewCooldown
+	if cooldown <= 0 {
+		utils.ErrorResponseWithDetails(c, http.StatusConflict, "Вы уже оставили отзыв об этой компании", gin.H{
+			"review_id": latest.ID,
+		})
+		return false
+	}
+
+	nextAllowedAt := latest.CreatedAt.Add(cooldown)
+	if time.Now().Before(nextAllowedAt) {
+		utils.ErrorResponseWithDetails(c, http.StatusConflict, "Новый отзыв об этой компании можно оставить позже", gin.H{
+			"review_id":       latest.ID,
+			"next_allowed_at": nextAllowedAt,
+		})
+		return false
+	}
+
+	return true
+}
+
+// detectDuplicates ищет отзывы с почти таким же текстом и помечает отзыв подозрительным для модераторов.
+// Ошибка поиска не мешает сохранению отзыва и только записывается в лог.
+func (h *ReviewHandler) detectDuplicates(c *gin.Context, review *models.Review) {
+	threshold := h.cfg.Moderation.DuplicateThreshold
+	if threshold <= 0 {
+		return
+	}
+
+	similarities, err := h.repo.ReviewSimilarities.Detect(c, review.ID, threshold)
+	if err != nil {
+		log.Printf("Ошибка при поиске похожих отзывов для отзыва %d: %v", review.ID, err)
+		return
+	}
+
+	review.IsSuspicious = len(similarities) > 0
+}
+
+// attachModerationSignals добавляет к отзывам результаты предмодерации, найденные почти дубликаты
+// и персональные данные в тексте для списков модерации.
+func (h *ReviewHandler) attachModerationSignals(c *gin.Context, reviews []models.ReviewWithDetails) error {
+	reviewIDs := make([]int, 0, len(reviews))
+	suspiciousIDs := make([]int, 0, len(reviews))
+	for _, review := range reviews {
+		reviewIDs = append(reviewIDs, review.Review.ID)
+		if review.Review.IsSuspicious {
+			suspiciousIDs = append(suspiciousIDs, review.Review.ID)
+		}
+	}
+
+	similarities, err := h.repo.ReviewSimilarities.GetByReviews(c, suspiciousIDs)
+	if err != nil {
+		return err
+	}
+
+	traces, err := h.repo.Reviews.GetModerationTraces(c, reviewIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range reviews {
+		id := reviews[i].Review.ID
+		signals := models.ModerationSignals{
+			Suspicious:     reviews[i].Review.IsSuspicious,
+			SimilarReviews: similarities[id],
+			PII:            h.detectPII(reviews[i].Review),
+		}
+		if trace, ok := traces[id]; ok {
+			signals.Trace = &trace
+		}
+		if signals.Suspicious || len(signals.SimilarReviews) > 0 || len(signals.PII) > 0 || signals.Trace != nil {
+			reviews[i].ModerationSignals = &signals
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"job_solition/internal/models"
+	"job_solition/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// submitReview проверяет и сохраняет новый отзыв пользователя, после чего запускает поиск почти
+// дубликатов и автоматическую предмодерацию. Ответ клиенту пишется здесь же, при ошибке возвращается nil.
+func (h *ReviewHandler) submitReview(c *gin.Context, userID int, input models.ReviewInput) *models.Review {
+	_, err := h.repo.Companies.GetByID(c, input.CompanyID)
+	if err != nil {
+		if err.Error() == "компания не найдена" {
+			utils.ErrorResponse(c, http.StatusNotFound, "Компания не найдена", nil)
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке компании", err)
+		}
+		return nil
+	}
+
+	if !h.checkReviewAllowed(c, userID, input.CompanyID) {
+		return nil
+	}
+
+	validationErrors, err := h.validateReviewReferences(c, input)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке данных отзыва", err)
+		return nil
+	}
+	if len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return nil
+	}
+
+	review := models.NewReview(userID, input)
+
+	id, err := h.repo.Reviews.Create(c, review, input.CategoryRatings, input.BenefitTypeIDs, h.cfg.Moderation.ReviewCooldown)
+	if err != nil {
+		switch {
+		case err.Error() != "отзыв о компании уже оставлен":
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при сохранении отзыва", err)
+		case h.cfg.Moderation.ReviewCooldown <= 0:
+			utils.ErrorResponse(c, http.StatusConflict, "Вы уже оставили отзыв об этой компании", nil)
+		default:
+			utils.ErrorResponse(c, http.StatusConflict, "Новый отзыв об этой компании можно оставить позже", nil)
+		}
+		return nil
+	}
+
+	review.ID = id
+	h.detectDuplicates(c, review)
+	h.preModerateReview(c, review)
+
+	utils.Response(c, http.StatusCreated, gin.H{
+		"review": review,
+		"status": reviewStatusMessage(review.Status),
+	})
+
+	return review
+}
+
+// validateReviewReferences проверяет по справочникам город, период работы, тип занятости, категории
+// рейтинга и льготы отзыва и возвращает все найденные ошибки сразу.
+func (h *ReviewHandler) validateReviewReferences(c *gin.Context, input models.ReviewInput) ([]utils.ValidationError, error) {
+	var validationErrors []utils.ValidationError
+
+	if _, err := h.repo.Cities.GetByID(c, input.CityID); err != nil {
+		if err.Error() != "город не найден" {
+			return nil, err
+		}
+		validationErrors = append(validationErrors, utils.ValidationError{Field: "city_id", Message: "Указанный город не найден"})
+	}
+
+	if _, err := h.repo.EmploymentPeriods.GetByID(c, input.EmploymentPeriodID); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		validationErrors = append(validationErrors, utils.ValidationError{Field: "employment_period_id", Message: "Указанный период работы не найден"})
+	}
+
+	if _, err := h.repo.EmploymentTypes.GetByID(c, input.EmploymentTypeID); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		validationErrors = append(validationErrors, utils.ValidationError{Field: "employment_type_id", Message: "Указанный тип занятости не найден"})
+	}
+
+	optionErrors, err := h.validateReviewOptions(c, input.CategoryRatings, input.BenefitTypeIDs)
+	if err != nil {
+		return nil, err
+	}
+	validationErrors = append(validationErrors, optionErrors...)
+
+	return validationErrors, nil
+}
+
+// validateReviewOptions проверяет, что категории рейтинга и льготы отзыва существуют. Справочники
+// загружаются целиком одним запросом на каждый, ошибки собираются по всем полям сразу.
+func (h *ReviewHandler) validateReviewOptions(c *gin.Context, categoryRatings map[int]float64, benefitTypeIDs []int) ([]utils.ValidationError, error) {
+	var validationErrors []utils.ValidationError
+
+	if len(categoryRatings) > 0 {
+		categories, err := h.repo.RatingCategories.GetAll(c)
+		if err != nil {
+			return nil, err
+		}
+		known := make(map[int]bool, len(categories))
+		for _, category := range categories {
+			known[category.ID] = true
+		}
+
+		categoryIDs := make([]int, 0, len(categoryRatings))
+		for categoryID := range categoryRatings {
+			categoryIDs = append(categoryIDs, categoryID)
+		}
+		sort.Ints(categoryIDs)
+
+		for _, categoryID := range categoryIDs {
+			if !known[categoryID] {
+				validationErrors = append(validationErrors, utils.ValidationError{
+					Field:   fmt.Sprintf("category_ratings.%d", categoryID),
+					Message: fmt.Sprintf("Категория рейтинга %d не найдена", categoryID),
+				})
+			}
+		}
+	}
+
+	if len(benefitTypeIDs) > 0 {
+		benefitTypes, err := h.repo.BenefitTypes.GetAll(c)
+		if err != nil {
+			return nil, err
+		}
+		known := make(map[int]bool, len(benefitTypes))
+		for _, benefitType := range benefitTypes {
+			known[benefitType.ID] = true
+		}
+
+		for i, benefitTypeID := range benefitTypeIDs {
+			if !known[benefitTypeID] {
+				validationErrors = append(validationErrors, utils.ValidationError{
+					Field:   fmt.Sprintf("benefit_type_ids[%d]", i),
+					Message: fmt.Sprintf("Льгота %d не найдена", benefitTypeID),
+				})
+			}
+		}
+	}
+
+	return validationErrors, nil
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"job_solition/internal/middleware"
 	"job_solition/internal/models"
 	"job_solition/internal/repository"
 	"job_solition/internal/utils"
@@ -22,7 +23,7 @@ func NewSuggestionHandlers(repo *repository.Repository) *SuggestionHandlers {
 }
 
 // @Summary Создать новое предложение
-// @Description Создает новое предложение компании или улучшение
+// @Description Создает новое предложение компании или улучшение. Если пользователь авторизован, предложение привязывается к нему
 // @Tags suggestions
 // @Accept json
 // @Produce json
@@ -38,7 +39,13 @@ func (h *SuggestionHandlers) CreateSuggestion(c *gin.Context) {
 		return
 	}
 
-	suggestion := models.NewSuggestion(input)
+	var userID *int
+	if id, ok := c.Get(middleware.UserIDKey); ok {
+		authorID := id.(int)
+		userID = &authorID
+	}
+
+	suggestion := models.NewSuggestion(input, userID)
 	id, err := h.repo.Suggestions.Create(c.Request.Context(), suggestion)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании предложения", err)
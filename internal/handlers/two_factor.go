@@ -0,0 +1,507 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"job_solition/internal/middleware"
+	"job_solition/internal/models"
+	"job_solition/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// twoFactorRequired сообщает, обязана ли учетная запись использовать двухфакторную аутентификацию.
+func (h *AuthHandler) twoFactorRequired(user *models.User) bool {
+	return h.cfg.Security.Require2FAForStaff && user.Role.IsStaff()
+}
+
+func (h *AuthHandler) respondTwoFactorChallenge(c *gin.Context, user *models.User, purpose string) {
+	challengeToken, err := h.jwt.GenerateChallengeToken(user, purpose, h.cfg.Security.TwoFactorChallengeExpires)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании токена", err)
+		return
+	}
+
+	response := gin.H{
+		"challenge_token": challengeToken,
+		"expires_in":      int(h.cfg.Security.TwoFactorChallengeExpires.Seconds()),
+	}
+	if purpose == utils.ChallengePurposeTwoFactorSetup {
+		response["two_factor_setup_required"] = true
+	} else {
+		response["two_factor_required"] = true
+	}
+
+	utils.Response(c, http.StatusOK, response)
+}
+
+func (h *AuthHandler) challengeUser(c *gin.Context, challengeToken, purpose string) (*models.User, bool) {
+	claims, err := h.jwt.ValidateChallengeToken(challengeToken, purpose)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Недействительный или просроченный challenge токен", nil)
+		return nil, false
+	}
+
+	user, err := h.repo.Users.GetByID(c, claims.UserID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Пользователь не найден", nil)
+		return nil, false
+	}
+
+	// После смены пароля или выхода со всех устройств выданные ранее challenge токены недействительны.
+	if claims.Version != user.TokenVersion {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Недействительный или просроченный challenge токен", nil)
+		return nil, false
+	}
+
+	return user, true
+}
+
+func (h *AuthHandler) currentUser(c *gin.Context) (*models.User, bool) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return nil, false
+	}
+
+	user, err := h.repo.Users.GetByID(c, userID.(int))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Пользователь не найден", nil)
+		return nil, false
+	}
+
+	return user, true
+}
+
+// verifyTOTP проверяет код из приложения-аутентификатора. Каждый код принимается только один раз.
+func (h *AuthHandler) verifyTOTP(c *gin.Context, user *models.User, code string) (bool, error) {
+	if !user.IsTwoFactorEnabled() {
+		return false, nil
+	}
+
+	step, ok := h.totp.Validate(*user.TOTPSecret, code, user.LastTOTPStep())
+	if !ok {
+		return false, nil
+	}
+
+	return h.repo.Users.UseTOTPStep(c, user.ID, step)
+}
+
+// verifySecondFactor принимает либо код TOTP, либо один из кодов восстановления.
+func (h *AuthHandler) verifySecondFactor(c *gin.Context, user *models.User, code string) (bool, error) {
+	ok, err := h.verifyTOTP(c, user, code)
+	if err != nil || ok {
+		return ok, err
+	}
+
+	used, err := h.repo.RecoveryCodes.Use(c, user.ID, utils.HashRecoveryCode(code))
+	if err != nil {
+		return false, err
+	}
+
+	if used {
+		h.recordSecurityEvent(c, user.ID, models.SecurityEventRecoveryCodeUsed, "")
+	}
+
+	return used, nil
+}
+
+func (h *AuthHandler) newRecoveryCodes(c *gin.Context, user *models.User) ([]string, error) {
+	codes, err := utils.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = utils.HashRecoveryCode(code)
+	}
+
+	if err := h.repo.RecoveryCodes.Replace(c, user.ID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (h *AuthHandler) beginTwoFactorSetup(c *gin.Context, user *models.User) {
+	if user.IsTwoFactorEnabled() {
+		utils.ErrorResponse(c, http.StatusConflict, "Двухфакторная аутентификация уже включена", nil)
+		return
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании секрета", err)
+		return
+	}
+
+	if err := h.repo.Users.SetTOTPSecret(c, user.ID, secret); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при сохранении секрета", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, models.TwoFactorSetup{
+		Secret:     secret,
+		OTPAuthURI: h.totp.URI(user.Email, secret),
+	})
+}
+
+// twoFactorSetupStarted проверяет, что подключение 2FA начато и еще не завершено.
+// При ошибке ответ уже отправлен и возвращается false.
+func (h *AuthHandler) twoFactorSetupStarted(c *gin.Context, user *models.User) bool {
+	if user.IsTwoFactorEnabled() {
+		utils.ErrorResponse(c, http.StatusConflict, "Двухфакторная аутентификация уже включена", nil)
+		return false
+	}
+
+	if user.TOTPSecret == nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Сначала начните подключение двухфакторной аутентификации", nil)
+		return false
+	}
+
+	return true
+}
+
+// enableTwoFactor включает 2FA, подтвержденную кодом из приложения для шага step, и выдает коды восстановления.
+// При ошибке ответ уже отправлен и возвращается false.
+func (h *AuthHandler) enableTwoFactor(c *gin.Context, user *models.User, step int64) ([]string, bool) {
+	if err := h.repo.Users.EnableTOTP(c, user.ID, step); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при включении двухфакторной аутентификации", err)
+		return nil, false
+	}
+
+	enabledAt := time.Now()
+	user.TOTPEnabledAt = &enabledAt
+	user.TOTPLastStep = &step
+
+	codes, err := h.newRecoveryCodes(c, user)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании кодов восстановления", err)
+		return nil, false
+	}
+
+	h.recordSecurityEvent(c, user.ID, models.SecurityEventTwoFactorEnabled, "")
+
+	return codes, true
+}
+
+// @Summary Второй шаг входа
+// @Description Завершает вход пользователя с включенной двухфакторной аутентификацией. Принимает код из приложения или код восстановления
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body models.TwoFactorLoginInput true "Challenge токен и код"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 429 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /auth/login/2fa [post]
+func (h *AuthHandler) LoginTwoFactor(c *gin.Context) {
+	var input models.TwoFactorLoginInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	user, ok := h.challengeUser(c, input.ChallengeToken, utils.ChallengePurposeTwoFactorLogin)
+	if !ok {
+		return
+	}
+
+	lockedUntil, err := h.loginLockedUntil(c, user.Email, user)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке блокировки входа", err)
+		return
+	}
+
+	if lockedUntil != nil {
+		h.respondLoginLocked(c, *lockedUntil)
+		return
+	}
+
+	verified, err := h.verifySecondFactor(c, user, input.Code)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке кода", err)
+		return
+	}
+
+	if !verified {
+		h.rejectLogin(c, user.Email, user, models.LoginFailureInvalidTwoFactorCode, "Неверный код подтверждения")
+		return
+	}
+
+	h.completeLogin(c, user, true, nil)
+}
+
+// @Summary Подключение 2FA при входе
+// @Description Начинает обязательное подключение двухфакторной аутентификации для администраторов и модераторов. Возвращает секрет и otpauth ссылку
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body models.TwoFactorChallengeInput true "Challenge токен из ответа /auth/login"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 409 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /auth/2fa/setup [post]
+func (h *AuthHandler) SetupTwoFactorOnLogin(c *gin.Context) {
+	var input models.TwoFactorChallengeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	user, ok := h.challengeUser(c, input.ChallengeToken, utils.ChallengePurposeTwoFactorSetup)
+	if !ok {
+		return
+	}
+
+	h.beginTwoFactorSetup(c, user)
+}
+
+// @Summary Подтверждение 2FA при входе
+// @Description Подтверждает подключение двухфакторной аутентификации кодом из приложения и завершает вход. Коды восстановления показываются один раз
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body models.TwoFactorLoginInput true "Challenge токен и код"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 409 {object} utils.ErrorResponseDTO
+// @Failure 429 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /auth/2fa/enable [post]
+func (h *AuthHandler) EnableTwoFactorOnLogin(c *gin.Context) {
+	var input models.TwoFactorLoginInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	user, ok := h.challengeUser(c, input.ChallengeToken, utils.ChallengePurposeTwoFactorSetup)
+	if !ok {
+		return
+	}
+
+	lockedUntil, err := h.loginLockedUntil(c, user.Email, user)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке блокировки входа", err)
+		return
+	}
+
+	if lockedUntil != nil {
+		h.respondLoginLocked(c, *lockedUntil)
+		return
+	}
+
+	if !h.twoFactorSetupStarted(c, user) {
+		return
+	}
+
+	step, ok := h.totp.Validate(*user.TOTPSecret, input.Code, 0)
+	if !ok {
+		h.rejectLogin(c, user.Email, user, models.LoginFailureInvalidTwoFactorCode, "Неверный код подтверждения")
+		return
+	}
+
+	recoveryCodes, ok := h.enableTwoFactor(c, user, step)
+	if !ok {
+		return
+	}
+
+	h.completeLogin(c, user, true, gin.H{
+		"recovery_codes": recoveryCodes,
+	})
+}
+
+// @Summary Начало подключения 2FA
+// @Description Создает секрет TOTP для текущего пользователя и возвращает его вместе с otpauth ссылкой для приложения-аутентификатора
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 409 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/2fa/setup [post]
+func (h *AuthHandler) SetupTwoFactor(c *gin.Context) {
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	h.beginTwoFactorSetup(c, user)
+}
+
+// @Summary Включение 2FA
+// @Description Подтверждает подключение двухфакторной аутентификации кодом из приложения. Коды восстановления показываются один раз
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body models.TwoFactorCodeInput true "Код из приложения"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 409 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/2fa/enable [post]
+func (h *AuthHandler) EnableTwoFactor(c *gin.Context) {
+	var input models.TwoFactorCodeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	if !h.twoFactorSetupStarted(c, user) {
+		return
+	}
+
+	step, ok := h.totp.Validate(*user.TOTPSecret, input.Code, 0)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Неверный код подтверждения", nil)
+		return
+	}
+
+	recoveryCodes, ok := h.enableTwoFactor(c, user, step)
+	if !ok {
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message":        "Двухфакторная аутентификация включена",
+		"recovery_codes": recoveryCodes,
+	})
+}
+
+// @Summary Отключение 2FA
+// @Description Отключает двухфакторную аутентификацию. Требует пароль и код из приложения или код восстановления
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body models.TwoFactorDisableInput true "Пароль и код"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 403 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/2fa/disable [post]
+func (h *AuthHandler) DisableTwoFactor(c *gin.Context) {
+	var input models.TwoFactorDisableInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	if h.twoFactorRequired(user) {
+		utils.ErrorResponse(c, http.StatusForbidden, "Для администраторов и модераторов двухфакторная аутентификация обязательна", nil)
+		return
+	}
+
+	if !user.IsTwoFactorEnabled() {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Двухфакторная аутентификация не включена", nil)
+		return
+	}
+
+	if !user.ComparePassword(input.Password) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Неверный пароль", nil)
+		return
+	}
+
+	verified, err := h.verifySecondFactor(c, user, input.Code)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке кода", err)
+		return
+	}
+
+	if !verified {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Неверный код подтверждения", nil)
+		return
+	}
+
+	if err := h.repo.Users.DisableTOTP(c, user.ID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отключении двухфакторной аутентификации", err)
+		return
+	}
+
+	if err := h.repo.RecoveryCodes.DeleteByUserID(c, user.ID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при удалении кодов восстановления", err)
+		return
+	}
+
+	h.recordSecurityEvent(c, user.ID, models.SecurityEventTwoFactorDisabled, "")
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message": "Двухфакторная аутентификация отключена",
+	})
+}
+
+// @Summary Новые коды восстановления
+// @Description Создает новый набор кодов восстановления взамен прежнего. Требует код из приложения
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body models.TwoFactorCodeInput true "Код из приложения"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/2fa/recovery-codes [post]
+func (h *AuthHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var input models.TwoFactorCodeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации", err)
+		return
+	}
+
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	if !user.IsTwoFactorEnabled() {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Двухфакторная аутентификация не включена", nil)
+		return
+	}
+
+	verified, err := h.verifyTOTP(c, user, input.Code)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке кода", err)
+		return
+	}
+
+	if !verified {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Неверный код подтверждения", nil)
+		return
+	}
+
+	recoveryCodes, err := h.newRecoveryCodes(c, user)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при создании кодов восстановления", err)
+		return
+	}
+
+	h.recordSecurityEvent(c, user.ID, models.SecurityEventRecoveryCodesReset, "")
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"recovery_codes": recoveryCodes,
+	})
+}
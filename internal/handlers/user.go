@@ -2,29 +2,36 @@ package handlers
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
 	"job_solition/internal/config"
 	"job_solition/internal/db"
+	"job_solition/internal/export"
 	"job_solition/internal/middleware"
 	"job_solition/internal/models"
 	"job_solition/internal/repository"
 	"job_solition/internal/utils"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type UserHandler struct {
-	repo *repository.Repository
-	cfg  *config.Config
+	repo     *repository.Repository
+	cfg      *config.Config
+	password *utils.PasswordPolicy
+	exporter *export.Builder
 }
 
 func NewUserHandler(postgres *db.PostgreSQL, cfg *config.Config) *UserHandler {
 	repo := repository.NewRepository(postgres)
 	return &UserHandler{
-		repo: repo,
-		cfg:  cfg,
+		repo:     repo,
+		cfg:      cfg,
+		password: utils.NewPasswordPolicy(cfg.Security.PasswordPolicy),
+		exporter: export.NewBuilder(repo, cfg.Security.DataExportExpiresIn),
 	}
 }
 
@@ -55,7 +62,7 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 }
 
 // @Summary Обновление профиля
-// @Description Обновляет профиль текущего пользователя
+// @Description Обновляет профиль текущего пользователя. При смене пароля остальные сессии завершаются, а выданные access токены перестают действовать: текущей сессии нужно получить новый токен через /auth/refresh
 // @Tags users
 // @Accept json
 // @Produce json
@@ -107,6 +114,10 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 		user.LastName = *input.LastName
 	}
 	if input.Password != nil {
+		if !validateNewPassword(c, h.repo, h.password, *input.Password, user) {
+			return
+		}
+
 		passwordHash, err := bcrypt.GenerateFromPassword([]byte(*input.Password), bcrypt.DefaultCost)
 		if err != nil {
 			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при хешировании пароля", err)
@@ -123,19 +134,40 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
+	if input.Password != nil {
+		rememberPassword(c, h.repo, h.password, user)
+
+		if currentSessionID := c.GetString(middleware.SessionIDKey); currentSessionID != "" {
+			if err := h.repo.RefreshTokens.DeleteOtherSessions(c, user.ID, currentSessionID); err != nil {
+				utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при завершении сессий", err)
+				return
+			}
+		}
+
+		if err := h.repo.Users.IncrementTokenVersion(c, user.ID); err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при отзыве access токенов", err)
+			return
+		}
+		middleware.InvalidateTokenState(user.ID)
+	}
+
 	utils.Response(c, http.StatusOK, user.ToProfile())
 }
 
 // @Summary Отзывы пользователя
-// @Description Возвращает отзывы текущего пользователя
+// @Description Возвращает отзывы текущего пользователя с состоянием модерации: статусом, комментарием модератора и последней правкой, если она ожидает модерации или отклонена. В counts - количество отзывов по статусам
 // @Tags users
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param status query string false "Статус отзывов (pending, approved, rejected)"
+// @Param status query []string false "Статусы отзывов (pending, approved, rejected, withdrawn), несколько значений или через запятую" collectionFormat(multi)
+// @Param company_id query int false "ID компании"
+// @Param sort_by query string false "Поле для сортировки (rating, created_at, useful_count)"
+// @Param sort_order query string false "Порядок сортировки (asc, desc)"
 // @Param page query int false "Номер страницы"
 // @Param limit query int false "Количество записей на странице"
 // @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
 // @Failure 401 {object} utils.ErrorResponseDTO
 // @Failure 500 {object} utils.ErrorResponseDTO
 // @Router /users/me/reviews [get]
@@ -146,14 +178,28 @@ func (h *UserHandler) GetUserReviews(c *gin.Context) {
 		return
 	}
 
-	var filter models.ReviewFilter
-	if err := c.ShouldBindQuery(&filter); err != nil {
+	var input models.UserReviewFilter
+	if err := c.ShouldBindQuery(&input); err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка валидации параметров", err)
 		return
 	}
 
+	statuses, ok := parseReviewStatuses(input.Status)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Неверный статус отзыва", nil)
+		return
+	}
+
 	userId := userID.(int)
-	filter.UserID = &userId
+	filter := models.ReviewFilter{
+		UserID:    &userId,
+		CompanyID: input.CompanyID,
+		Statuses:  statuses,
+		SortBy:    input.SortBy,
+		SortOrder: input.SortOrder,
+		Page:      input.Page,
+		Limit:     input.Limit,
+	}
 
 	if filter.Page <= 0 {
 		filter.Page = 1
@@ -168,8 +214,35 @@ func (h *UserHandler) GetUserReviews(c *gin.Context) {
 		return
 	}
 
+	reviewIDs := make([]int, len(reviews))
+	for i, review := range reviews {
+		reviewIDs[i] = review.Review.ID
+	}
+
+	revisions, err := h.repo.ReviewRevisions.GetLatestByReviews(c, reviewIDs)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении правок отзывов", err)
+		return
+	}
+
+	userReviews := make([]models.UserReview, len(reviews))
+	for i, review := range reviews {
+		var latestRevision *models.ReviewRevision
+		if revision, ok := revisions[review.Review.ID]; ok {
+			latestRevision = &revision
+		}
+		userReviews[i] = models.NewUserReview(review, latestRevision)
+	}
+
+	counts, err := h.repo.Reviews.CountByUser(c, userId)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при подсчете отзывов", err)
+		return
+	}
+
 	utils.Response(c, http.StatusOK, gin.H{
-		"reviews": reviews,
+		"reviews": userReviews,
+		"counts":  counts,
 		"pagination": gin.H{
 			"total": total,
 			"page":  filter.Page,
@@ -178,3 +251,131 @@ func (h *UserHandler) GetUserReviews(c *gin.Context) {
 		},
 	})
 }
+
+// parseReviewStatuses разбирает статусы отзывов из параметров запроса, допуская перечисление через запятую.
+func parseReviewStatuses(values []string) ([]models.ReviewStatus, bool) {
+	var statuses []models.ReviewStatus
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			status := models.ReviewStatus(strings.TrimSpace(part))
+			switch status {
+			case "":
+				continue
+			case models.ReviewStatusPending, models.ReviewStatusApproved, models.ReviewStatusRejected, models.ReviewStatusWithdrawn:
+				statuses = append(statuses, status)
+			default:
+				return nil, false
+			}
+		}
+	}
+	return statuses, true
+}
+
+// @Summary Активные сессии
+// @Description Возвращает список устройств и браузеров, с которых выполнен вход в аккаунт
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/sessions [get]
+func (h *UserHandler) GetSessions(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	sessions, err := h.repo.RefreshTokens.GetSessionsByUser(c, userID.(int))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при получении сессий", err)
+		return
+	}
+
+	currentSessionID := c.GetString(middleware.SessionIDKey)
+	for i := range sessions {
+		sessions[i].IsCurrent = sessions[i].ID == currentSessionID
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"sessions": sessions,
+	})
+}
+
+// @Summary Завершение сессии
+// @Description Завершает одну из сессий текущего пользователя. Refresh токены сессии перестают действовать
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param sessionId path string true "ID сессии"
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 404 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/sessions/{sessionId} [delete]
+func (h *UserHandler) RevokeSession(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	sessionID := c.Param("sessionId")
+	if _, err := uuid.Parse(sessionID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Неверный формат ID сессии", err)
+		return
+	}
+
+	deleted, err := h.repo.RefreshTokens.DeleteSession(c, userID.(int), sessionID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при завершении сессии", err)
+		return
+	}
+
+	if !deleted {
+		utils.ErrorResponse(c, http.StatusNotFound, "Сессия не найдена", nil)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message": "Сессия завершена",
+	})
+}
+
+// @Summary Завершение остальных сессий
+// @Description Завершает все сессии текущего пользователя, кроме той, из которой выполнен запрос
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.ResponseDTO
+// @Failure 400 {object} utils.ErrorResponseDTO
+// @Failure 401 {object} utils.ErrorResponseDTO
+// @Failure 500 {object} utils.ErrorResponseDTO
+// @Router /users/me/sessions [delete]
+func (h *UserHandler) RevokeOtherSessions(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+		return
+	}
+
+	currentSessionID := c.GetString(middleware.SessionIDKey)
+	if currentSessionID == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Не удалось определить текущую сессию, выполните вход заново", nil)
+		return
+	}
+
+	if err := h.repo.RefreshTokens.DeleteOtherSessions(c, userID.(int), currentSessionID); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при завершении сессий", err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, gin.H{
+		"message": "Остальные сессии завершены",
+	})
+}
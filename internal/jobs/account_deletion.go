@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"job_solition/internal/middleware"
+	"job_solition/internal/repository"
+)
+
+const accountDeletionBatchSize = 100
+
+// AccountDeletionJob периодически удаляет аккаунты, у которых истек срок на отмену удаления.
+type AccountDeletionJob struct {
+	*Periodic
+	repo *repository.Repository
+}
+
+func NewAccountDeletionJob(repo *repository.Repository, interval time.Duration) *AccountDeletionJob {
+	j := &AccountDeletionJob{repo: repo}
+	j.Periodic = NewPeriodic(interval, j.run)
+	return j
+}
+
+func (j *AccountDeletionJob) run(ctx context.Context) {
+	ids, err := j.repo.Users.GetDueForDeletion(ctx, time.Now(), accountDeletionBatchSize)
+	if err != nil {
+		log.Printf("Ошибка при получении аккаунтов к удалению: %v", err)
+		return
+	}
+
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err := j.repo.Users.Purge(ctx, id); err != nil {
+			log.Printf("Ошибка при удалении аккаунта пользователя %d: %v", id, err)
+			continue
+		}
+		middleware.InvalidateTokenState(id)
+
+		log.Printf("Аккаунт пользователя %d удален по истечении срока", id)
+	}
+}
@@ -0,0 +1,34 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"job_solition/internal/repository"
+)
+
+// IdempotencyKeyCleanupJob периодически удаляет ключи идемпотентности, созданные дольше ttl назад.
+type IdempotencyKeyCleanupJob struct {
+	*Periodic
+	repo *repository.Repository
+	ttl  time.Duration
+}
+
+func NewIdempotencyKeyCleanupJob(repo *repository.Repository, interval, ttl time.Duration) *IdempotencyKeyCleanupJob {
+	j := &IdempotencyKeyCleanupJob{repo: repo, ttl: ttl}
+	j.Periodic = NewPeriodic(interval, j.run)
+	return j
+}
+
+func (j *IdempotencyKeyCleanupJob) run(ctx context.Context) {
+	deleted, err := j.repo.IdempotencyKeys.DeleteExpired(ctx, time.Now().Add(-j.ttl))
+	if err != nil {
+		log.Printf("Ошибка при удалении устаревших ключей идемпотентности: %v", err)
+		return
+	}
+
+	if deleted > 0 {
+		log.Printf("Удалено устаревших ключей идемпотентности: %d", deleted)
+	}
+}
@@ -0,0 +1,54 @@
+package jobs
+
+import (
+	"context"
+	"time"
+)
+
+// Periodic вызывает run сразу после Start и затем каждые interval, пока не будет вызван Stop.
+// Stop отменяет контекст run и ждет завершения текущего вызова.
+type Periodic struct {
+	interval time.Duration
+	run      func(context.Context)
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func NewPeriodic(interval time.Duration, run func(context.Context)) *Periodic {
+	return &Periodic{
+		interval: interval,
+		run:      run,
+	}
+}
+
+func (p *Periodic) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.done = make(chan struct{})
+
+	go func() {
+		defer close(p.done)
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			p.run(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (p *Periodic) Stop() {
+	if p.cancel == nil {
+		return
+	}
+
+	p.cancel()
+	<-p.done
+}
@@ -0,0 +1,34 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"job_solition/internal/repository"
+)
+
+// ReviewDraftPurgeJob периодически удаляет черновики отзывов, которые не менялись дольше ttl.
+type ReviewDraftPurgeJob struct {
+	*Periodic
+	repo *repository.Repository
+	ttl  time.Duration
+}
+
+func NewReviewDraftPurgeJob(repo *repository.Repository, interval, ttl time.Duration) *ReviewDraftPurgeJob {
+	j := &ReviewDraftPurgeJob{repo: repo, ttl: ttl}
+	j.Periodic = NewPeriodic(interval, j.run)
+	return j
+}
+
+func (j *ReviewDraftPurgeJob) run(ctx context.Context) {
+	deleted, err := j.repo.ReviewDrafts.DeleteStale(ctx, time.Now().Add(-j.ttl))
+	if err != nil {
+		log.Printf("Ошибка при удалении устаревших черновиков отзывов: %v", err)
+		return
+	}
+
+	if deleted > 0 {
+		log.Printf("Удалено устаревших черновиков отзывов: %d", deleted)
+	}
+}
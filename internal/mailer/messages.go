@@ -0,0 +1,123 @@
+package mailer
+
+import (
+	"fmt"
+	"time"
+)
+
+func PasswordResetMessage(to, link string, expiresIn time.Duration) Message {
+	return Message{
+		To:      to,
+		Subject: "Восстановление пароля JobSolution",
+		Body: fmt.Sprintf(
+			"Здравствуйте!\n\n"+
+				"Мы получили запрос на восстановление пароля для вашей учетной записи.\n"+
+				"Чтобы задать новый пароль, перейдите по ссылке:\n\n%s\n\n"+
+				"Ссылка действительна %s и может быть использована только один раз.\n"+
+				"Если вы не запрашивали восстановление пароля, просто проигнорируйте это письмо.\n",
+			link, formatDuration(expiresIn),
+		),
+	}
+}
+
+func EmailVerificationMessage(to, link string, expiresIn time.Duration) Message {
+	return Message{
+		To:      to,
+		Subject: "Подтверждение email на JobSolution",
+		Body: fmt.Sprintf(
+			"Здравствуйте!\n\n"+
+				"Спасибо за регистрацию на JobSolution.\n"+
+				"Чтобы подтвердить адрес электронной почты, перейдите по ссылке:\n\n%s\n\n"+
+				"Ссылка действительна %s.\n"+
+				"Без подтверждения email нельзя оставлять отзывы и отмечать их полезными.\n",
+			link, formatDuration(expiresIn),
+		),
+	}
+}
+
+func MagicLinkMessage(to, link string, expiresIn time.Duration) Message {
+	return Message{
+		To:      to,
+		Subject: "Вход на JobSolution",
+		Body: fmt.Sprintf(
+			"Здравствуйте!\n\n"+
+				"Чтобы войти в свою учетную запись без пароля, перейдите по ссылке:\n\n%s\n\n"+
+				"Ссылка действительна %s и может быть использована только один раз.\n"+
+				"Если вы не запрашивали вход, просто проигнорируйте это письмо.\n",
+			link, formatDuration(expiresIn),
+		),
+	}
+}
+
+func EmailChangeConfirmationMessage(to, link string, expiresIn time.Duration) Message {
+	return Message{
+		To:      to,
+		Subject: "Подтверждение нового email на JobSolution",
+		Body: fmt.Sprintf(
+			"Здравствуйте!\n\n"+
+				"Этот адрес указан как новый email учетной записи JobSolution.\n"+
+				"Чтобы подтвердить смену email, перейдите по ссылке:\n\n%s\n\n"+
+				"Ссылка действительна %s. После подтверждения входить нужно будет с этим адресом.\n"+
+				"Если вы не запрашивали смену email, просто проигнорируйте это письмо.\n",
+			link, formatDuration(expiresIn),
+		),
+	}
+}
+
+func EmailChangeNoticeMessage(to, newEmail, cancelLink string) Message {
+	return Message{
+		To:      to,
+		Subject: "Запрос на смену email на JobSolution",
+		Body: fmt.Sprintf(
+			"Здравствуйте!\n\n"+
+				"Для вашей учетной записи JobSolution запрошена смена email на %s.\n"+
+				"Смена произойдет после подтверждения с нового адреса.\n\n"+
+				"Если вы не запрашивали смену email, отмените ее по ссылке и смените пароль:\n\n%s\n",
+			newEmail, cancelLink,
+		),
+	}
+}
+
+func RepresentativeClaimCodeMessage(to, companyName, code string, expiresIn time.Duration) Message {
+	return Message{
+		To:      to,
+		Subject: "Код подтверждения представителя компании на JobSolution",
+		Body: fmt.Sprintf(
+			"Здравствуйте!\n\n"+
+				"Этот адрес указан в заявке на представительство компании %s на JobSolution.\n"+
+				"Код подтверждения: %s\n\n"+
+				"Код действителен %s. После подтверждения заявку рассмотрит администратор.\n"+
+				"Если вы не подавали заявку, просто проигнорируйте это письмо.\n",
+			companyName, code, formatDuration(expiresIn),
+		),
+	}
+}
+
+func ReviewFlagResolvedMessage(to, companyName string, actioned bool, comment string) Message {
+	outcome := "Модератор проверил отзыв и не нашел в нем нарушений, поэтому отзыв остается опубликованным."
+	if actioned {
+		outcome = "Модератор подтвердил нарушение, отзыв снят с публикации."
+	}
+	if comment != "" {
+		outcome += "\nКомментарий модератора: " + comment
+	}
+
+	return Message{
+		To:      to,
+		Subject: "Результат рассмотрения жалобы на JobSolution",
+		Body: fmt.Sprintf(
+			"Здравствуйте!\n\n"+
+				"Вы пожаловались на отзыв о компании %s.\n"+
+				"%s\n\n"+
+				"Спасибо, что помогаете поддерживать качество отзывов.\n",
+			companyName, outcome,
+		),
+	}
+}
+
+func formatDuration(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		return fmt.Sprintf("%d ч.", int(d.Hours()))
+	}
+	return fmt.Sprintf("%d мин.", int(d.Minutes()))
+}
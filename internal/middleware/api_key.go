@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"job_solition/internal/models"
+	"job_solition/internal/repository"
+	"job_solition/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	APIKeyHeader = "X-API-Key"
+	APIKeyKey    = "api_key"
+)
+
+// APIKeyAuth аутентифицирует запросы с заголовком X-API-Key и требует у ключа область доступа scope.
+// Запросы без заголовка пропускаются как есть, поэтому группа маршрутов остается доступна пользователям.
+// Для ключей с квотой ответ содержит X-RateLimit-Limit и X-RateLimit-Remaining.
+func APIKeyAuth(repo *repository.Repository, scope models.APIKeyScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rawKey := c.GetHeader(APIKeyHeader)
+		if rawKey == "" {
+			c.Next()
+			return
+		}
+
+		key, err := repo.APIKeys.GetByHash(c, utils.HashAPIKey(rawKey))
+		if err != nil {
+			if err.Error() == "API ключ не найден" {
+				utils.ErrorResponse(c, http.StatusUnauthorized, "Недействительный API ключ", nil)
+			} else {
+				utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке API ключа", err)
+			}
+			c.Abort()
+			return
+		}
+
+		if key.IsRevoked() || key.IsExpired() {
+			utils.ErrorResponse(c, http.StatusUnauthorized, "API ключ отозван или срок его действия истек", nil)
+			c.Abort()
+			return
+		}
+
+		if !key.HasScope(scope) {
+			utils.ErrorResponse(c, http.StatusForbidden, "У API ключа нет доступа к этому ресурсу", nil)
+			c.Abort()
+			return
+		}
+
+		now := time.Now()
+		requests, err := repo.APIKeys.RecordUsage(c, key.ID, now)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при учете использования API ключа", err)
+			c.Abort()
+			return
+		}
+
+		if key.DailyQuota > 0 {
+			remaining := key.DailyQuota - requests
+			if remaining < 0 {
+				remaining = 0
+			}
+			c.Header("X-RateLimit-Limit", strconv.Itoa(key.DailyQuota))
+			c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+
+			if requests > key.DailyQuota {
+				nextDay := now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+				c.Header("Retry-After", strconv.Itoa(int(nextDay.Sub(now).Seconds())+1))
+				utils.ErrorResponse(c, http.StatusTooManyRequests, "Превышена суточная квота API ключа", nil)
+				c.Abort()
+				return
+			}
+		}
+
+		c.Set(APIKeyKey, key)
+
+		c.Next()
+	}
+}
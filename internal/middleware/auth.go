@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
@@ -17,11 +18,13 @@ const (
 	UserIDKey          = "user_id"
 	UserKey            = "user"
 	RoleKey            = "role"
+	SessionIDKey       = "session_id"
+	TwoFactorKey       = "two_factor"
 	IsAuthenticatedKey = "is_authenticated"
 )
 
-func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
-	jwtUtil := utils.NewJWT(cfg.JWT.Secret, cfg.JWT.ExpiresIn, cfg.JWT.RefreshExpiresIn)
+func AuthMiddleware(cfg *config.Config, repo *repository.Repository) gin.HandlerFunc {
+	jwtUtil := utils.NewJWT(cfg.JWT)
 
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
@@ -45,16 +48,25 @@ func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
 			return
 		}
 
+		state, err := currentTokenState(c, cfg, repo, claims)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Недействительный токен", err)
+			c.Abort()
+			return
+		}
+
 		c.Set(UserIDKey, claims.UserID)
-		c.Set(RoleKey, claims.Role)
+		c.Set(RoleKey, state.Role)
+		c.Set(SessionIDKey, claims.SessionID)
+		c.Set(TwoFactorKey, claims.TwoFactor)
 		c.Set(IsAuthenticatedKey, true)
 
 		c.Next()
 	}
 }
 
-func OptionalAuth(cfg *config.Config) gin.HandlerFunc {
-	jwtUtil := utils.NewJWT(cfg.JWT.Secret, cfg.JWT.ExpiresIn, cfg.JWT.RefreshExpiresIn)
+func OptionalAuth(cfg *config.Config, repo *repository.Repository) gin.HandlerFunc {
+	jwtUtil := utils.NewJWT(cfg.JWT)
 
 	return func(c *gin.Context) {
 		c.Set(IsAuthenticatedKey, false)
@@ -80,16 +92,40 @@ func OptionalAuth(cfg *config.Config) gin.HandlerFunc {
 			return
 		}
 
-		fmt.Printf("OptionalAuth: пользователь аутентифицирован, ID: %d, роль: %s\n", claims.UserID, claims.Role)
+		state, err := currentTokenState(c, cfg, repo, claims)
+		if err != nil {
+			fmt.Printf("OptionalAuth: токен отозван: %v\n", err)
+			c.Next()
+			return
+		}
+
+		fmt.Printf("OptionalAuth: пользователь аутентифицирован, ID: %d, роль: %s\n", claims.UserID, state.Role)
 
 		c.Set(UserIDKey, claims.UserID)
-		c.Set(RoleKey, claims.Role)
+		c.Set(RoleKey, state.Role)
+		c.Set(SessionIDKey, claims.SessionID)
+		c.Set(TwoFactorKey, claims.TwoFactor)
 		c.Set(IsAuthenticatedKey, true)
 
 		c.Next()
 	}
 }
 
+// currentTokenState сверяет версию токена с текущей версией пользователя. Роль берется из БД,
+// поэтому понижение прав действует с первого же запроса, а удаленный пользователь теряет доступ.
+func currentTokenState(ctx context.Context, cfg *config.Config, repo *repository.Repository, claims *utils.AuthClaims) (*models.TokenState, error) {
+	state, err := tokenStates.get(ctx, repo, claims.UserID, cfg.JWT.StateCacheTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.TokenVersion != claims.Version {
+		return nil, fmt.Errorf("версия токена устарела")
+	}
+
+	return state, nil
+}
+
 func RequireAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		isAuthenticated, exists := c.Get(IsAuthenticatedKey)
@@ -138,6 +174,33 @@ func RequireRoleMiddleware(roles ...models.UserRole) gin.HandlerFunc {
 	}
 }
 
+// RequireTwoFactorForStaff не пускает администраторов и модераторов, вошедших без второго фактора,
+// если в конфигурации включена обязательная двухфакторная аутентификация для сотрудников.
+func RequireTwoFactorForStaff(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !cfg.Security.Require2FAForStaff {
+			c.Next()
+			return
+		}
+
+		roleValue, exists := c.Get(RoleKey)
+		if !exists {
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+			c.Abort()
+			return
+		}
+
+		role, ok := roleValue.(models.UserRole)
+		if ok && role.IsStaff() && !c.GetBool(TwoFactorKey) {
+			utils.ErrorResponse(c, http.StatusForbidden, "Войдите заново с двухфакторной аутентификацией", nil)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
 func LoadUserMiddleware(repo *repository.Repository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userIDValue, exists := c.Get(UserIDKey)
@@ -166,3 +229,31 @@ func LoadUserMiddleware(repo *repository.Repository) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+func RequireVerifiedEmail(repo *repository.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDValue, exists := c.Get(UserIDKey)
+		if !exists {
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Требуется авторизация", nil)
+			c.Abort()
+			return
+		}
+
+		user, err := repo.Users.GetByID(c, userIDValue.(int))
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Пользователь не найден", nil)
+			c.Abort()
+			return
+		}
+
+		if !user.IsEmailVerified() {
+			utils.ErrorResponse(c, http.StatusForbidden, "Подтвердите email, чтобы выполнить это действие", nil)
+			c.Abort()
+			return
+		}
+
+		c.Set(UserKey, user)
+
+		c.Next()
+	}
+}
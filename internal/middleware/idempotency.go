@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"job_solition/internal/models"
+	"job_solition/internal/repository"
+	"job_solition/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+	replayContentType        = "application/json; charset=utf-8"
+)
+
+// responseRecorder копирует тело ответа, чтобы сохранить его для повторов запроса.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
+
+// Idempotency делает запросы авторизованного пользователя с заголовком Idempotency-Key идемпотентными:
+// ответ на первый запрос сохраняется на ttl и отдается при повторах с тем же ключом с заголовком
+// Idempotent-Replayed. Повтор с тем же ключом, но другим телом отклоняется, пока исходный запрос
+// обрабатывается, повтор получает 409. После внутренней ошибки ключ освобождается. Запросы без
+// заголовка пропускаются как есть.
+func Idempotency(repo *repository.Repository, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rawKey := c.GetHeader(IdempotencyKeyHeader)
+		if rawKey == "" {
+			c.Next()
+			return
+		}
+
+		if len(rawKey) > maxIdempotencyKeyLength {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Слишком длинный ключ идемпотентности", nil)
+			c.Abort()
+			return
+		}
+
+		userID := c.GetInt(UserIDKey)
+		if userID == 0 {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Ошибка при чтении тела запроса", err)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		hash := sha256.New()
+		hash.Write([]byte(c.Request.Method + " " + c.Request.URL.Path + "\n"))
+		hash.Write(body)
+		requestHash := hex.EncodeToString(hash.Sum(nil))
+
+		now := time.Now()
+		record, created, err := repo.IdempotencyKeys.Reserve(c, &models.IdempotencyKey{
+			UserID:      userID,
+			Key:         rawKey,
+			RequestHash: requestHash,
+			CreatedAt:   now,
+		}, now.Add(-ttl))
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Ошибка при проверке ключа идемпотентности", err)
+			c.Abort()
+			return
+		}
+
+		if !created {
+			switch {
+			case record.RequestHash != requestHash:
+				utils.ErrorResponse(c, http.StatusUnprocessableEntity, "Ключ идемпотентности уже использован для другого запроса", nil)
+			case record.StatusCode == nil:
+				utils.ErrorResponse(c, http.StatusConflict, "Запрос с этим ключом идемпотентности еще обрабатывается", nil)
+			default:
+				c.Header(IdempotentReplayedHeader, "true")
+				c.Data(*record.StatusCode, replayContentType, record.ResponseBody)
+			}
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		// Если обработчик упадет с паникой, ключ освобождается, чтобы запрос можно было повторить.
+		defer func() {
+			if r := recover(); r != nil {
+				if err := repo.IdempotencyKeys.Delete(c, record.ID); err != nil {
+					log.Printf("Ошибка при освобождении ключа идемпотентности %d: %v", record.ID, err)
+				}
+				panic(r)
+			}
+		}()
+
+		c.Next()
+
+		if status := recorder.Status(); status >= http.StatusInternalServerError {
+			if err := repo.IdempotencyKeys.Delete(c, record.ID); err != nil {
+				log.Printf("Ошибка при освобождении ключа идемпотентности %d: %v", record.ID, err)
+			}
+		} else if err := repo.IdempotencyKeys.Complete(c, record.ID, status, recorder.body.Bytes()); err != nil {
+			log.Printf("Ошибка при сохранении ответа для ключа идемпотентности %d: %v", record.ID, err)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"job_solition/internal/models"
+	"job_solition/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fakeIdempotencyKeys struct {
+	mu      sync.Mutex
+	nextID  int
+	records map[string]*models.IdempotencyKey
+}
+
+func newFakeIdempotencyKeys() *fakeIdempotencyKeys {
+	return &fakeIdempotencyKeys{records: make(map[string]*models.IdempotencyKey)}
+}
+
+func (f *fakeIdempotencyKeys) Reserve(ctx context.Context, key *models.IdempotencyKey, expiresBefore time.Time) (*models.IdempotencyKey, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if existing, ok := f.records[key.Key]; ok && !existing.CreatedAt.Before(expiresBefore) {
+		copied := *existing
+		return &copied, false, nil
+	}
+
+	f.nextID++
+	record := *key
+	record.ID = f.nextID
+	f.records[key.Key] = &record
+	return &record, true, nil
+}
+
+func (f *fakeIdempotencyKeys) Complete(ctx context.Context, id, statusCode int, body []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, record := range f.records {
+		if record.ID == id {
+			record.StatusCode = &statusCode
+			record.ResponseBody = append([]byte{}, body...)
+		}
+	}
+	return nil
+}
+
+func (f *fakeIdempotencyKeys) Delete(ctx context.Context, id int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for key, record := range f.records {
+		if record.ID == id {
+			delete(f.records, key)
+		}
+	}
+	return nil
+}
+
+func (f *fakeIdempotencyKeys) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func newIdempotencyRouter(submitted *[]string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	repo := &repository.Repository{IdempotencyKeys: newFakeIdempotencyKeys()}
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(UserIDKey, 1)
+	})
+	router.POST("/users/me/drafts/:id/submit", Idempotency(repo, time.Hour), func(c *gin.Context) {
+		*submitted = append(*submitted, c.Param("id"))
+		c.JSON(http.StatusCreated, gin.H{"draft_id": c.Param("id")})
+	})
+	return router
+}
+
+func sendWithKey(router *gin.Engine, path, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(""))
+	req.Header.Set(IdempotencyKeyHeader, key)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotencyReplaysSameRequest(t *testing.T) {
+	var submitted []string
+	router := newIdempotencyRouter(&submitted)
+
+	first := sendWithKey(router, "/users/me/drafts/1/submit", "key-1")
+	second := sendWithKey(router, "/users/me/drafts/1/submit", "key-1")
+
+	if len(submitted) != 1 {
+		t.Fatalf("обработчик вызван %d раз, ожидался 1", len(submitted))
+	}
+	if second.Code != first.Code || second.Body.String() != first.Body.String() {
+		t.Fatalf("повтор вернул %d %q, ожидался %d %q", second.Code, second.Body.String(), first.Code, first.Body.String())
+	}
+	if second.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Fatalf("у повтора нет заголовка %s", IdempotentReplayedHeader)
+	}
+}
+
+func TestIdempotencySameKeyDifferentPathParams(t *testing.T) {
+	var submitted []string
+	router := newIdempotencyRouter(&submitted)
+
+	first := sendWithKey(router, "/users/me/drafts/1/submit", "key-1")
+	if first.Code != http.StatusCreated {
+		t.Fatalf("первый запрос вернул %d", first.Code)
+	}
+
+	second := sendWithKey(router, "/users/me/drafts/2/submit", "key-1")
+	if second.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("запрос к другому черновику с тем же ключом вернул %d %q, ожидался 422", second.Code, second.Body.String())
+	}
+	if second.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Fatal("ответ для первого черновика отдан для другого черновика")
+	}
+	if len(submitted) != 1 || submitted[0] != "1" {
+		t.Fatalf("обработаны черновики %v, ожидался только 1", submitted)
+	}
+}
+
+func TestIdempotencyReleasesKeyOnPanic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	calls := 0
+	repo := &repository.Repository{IdempotencyKeys: newFakeIdempotencyKeys()}
+	router := gin.New()
+	router.Use(gin.Recovery(), func(c *gin.Context) {
+		c.Set(UserIDKey, 1)
+	})
+	router.POST("/users/me/drafts/:id/submit", Idempotency(repo, time.Hour), func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			panic("сбой обработчика")
+		}
+		c.JSON(http.StatusCreated, gin.H{"draft_id": c.Param("id")})
+	})
+
+	first := sendWithKey(router, "/users/me/drafts/1/submit", "key-1")
+	if first.Code != http.StatusInternalServerError {
+		t.Fatalf("запрос с паникой вернул %d, ожидался 500", first.Code)
+	}
+
+	second := sendWithKey(router, "/users/me/drafts/1/submit", "key-1")
+	if second.Code != http.StatusCreated {
+		t.Fatalf("повтор после паники вернул %d %q, ожидался 201", second.Code, second.Body.String())
+	}
+	if calls != 2 {
+		t.Fatalf("обработчик вызван %d раз, ожидалось 2", calls)
+	}
+}
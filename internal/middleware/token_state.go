@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"job_solition/internal/models"
+	"job_solition/internal/repository"
+)
+
+const tokenStateCacheCleanupSize = 10000
+
+type tokenStateEntry struct {
+	state     models.TokenState
+	expiresAt time.Time
+}
+
+// tokenStateCache хранит роль и версию токенов пользователей, чтобы не обращаться к БД на каждый запрос.
+// Кэш общий для всех экземпляров middleware в процессе: InvalidateTokenState сбрасывает запись сразу,
+// на остальных экземплярах сервиса изменения вступают в силу не позже чем через JWT_STATE_CACHE_TTL.
+type tokenStateCache struct {
+	mu      sync.RWMutex
+	entries map[int]tokenStateEntry
+}
+
+var tokenStates = &tokenStateCache{
+	entries: map[int]tokenStateEntry{},
+}
+
+func (c *tokenStateCache) get(ctx context.Context, repo *repository.Repository, userID int, ttl time.Duration) (*models.TokenState, error) {
+	now := time.Now()
+
+	c.mu.RLock()
+	entry, ok := c.entries[userID]
+	c.mu.RUnlock()
+
+	if ok && now.Before(entry.expiresAt) {
+		state := entry.state
+		return &state, nil
+	}
+
+	state, err := repo.Users.GetTokenState(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ttl > 0 {
+		c.mu.Lock()
+		if len(c.entries) >= tokenStateCacheCleanupSize {
+			for id, e := range c.entries {
+				if !now.Before(e.expiresAt) {
+					delete(c.entries, id)
+				}
+			}
+		}
+		c.entries[userID] = tokenStateEntry{state: *state, expiresAt: now.Add(ttl)}
+		c.mu.Unlock()
+	}
+
+	return state, nil
+}
+
+// InvalidateTokenState сбрасывает закэшированное состояние пользователя.
+// Вызывается после изменения роли, пароля или удаления пользователя.
+func InvalidateTokenState(userID int) {
+	tokenStates.mu.Lock()
+	delete(tokenStates.entries, userID)
+	tokenStates.mu.Unlock()
+}
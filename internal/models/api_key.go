@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type APIKeyScope string
+
+const (
+	APIKeyScopeCompaniesRead APIKeyScope = "companies:read"
+	APIKeyScopeReviewsRead   APIKeyScope = "reviews:read"
+)
+
+// APIKeyScopes - все области доступа, которые можно выдать ключу.
+var APIKeyScopes = []APIKeyScope{
+	APIKeyScopeCompaniesRead,
+	APIKeyScopeReviewsRead,
+}
+
+func (s APIKeyScope) IsValid() bool {
+	for _, scope := range APIKeyScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey - ключ доступа партнеров и внутренних сервисов. Сам ключ хранится только в виде SHA-256,
+// Prefix - его открытое начало, по которому ключ можно узнать в списке. DailyQuota 0 - без ограничения.
+type APIKey struct {
+	ID         int            `json:"id" db:"id"`
+	Name       string         `json:"name" db:"name"`
+	Prefix     string         `json:"prefix" db:"prefix"`
+	KeyHash    string         `json:"-" db:"key_hash"`
+	Scopes     pq.StringArray `json:"scopes" db:"scopes" swaggertype:"array,string"`
+	DailyQuota int            `json:"daily_quota" db:"daily_quota"`
+	ExpiresAt  *time.Time     `json:"expires_at,omitempty" db:"expires_at"`
+	LastUsedAt *time.Time     `json:"last_used_at,omitempty" db:"last_used_at"`
+	RevokedAt  *time.Time     `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedBy  *int           `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt  time.Time      `json:"created_at" db:"created_at"`
+}
+
+type APIKeyCreateInput struct {
+	Name       string        `json:"name" binding:"required,max=100"`
+	Scopes     []APIKeyScope `json:"scopes" binding:"required,min=1"`
+	DailyQuota int           `json:"daily_quota" binding:"min=0"`
+	ExpiresAt  *time.Time    `json:"expires_at" binding:"omitempty"`
+}
+
+func NewAPIKey(input APIKeyCreateInput, prefix, keyHash string, createdBy int) *APIKey {
+	scopes := make(pq.StringArray, 0, len(input.Scopes))
+	for _, scope := range input.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	return &APIKey{
+		Name:       input.Name,
+		Prefix:     prefix,
+		KeyHash:    keyHash,
+		Scopes:     scopes,
+		DailyQuota: input.DailyQuota,
+		ExpiresAt:  input.ExpiresAt,
+		CreatedBy:  &createdBy,
+		CreatedAt:  time.Now(),
+	}
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+func (k *APIKey) IsExpired() bool {
+	return k.ExpiresAt != nil && time.Now().After(*k.ExpiresAt)
+}
+
+func (k *APIKey) HasScope(scope APIKeyScope) bool {
+	for _, s := range k.Scopes {
+		if s == string(scope) {
+			return true
+		}
+	}
+	return false
+}
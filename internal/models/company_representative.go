@@ -0,0 +1,151 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type CompanyRepresentativeStatus string
+
+const (
+	// CompanyRepresentativeStatusUnconfirmed - заявка подана, корпоративный email еще не подтвержден кодом.
+	CompanyRepresentativeStatusUnconfirmed CompanyRepresentativeStatus = "unconfirmed"
+	// CompanyRepresentativeStatusPending - корпоративный email подтвержден, заявка ждет решения администратора.
+	CompanyRepresentativeStatusPending  CompanyRepresentativeStatus = "pending"
+	CompanyRepresentativeStatusVerified CompanyRepresentativeStatus = "verified"
+	CompanyRepresentativeStatusRejected CompanyRepresentativeStatus = "rejected"
+	CompanyRepresentativeStatusRevoked  CompanyRepresentativeStatus = "revoked"
+)
+
+type RepresentativePermission string
+
+const (
+	RepresentativePermissionReviewsRespond RepresentativePermission = "reviews:respond"
+	RepresentativePermissionCompanyUpdate  RepresentativePermission = "company:update"
+)
+
+// RepresentativePermissions - все права, которые можно выдать представителю компании. По умолчанию
+// подтвержденный представитель получает их все.
+var RepresentativePermissions = []RepresentativePermission{
+	RepresentativePermissionReviewsRespond,
+	RepresentativePermissionCompanyUpdate,
+}
+
+func (p RepresentativePermission) IsValid() bool {
+	for _, permission := range RepresentativePermissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// CompanyRepresentative - пользователь, которому разрешено действовать от имени компании. Связь
+// появляется как заявка пользователя и начинает действовать после одобрения администратором.
+// Права представителя ограничены Permissions и относятся только к этой компании.
+type CompanyRepresentative struct {
+	ID               int                         `json:"id" db:"id"`
+	UserID           int                         `json:"user_id" db:"user_id"`
+	CompanyID        int                         `json:"company_id" db:"company_id"`
+	Status           CompanyRepresentativeStatus `json:"status" db:"status"`
+	CorporateEmail   *string                     `json:"corporate_email,omitempty" db:"corporate_email"`
+	CodeHash         *string                     `json:"-" db:"code_hash"`
+	CodeExpiresAt    *time.Time                  `json:"-" db:"code_expires_at"`
+	CodeAttempts     int                         `json:"-" db:"code_attempts"`
+	EmailConfirmedAt *time.Time                  `json:"email_confirmed_at,omitempty" db:"email_confirmed_at"`
+	Permissions      pq.StringArray              `json:"permissions" db:"permissions" swaggertype:"array,string"`
+	Comment          *string                     `json:"comment,omitempty" db:"comment"`
+	ReviewComment    *string                     `json:"review_comment,omitempty" db:"review_comment"`
+	VerifiedBy       *int                        `json:"verified_by,omitempty" db:"verified_by"`
+	CreatedAt        time.Time                   `json:"created_at" db:"created_at"`
+	VerifiedAt       *time.Time                  `json:"verified_at,omitempty" db:"verified_at"`
+	RevokedAt        *time.Time                  `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+type CompanyRepresentativeInput struct {
+	UserID      int                        `json:"user_id" binding:"required,min=1"`
+	Permissions []RepresentativePermission `json:"permissions" binding:"omitempty"`
+}
+
+type CompanyClaimInput struct {
+	CorporateEmail string `json:"corporate_email" binding:"required,email,max=255"`
+	Comment        string `json:"comment" binding:"omitempty,max=1000"`
+}
+
+type CompanyClaimConfirmInput struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type CompanyClaimModerationInput struct {
+	Permissions []RepresentativePermission `json:"permissions" binding:"omitempty"`
+	Comment     string                     `json:"comment" binding:"omitempty"`
+}
+
+type CompanyClaimFilter struct {
+	Status    *CompanyRepresentativeStatus `form:"status" binding:"omitempty,oneof=unconfirmed pending verified rejected revoked"`
+	CompanyID *int                         `form:"company_id" binding:"omitempty,min=1"`
+	Page      int                          `form:"page" binding:"omitempty,min=1"`
+	Limit     int                          `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// CompanyProfileUpdateInput - поля профиля компании, которые может менять ее представитель. Название,
+// размер, отрасли и сайт, по домену которого подтверждаются представители, меняет только администратор.
+type CompanyProfileUpdateInput struct {
+	Logo    *string `json:"logo,omitempty" binding:"omitempty,url"`
+	Email   *string `json:"email,omitempty" binding:"omitempty,email"`
+	Phone   *string `json:"phone,omitempty" binding:"omitempty"`
+	Address *string `json:"address,omitempty" binding:"omitempty"`
+}
+
+func NewCompanyClaim(userID, companyID int, input CompanyClaimInput, codeHash string, expiresIn time.Duration) CompanyRepresentative {
+	now := time.Now()
+	email := strings.ToLower(strings.TrimSpace(input.CorporateEmail))
+	expiresAt := now.Add(expiresIn)
+
+	claim := CompanyRepresentative{
+		UserID:         userID,
+		CompanyID:      companyID,
+		Status:         CompanyRepresentativeStatusUnconfirmed,
+		CorporateEmail: &email,
+		CodeHash:       &codeHash,
+		CodeExpiresAt:  &expiresAt,
+		CreatedAt:      now,
+	}
+	if input.Comment != "" {
+		claim.Comment = &input.Comment
+	}
+
+	return claim
+}
+
+// NewRepresentativePermissions возвращает выданные права, по умолчанию - все.
+func NewRepresentativePermissions(permissions []RepresentativePermission) pq.StringArray {
+	if len(permissions) == 0 {
+		permissions = RepresentativePermissions
+	}
+
+	result := make(pq.StringArray, 0, len(permissions))
+	for _, permission := range permissions {
+		result = append(result, string(permission))
+	}
+	return result
+}
+
+func (r *CompanyRepresentative) IsCodeExpired() bool {
+	return r.CodeExpiresAt == nil || time.Now().After(*r.CodeExpiresAt)
+}
+
+func (r *CompanyRepresentative) HasPermission(permission RepresentativePermission) bool {
+	if r.Status != CompanyRepresentativeStatusVerified {
+		return false
+	}
+
+	for _, p := range r.Permissions {
+		if p == string(permission) {
+			return true
+		}
+	}
+	return false
+}
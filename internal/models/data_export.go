@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DataExportStatus string
+
+const (
+	DataExportStatusPending DataExportStatus = "pending"
+	DataExportStatusReady   DataExportStatus = "ready"
+	DataExportStatusFailed  DataExportStatus = "failed"
+)
+
+// DataExport - выгрузка персональных данных пользователя. Архив собирается в фоне,
+// после чего доступен по ссылке с токеном до ExpiresAt.
+type DataExport struct {
+	ID          int              `json:"id" db:"id"`
+	UserID      int              `json:"user_id" db:"user_id"`
+	RequestedBy *int             `json:"requested_by,omitempty" db:"requested_by"`
+	Status      DataExportStatus `json:"status" db:"status"`
+	Token       string           `json:"-" db:"token"`
+	Archive     []byte           `json:"-" db:"archive"`
+	Error       *string          `json:"-" db:"error"`
+	ExpiresAt   *time.Time       `json:"expires_at,omitempty" db:"expires_at"`
+	CreatedAt   time.Time        `json:"created_at" db:"created_at"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty" db:"completed_at"`
+	DownloadURL string           `json:"download_url,omitempty" db:"-"`
+}
+
+func NewDataExport(userID, requestedBy int) DataExport {
+	return DataExport{
+		UserID:      userID,
+		RequestedBy: &requestedBy,
+		Status:      DataExportStatusPending,
+		Token:       uuid.New().String(),
+		CreatedAt:   time.Now(),
+	}
+}
+
+// IsDownloadable сообщает, готов ли архив и действует ли еще ссылка на скачивание.
+func (e *DataExport) IsDownloadable() bool {
+	return e.Status == DataExportStatusReady && e.ExpiresAt != nil && e.ExpiresAt.After(time.Now())
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// IdempotencyKey - ключ из заголовка Idempotency-Key и сохраненный ответ на запрос с ним.
+// StatusCode равен nil, пока исходный запрос обрабатывается.
+type IdempotencyKey struct {
+	ID           int        `json:"id" db:"id"`
+	UserID       int        `json:"user_id" db:"user_id"`
+	Key          string     `json:"key" db:"key"`
+	RequestHash  string     `json:"-" db:"request_hash"`
+	StatusCode   *int       `json:"status_code,omitempty" db:"status_code"`
+	ResponseBody []byte     `json:"-" db:"response_body"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+}
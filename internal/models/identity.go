@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type UserIdentity struct {
+	ID        int       `json:"id" db:"id"`
+	UserID    int       `json:"-" db:"user_id"`
+	Provider  string    `json:"provider" db:"provider"`
+	Subject   string    `json:"-" db:"subject"`
+	Email     string    `json:"email,omitempty" db:"email"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// OAuthState хранит состояние начатого входа через внешнего провайдера до возврата пользователя.
+type OAuthState struct {
+	State        string    `db:"state"`
+	Provider     string    `db:"provider"`
+	CodeVerifier string    `db:"code_verifier"`
+	UserID       *int      `db:"user_id"`
+	ExpiresAt    time.Time `db:"expires_at"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+type OIDCCallbackInput struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required"`
+}
+
+func NewOAuthState(provider, codeVerifier string, userID *int, expiresIn time.Duration) OAuthState {
+	now := time.Now()
+	return OAuthState{
+		State:        uuid.New().String(),
+		Provider:     provider,
+		CodeVerifier: codeVerifier,
+		UserID:       userID,
+		ExpiresAt:    now.Add(expiresIn),
+		CreatedAt:    now,
+	}
+}
+
+func (s *OAuthState) IsExpired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
+
+// NewUserFromIdentity создает пользователя для первого входа через внешнего провайдера.
+// Пароль задается случайным, при необходимости пользователь может установить его через восстановление пароля.
+func NewUserFromIdentity(email, firstName, lastName string, emailVerified bool) (*User, error) {
+	password := uuid.New().String() + uuid.New().String()
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	user := &User{
+		Email:        email,
+		PasswordHash: string(passwordHash),
+		FirstName:    firstName,
+		LastName:     lastName,
+		Role:         RoleUser,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if emailVerified {
+		user.EmailVerifiedAt = &now
+	}
+
+	return user, nil
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+type LoginFailureReason string
+
+const (
+	LoginFailureInvalidCredentials   LoginFailureReason = "invalid_credentials"
+	LoginFailureInvalidTwoFactorCode LoginFailureReason = "invalid_2fa_code"
+)
+
+type LoginAttempt struct {
+	ID            int                 `json:"id" db:"id"`
+	UserID        *int                `json:"user_id,omitempty" db:"user_id"`
+	Email         string              `json:"email" db:"email"`
+	IPAddress     string              `json:"ip_address" db:"ip_address"`
+	UserAgent     string              `json:"user_agent,omitempty" db:"user_agent"`
+	Success       bool                `json:"success" db:"success"`
+	FailureReason *LoginFailureReason `json:"failure_reason,omitempty" db:"failure_reason"`
+	CreatedAt     time.Time           `json:"created_at" db:"created_at"`
+}
+
+func NewLoginAttempt(userID *int, email, ipAddress, userAgent string, success bool, reason LoginFailureReason) *LoginAttempt {
+	attempt := &LoginAttempt{
+		UserID:    userID,
+		Email:     NormalizeEmail(email),
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+		Success:   success,
+		CreatedAt: time.Now(),
+	}
+	if !success {
+		attempt.FailureReason = &reason
+	}
+	return attempt
+}
+
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// LoginFailureStats - неудачные попытки входа подряд (после последнего успешного входа).
+type LoginFailureStats struct {
+	Count  int        `db:"count"`
+	LastAt *time.Time `db:"last_at"`
+}
+
+// LockedUntil возвращает время окончания блокировки входа или nil, если блокировки нет.
+// Начиная с threshold неудач подряд вход блокируется на base, каждая следующая неудача удваивает
+// время блокировки, но не более чем до max. Отсчет идет от последней неудачной попытки.
+func (s LoginFailureStats) LockedUntil(threshold int, base, max time.Duration) *time.Time {
+	if threshold <= 0 || s.Count < threshold || s.LastAt == nil {
+		return nil
+	}
+
+	duration := base
+	for i := threshold; i < s.Count && duration < max; i++ {
+		duration *= 2
+	}
+	if duration > max {
+		duration = max
+	}
+
+	lockedUntil := s.LastAt.Add(duration)
+	return &lockedUntil
+}
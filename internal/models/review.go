@@ -12,6 +12,8 @@ const (
 	ReviewStatusPending  ReviewStatus = "pending"
 	ReviewStatusApproved ReviewStatus = "approved"
 	ReviewStatusRejected ReviewStatus = "rejected"
+	// ReviewStatusWithdrawn - отзыв снят с публикации автором.
+	ReviewStatusWithdrawn ReviewStatus = "withdrawn"
 )
 
 type Review struct {
@@ -33,6 +35,9 @@ type Review struct {
 	CreatedAt          time.Time      `json:"created_at" db:"created_at"`
 	UpdatedAt          time.Time      `json:"updated_at" db:"updated_at"`
 	ApprovedAt         sql.NullTime   `json:"approved_at,omitempty" db:"approved_at"`
+	// Признаки модерации не отдаются в ответах API: модераторы видят их в ModerationSignals и очереди жалоб.
+	IsHidden     bool `json:"-" db:"is_hidden"`
+	IsSuspicious bool `json:"-" db:"is_suspicious"`
 }
 
 type RatingCategory struct {
@@ -69,15 +74,18 @@ type UsefulMark struct {
 }
 
 type ReviewWithDetails struct {
-	Review           Review                 `json:"review"`
-	CategoryRatings  []ReviewCategoryRating `json:"category_ratings"`
-	Benefits         []ReviewBenefit        `json:"benefits"`
-	Company          *CompanyWithRatings    `json:"company,omitempty"`
-	User             *User                  `json:"user,omitempty"`
-	City             *City                  `json:"city,omitempty"`
-	EmploymentType   *EmploymentType        `json:"employment_type,omitempty"`
-	EmploymentPeriod *EmploymentPeriod      `json:"employment_period,omitempty"`
-	IsMarkedAsUseful bool                   `json:"is_marked_as_useful"`
+	Review           Review                   `json:"review"`
+	CategoryRatings  []ReviewCategoryRating   `json:"category_ratings"`
+	Benefits         []ReviewBenefit          `json:"benefits"`
+	Company          *CompanyWithRatings      `json:"company,omitempty"`
+	User             *User                    `json:"user,omitempty"`
+	City             *City                    `json:"city,omitempty"`
+	EmploymentType   *EmploymentType          `json:"employment_type,omitempty"`
+	EmploymentPeriod *EmploymentPeriod        `json:"employment_period,omitempty"`
+	Response         *PublishedReviewResponse `json:"response,omitempty"`
+	// ModerationSignals заполняется только в списках для модераторов.
+	ModerationSignals *ModerationSignals `json:"moderation_signals,omitempty"`
+	IsMarkedAsUseful  bool               `json:"is_marked_as_useful"`
 }
 
 type ReviewInput struct {
@@ -95,17 +103,66 @@ type ReviewInput struct {
 }
 
 type ReviewFilter struct {
-	CompanyID        *int          `form:"company_id" binding:"omitempty,min=1"`
-	UserID           *int          `form:"user_id" binding:"omitempty,min=1"`
-	Status           *ReviewStatus `form:"status" binding:"omitempty,oneof=pending approved rejected"`
-	CityID           *int          `form:"city_id" binding:"omitempty,min=1"`
-	MinRating        *float64      `form:"min_rating" binding:"omitempty,min=1,max=5"`
-	MaxRating        *float64      `form:"max_rating" binding:"omitempty,min=1,max=5"`
-	IsFormerEmployee *bool         `form:"is_former_employee" binding:"omitempty"`
-	SortBy           string        `form:"sort_by" binding:"omitempty,oneof=rating created_at useful_count"`
-	SortOrder        string        `form:"sort_order" binding:"omitempty,oneof=asc desc"`
-	Page             int           `form:"page" binding:"omitempty,min=1"`
-	Limit            int           `form:"limit" binding:"omitempty,min=1,max=100"`
+	CompanyID        *int           `form:"company_id" binding:"omitempty,min=1"`
+	UserID           *int           `form:"user_id" binding:"omitempty,min=1"`
+	Status           *ReviewStatus  `form:"status" binding:"omitempty,oneof=pending approved rejected"`
+	Statuses         []ReviewStatus `form:"-"`
+	ExcludeHidden    bool           `form:"-"`
+	CityID           *int           `form:"city_id" binding:"omitempty,min=1"`
+	MinRating        *float64       `form:"min_rating" binding:"omitempty,min=1,max=5"`
+	MaxRating        *float64       `form:"max_rating" binding:"omitempty,min=1,max=5"`
+	IsFormerEmployee *bool          `form:"is_former_employee" binding:"omitempty"`
+	SortBy           string         `form:"sort_by" binding:"omitempty,oneof=rating created_at useful_count"`
+	SortOrder        string         `form:"sort_order" binding:"omitempty,oneof=asc desc"`
+	Page             int            `form:"page" binding:"omitempty,min=1"`
+	Limit            int            `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// UserReviewFilter - параметры списка собственных отзывов. Статусы можно передать несколько раз
+// (status=pending&status=rejected) или через запятую (status=pending,rejected).
+type UserReviewFilter struct {
+	Status    []string `form:"status"`
+	CompanyID *int     `form:"company_id" binding:"omitempty,min=1"`
+	SortBy    string   `form:"sort_by" binding:"omitempty,oneof=rating created_at useful_count"`
+	SortOrder string   `form:"sort_order" binding:"omitempty,oneof=asc desc"`
+	Page      int      `form:"page" binding:"omitempty,min=1"`
+	Limit     int      `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// ReviewModeration - состояние модерации отзыва для его автора.
+type ReviewModeration struct {
+	Status     ReviewStatus `json:"status"`
+	Comment    string       `json:"comment,omitempty"`
+	ApprovedAt *time.Time   `json:"approved_at,omitempty"`
+	// Revision - последняя правка отзыва, если она ожидает модерации или отклонена.
+	Revision *ReviewRevision `json:"revision,omitempty"`
+}
+
+type UserReview struct {
+	ReviewWithDetails
+	Moderation ReviewModeration `json:"moderation"`
+}
+
+func NewUserReview(details ReviewWithDetails, latestRevision *ReviewRevision) UserReview {
+	moderation := ReviewModeration{
+		Status: details.Review.Status,
+	}
+
+	if details.Review.ModerationComment.Valid {
+		moderation.Comment = details.Review.ModerationComment.String
+	}
+	if details.Review.ApprovedAt.Valid {
+		approvedAt := details.Review.ApprovedAt.Time
+		moderation.ApprovedAt = &approvedAt
+	}
+	if latestRevision != nil && (latestRevision.Status == ReviewRevisionStatusPending || latestRevision.Status == ReviewRevisionStatusRejected) {
+		moderation.Revision = latestRevision
+	}
+
+	return UserReview{
+		ReviewWithDetails: details,
+		Moderation:        moderation,
+	}
 }
 
 type ReviewModerationInput struct {
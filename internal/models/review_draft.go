@@ -0,0 +1,87 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+)
+
+// ReviewDraftInput - частично заполненная форма отзыва. Все поля необязательны, проверяются только
+// их границы, полная проверка выполняется при отправке черновика.
+type ReviewDraftInput struct {
+	CompanyID          *int            `json:"company_id,omitempty" binding:"omitempty,min=1"`
+	Position           *string         `json:"position,omitempty" binding:"omitempty,max=100"`
+	EmploymentTypeID   *int            `json:"employment_type_id,omitempty" binding:"omitempty,min=1"`
+	EmploymentPeriodID *int            `json:"employment_period_id,omitempty" binding:"omitempty,min=1"`
+	CityID             *int            `json:"city_id,omitempty" binding:"omitempty,min=1"`
+	CategoryRatings    map[int]float64 `json:"category_ratings,omitempty" binding:"omitempty,dive,min=1,max=5"`
+	Pros               *string         `json:"pros,omitempty" binding:"omitempty,max=20000"`
+	Cons               *string         `json:"cons,omitempty" binding:"omitempty,max=20000"`
+	BenefitTypeIDs     []int           `json:"benefit_type_ids,omitempty" binding:"omitempty,max=100,dive,min=1"`
+	IsFormerEmployee   *bool           `json:"is_former_employee,omitempty"`
+	IsRecommended      *bool           `json:"is_recommended,omitempty"`
+}
+
+type ReviewDraft struct {
+	ID        int              `json:"id" db:"id"`
+	UserID    int              `json:"user_id" db:"user_id"`
+	Payload   ReviewDraftInput `json:"payload" db:"payload"`
+	CreatedAt time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+func NewReviewDraft(userID int, input ReviewDraftInput) *ReviewDraft {
+	now := time.Now()
+	return &ReviewDraft{
+		UserID:    userID,
+		Payload:   input,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// ReviewInput переносит заполненные поля черновика в запрос на создание отзыва.
+func (d ReviewDraftInput) ReviewInput() ReviewInput {
+	input := ReviewInput{
+		CategoryRatings: d.CategoryRatings,
+		BenefitTypeIDs:  d.BenefitTypeIDs,
+	}
+
+	if d.CompanyID != nil {
+		input.CompanyID = *d.CompanyID
+	}
+	if d.Position != nil {
+		input.Position = *d.Position
+	}
+	if d.EmploymentTypeID != nil {
+		input.EmploymentTypeID = *d.EmploymentTypeID
+	}
+	if d.EmploymentPeriodID != nil {
+		input.EmploymentPeriodID = *d.EmploymentPeriodID
+	}
+	if d.CityID != nil {
+		input.CityID = *d.CityID
+	}
+	if d.Pros != nil {
+		input.Pros = *d.Pros
+	}
+	if d.Cons != nil {
+		input.Cons = *d.Cons
+	}
+	if d.IsFormerEmployee != nil {
+		input.IsFormerEmployee = *d.IsFormerEmployee
+	}
+	if d.IsRecommended != nil {
+		input.IsRecommended = *d.IsRecommended
+	}
+
+	return input
+}
+
+func (d ReviewDraftInput) Value() (driver.Value, error) {
+	return json.Marshal(d)
+}
+
+func (d *ReviewDraftInput) Scan(src interface{}) error {
+	return scanJSON(src, d)
+}
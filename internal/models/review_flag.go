@@ -0,0 +1,90 @@
+package models
+
+import "time"
+
+type ReviewFlagReason string
+
+const (
+	ReviewFlagReasonDefamation       ReviewFlagReason = "defamation"
+	ReviewFlagReasonPersonalData     ReviewFlagReason = "personal_data"
+	ReviewFlagReasonSpam             ReviewFlagReason = "spam"
+	ReviewFlagReasonOffensive        ReviewFlagReason = "offensive"
+	ReviewFlagReasonFalseInformation ReviewFlagReason = "false_information"
+	ReviewFlagReasonOther            ReviewFlagReason = "other"
+)
+
+type ReviewFlagStatus string
+
+const (
+	ReviewFlagStatusOpen ReviewFlagStatus = "open"
+	// ReviewFlagStatusDismissed - модератор не нашел нарушений, отзыв остается опубликованным.
+	ReviewFlagStatusDismissed ReviewFlagStatus = "dismissed"
+	// ReviewFlagStatusActioned - жалоба подтверждена, отзыв снят с публикации.
+	ReviewFlagStatusActioned ReviewFlagStatus = "actioned"
+)
+
+type ReviewFlag struct {
+	ID                int              `json:"id" db:"id"`
+	ReviewID          int              `json:"review_id" db:"review_id"`
+	UserID            *int             `json:"user_id,omitempty" db:"user_id"`
+	Reason            ReviewFlagReason `json:"reason" db:"reason"`
+	Comment           *string          `json:"comment,omitempty" db:"comment"`
+	Status            ReviewFlagStatus `json:"status" db:"status"`
+	ResolutionComment *string          `json:"resolution_comment,omitempty" db:"resolution_comment"`
+	ResolvedBy        *int             `json:"resolved_by,omitempty" db:"resolved_by"`
+	CreatedAt         time.Time        `json:"created_at" db:"created_at"`
+	ResolvedAt        *time.Time       `json:"resolved_at,omitempty" db:"resolved_at"`
+}
+
+type ReviewFlagInput struct {
+	Reason  ReviewFlagReason `json:"reason" binding:"required,oneof=defamation personal_data spam offensive false_information other"`
+	Comment string           `json:"comment" binding:"omitempty,max=1000"`
+}
+
+type ReviewFlagResolutionInput struct {
+	Comment string `json:"comment" binding:"omitempty"`
+}
+
+// ReviewFlagSummary - отзыв с открытыми жалобами в очереди модерации.
+type ReviewFlagSummary struct {
+	ReviewID       int       `json:"review_id" db:"review_id"`
+	OpenFlags      int       `json:"open_flags" db:"open_flags"`
+	FirstFlaggedAt time.Time `json:"first_flagged_at" db:"first_flagged_at"`
+}
+
+type FlaggedReview struct {
+	Review    *ReviewWithDetails       `json:"review"`
+	IsHidden  bool                     `json:"is_hidden"`
+	OpenFlags int                      `json:"open_flags"`
+	Reasons   map[ReviewFlagReason]int `json:"reasons"`
+	Flags     []ReviewFlag             `json:"flags"`
+}
+
+func NewReviewFlag(reviewID, userID int, input ReviewFlagInput) ReviewFlag {
+	flag := ReviewFlag{
+		ReviewID:  reviewID,
+		UserID:    &userID,
+		Reason:    input.Reason,
+		Status:    ReviewFlagStatusOpen,
+		CreatedAt: time.Now(),
+	}
+	if input.Comment != "" {
+		flag.Comment = &input.Comment
+	}
+	return flag
+}
+
+func NewFlaggedReview(review *ReviewWithDetails, flags []ReviewFlag) FlaggedReview {
+	reasons := make(map[ReviewFlagReason]int)
+	for _, flag := range flags {
+		reasons[flag.Reason]++
+	}
+
+	return FlaggedReview{
+		Review:    review,
+		IsHidden:  review.Review.IsHidden,
+		OpenFlags: len(flags),
+		Reasons:   reasons,
+		Flags:     flags,
+	}
+}
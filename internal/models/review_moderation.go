@@ -0,0 +1,100 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// ModerationOutcome - решение автоматической предмодерации отзыва.
+type ModerationOutcome string
+
+const (
+	ModerationOutcomeApprove ModerationOutcome = "approve"
+	ModerationOutcomeReject  ModerationOutcome = "reject"
+	ModerationOutcomeQueue   ModerationOutcome = "queue"
+)
+
+// Коды причин, которые выставляют правила предмодерации.
+const (
+	ModerationReasonProfanityRU    = "profanity_ru"
+	ModerationReasonProfanityKK    = "profanity_kk"
+	ModerationReasonLink           = "link"
+	ModerationReasonEmail          = "email"
+	ModerationReasonPhone          = "phone"
+	ModerationReasonMessenger      = "messenger"
+	ModerationReasonLowInformation = "low_information"
+	ModerationReasonLowLetterRatio = "low_letter_ratio"
+	ModerationReasonAllCaps        = "all_caps"
+	ModerationReasonRepeatedChars  = "repeated_chars"
+	ModerationReasonRepeatedWords  = "repeated_words"
+	ModerationReasonTrustedAuthor  = "trusted_author"
+	ModerationReasonNearDuplicate  = "near_duplicate"
+)
+
+// moderationReasonDescriptions - описания причин для комментария к автоматически отклоненному отзыву.
+var moderationReasonDescriptions = map[string]string{
+	ModerationReasonProfanityRU:    "нецензурная лексика",
+	ModerationReasonProfanityKK:    "нецензурная лексика на казахском языке",
+	ModerationReasonLink:           "ссылки на сторонние ресурсы",
+	ModerationReasonEmail:          "адреса электронной почты",
+	ModerationReasonPhone:          "номера телефонов",
+	ModerationReasonMessenger:      "контакты в мессенджерах",
+	ModerationReasonLowInformation: "слишком мало содержательного текста",
+	ModerationReasonLowLetterRatio: "текст почти не содержит слов",
+	ModerationReasonAllCaps:        "текст набран заглавными буквами",
+	ModerationReasonRepeatedChars:  "повторяющиеся символы",
+	ModerationReasonRepeatedWords:  "повторяющиеся слова",
+	ModerationReasonNearDuplicate:  "текст почти совпадает с другими отзывами",
+}
+
+// ModerationRuleResult - результат одного правила предмодерации. Положительный балл говорит о нарушении,
+// отрицательный - о доверии к отзыву.
+type ModerationRuleResult struct {
+	Rule    string   `json:"rule"`
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// ModerationTrace - результат автоматической предмодерации со всеми сработавшими правилами.
+// RevisionID заполняется, если проверялась правка одобренного отзыва.
+type ModerationTrace struct {
+	Outcome     ModerationOutcome      `json:"outcome"`
+	Score       float64                `json:"score"`
+	Rules       []ModerationRuleResult `json:"rules"`
+	RevisionID  *int                   `json:"revision_id,omitempty"`
+	EvaluatedAt time.Time              `json:"evaluated_at"`
+}
+
+// Reasons возвращает коды причин всех правил в порядке их срабатывания.
+func (t ModerationTrace) Reasons() []string {
+	reasons := []string{}
+	for _, rule := range t.Rules {
+		reasons = append(reasons, rule.Reasons...)
+	}
+	return reasons
+}
+
+// RejectionComment формирует комментарий для автора автоматически отклоненного отзыва.
+func (t ModerationTrace) RejectionComment() string {
+	descriptions := []string{}
+	for _, reason := range t.Reasons() {
+		if description, ok := moderationReasonDescriptions[reason]; ok {
+			descriptions = append(descriptions, description)
+		}
+	}
+
+	if len(descriptions) == 0 {
+		return "Отзыв отклонен автоматической проверкой"
+	}
+	return "Отзыв отклонен автоматической проверкой: " + strings.Join(descriptions, ", ")
+}
+
+func (t ModerationTrace) Value() (driver.Value, error) {
+	return json.Marshal(t)
+}
+
+func (t *ModerationTrace) Scan(src interface{}) error {
+	return scanJSON(src, t)
+}
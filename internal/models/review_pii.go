@@ -0,0 +1,22 @@
+package models
+
+// PIIType - вид персональных данных, найденных в тексте отзыва.
+type PIIType string
+
+const (
+	PIITypePhone      PIIType = "phone"
+	PIITypeEmail      PIIType = "email"
+	PIITypeIIN        PIIType = "iin"
+	PIITypeIDDocument PIIType = "id_document"
+	PIITypePerson     PIIType = "person"
+)
+
+// PIISpan - фрагмент текста отзыва с персональными данными. Start и End - позиции в символах
+// (а не в байтах) внутри поля Field, End не включается.
+type PIISpan struct {
+	Field string  `json:"field"`
+	Type  PIIType `json:"type"`
+	Start int     `json:"start"`
+	End   int     `json:"end"`
+	Text  string  `json:"text"`
+}
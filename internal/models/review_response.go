@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+type ReviewResponseStatus string
+
+const (
+	ReviewResponseStatusPending  ReviewResponseStatus = "pending"
+	ReviewResponseStatusApproved ReviewResponseStatus = "approved"
+	ReviewResponseStatusRejected ReviewResponseStatus = "rejected"
+)
+
+// ReviewResponse - официальный ответ компании на отзыв. Text - последняя отправленная версия,
+// PublishedText - версия, одобренная модератором. Пока правка ответа на модерации, вместе с отзывом
+// показывается прежняя одобренная версия.
+type ReviewResponse struct {
+	ID                int                  `json:"id" db:"id"`
+	ReviewID          int                  `json:"review_id" db:"review_id"`
+	CompanyID         int                  `json:"company_id" db:"company_id"`
+	AuthorID          *int                 `json:"author_id,omitempty" db:"author_id"`
+	Text              string               `json:"text" db:"text"`
+	PublishedText     *string              `json:"published_text,omitempty" db:"published_text"`
+	Status            ReviewResponseStatus `json:"status" db:"status"`
+	ModerationComment *string              `json:"moderation_comment,omitempty" db:"moderation_comment"`
+	ModeratedBy       *int                 `json:"moderated_by,omitempty" db:"moderated_by"`
+	CreatedAt         time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time            `json:"updated_at" db:"updated_at"`
+	ModeratedAt       *time.Time           `json:"moderated_at,omitempty" db:"moderated_at"`
+	PublishedAt       *time.Time           `json:"published_at,omitempty" db:"published_at"`
+}
+
+// PublishedReviewResponse - опубликованный ответ компании в составе отзыва.
+type PublishedReviewResponse struct {
+	ID          int       `json:"id" db:"id"`
+	CompanyID   int       `json:"company_id" db:"company_id"`
+	Text        string    `json:"text" db:"published_text"`
+	PublishedAt time.Time `json:"published_at" db:"published_at"`
+	ReviewID    int       `json:"-" db:"review_id"`
+}
+
+type ReviewResponseInput struct {
+	Text string `json:"text" binding:"required,min=10,max=5000"`
+}
+
+type ReviewResponseModerationInput struct {
+	ModerationComment string `json:"moderation_comment" binding:"omitempty"`
+}
@@ -0,0 +1,259 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+type ReviewRevisionStatus string
+
+const (
+	// ReviewRevisionStatusApplied - правка отзыва, который еще не прошел модерацию. Она сразу применяется
+	// к отзыву и проверяется вместе с ним.
+	ReviewRevisionStatusApplied ReviewRevisionStatus = "applied"
+	// ReviewRevisionStatusPending - правка одобренного отзыва, ожидающая модерации. До ее одобрения
+	// опубликованной остается прежняя версия отзыва.
+	ReviewRevisionStatusPending    ReviewRevisionStatus = "pending"
+	ReviewRevisionStatusApproved   ReviewRevisionStatus = "approved"
+	ReviewRevisionStatusRejected   ReviewRevisionStatus = "rejected"
+	ReviewRevisionStatusSuperseded ReviewRevisionStatus = "superseded"
+)
+
+// ReviewContent - редактируемая автором часть отзыва.
+type ReviewContent struct {
+	Position         string          `json:"position"`
+	Pros             string          `json:"pros"`
+	Cons             string          `json:"cons"`
+	IsFormerEmployee bool            `json:"is_former_employee"`
+	IsRecommended    bool            `json:"is_recommended"`
+	CategoryRatings  map[int]float64 `json:"category_ratings"`
+	BenefitTypeIDs   []int           `json:"benefit_type_ids"`
+}
+
+// ReviewFieldChange - изменение одного поля отзыва. Для оценок по категориям поле называется
+// category_ratings.<ID категории>, отсутствующая оценка передается как null.
+type ReviewFieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+type ReviewChanges []ReviewFieldChange
+
+type ReviewRevision struct {
+	ID                int                  `json:"id" db:"id"`
+	ReviewID          int                  `json:"review_id" db:"review_id"`
+	Revision          int                  `json:"revision" db:"revision"`
+	EditorID          *int                 `json:"editor_id,omitempty" db:"editor_id"`
+	Status            ReviewRevisionStatus `json:"status" db:"status"`
+	Content           ReviewContent        `json:"content" db:"content"`
+	Changes           ReviewChanges        `json:"changes" db:"changes"`
+	ModerationComment *string              `json:"moderation_comment,omitempty" db:"moderation_comment"`
+	ModeratedBy       *int                 `json:"moderated_by,omitempty" db:"moderated_by"`
+	CreatedAt         time.Time            `json:"created_at" db:"created_at"`
+	ModeratedAt       *time.Time           `json:"moderated_at,omitempty" db:"moderated_at"`
+}
+
+// ReviewEditInput - правка отзыва автором. Незаданные поля не меняются, оценки по категориям
+// дополняют и перезаписывают текущие, а список льгот заменяется целиком.
+type ReviewEditInput struct {
+	Position         *string         `json:"position,omitempty" binding:"omitempty,min=2,max=100"`
+	Pros             *string         `json:"pros,omitempty" binding:"omitempty,min=10"`
+	Cons             *string         `json:"cons,omitempty" binding:"omitempty,min=10"`
+	IsFormerEmployee *bool           `json:"is_former_employee,omitempty"`
+	IsRecommended    *bool           `json:"is_recommended,omitempty"`
+	CategoryRatings  map[int]float64 `json:"category_ratings,omitempty" binding:"omitempty,dive,min=1,max=5"`
+	BenefitTypeIDs   []int           `json:"benefit_type_ids,omitempty" binding:"omitempty,dive,min=1"`
+}
+
+type ReviewRevisionModerationInput struct {
+	ModerationComment string `json:"moderation_comment" binding:"omitempty"`
+}
+
+func NewReviewContent(details *ReviewWithDetails) ReviewContent {
+	content := ReviewContent{
+		Position:         details.Review.Position,
+		Pros:             details.Review.Pros,
+		Cons:             details.Review.Cons,
+		IsFormerEmployee: details.Review.IsFormerEmployee,
+		IsRecommended:    details.Review.IsRecommended,
+		CategoryRatings:  make(map[int]float64, len(details.CategoryRatings)),
+		BenefitTypeIDs:   make([]int, 0, len(details.Benefits)),
+	}
+
+	for _, rating := range details.CategoryRatings {
+		content.CategoryRatings[rating.CategoryID] = rating.Rating
+	}
+	for _, benefit := range details.Benefits {
+		content.BenefitTypeIDs = append(content.BenefitTypeIDs, benefit.BenefitTypeID)
+	}
+	sort.Ints(content.BenefitTypeIDs)
+
+	return content
+}
+
+// Apply возвращает копию содержимого с примененной правкой.
+func (c ReviewContent) Apply(input ReviewEditInput) ReviewContent {
+	result := c
+	result.CategoryRatings = make(map[int]float64, len(c.CategoryRatings))
+	for categoryID, rating := range c.CategoryRatings {
+		result.CategoryRatings[categoryID] = rating
+	}
+	result.BenefitTypeIDs = append([]int{}, c.BenefitTypeIDs...)
+
+	if input.Position != nil {
+		result.Position = *input.Position
+	}
+	if input.Pros != nil {
+		result.Pros = *input.Pros
+	}
+	if input.Cons != nil {
+		result.Cons = *input.Cons
+	}
+	if input.IsFormerEmployee != nil {
+		result.IsFormerEmployee = *input.IsFormerEmployee
+	}
+	if input.IsRecommended != nil {
+		result.IsRecommended = *input.IsRecommended
+	}
+	for categoryID, rating := range input.CategoryRatings {
+		result.CategoryRatings[categoryID] = rating
+	}
+	if input.BenefitTypeIDs != nil {
+		result.BenefitTypeIDs = uniqueSortedIDs(input.BenefitTypeIDs)
+	}
+
+	return result
+}
+
+// Rating - средняя оценка по категориям, округленная так же, как при создании отзыва.
+func (c ReviewContent) Rating() float64 {
+	if len(c.CategoryRatings) == 0 {
+		return 0
+	}
+
+	var total float64
+	for _, rating := range c.CategoryRatings {
+		total += rating
+	}
+
+	return math.Round(total/float64(len(c.CategoryRatings))*10) / 10
+}
+
+// Diff возвращает изменения, которые переводят содержимое c в next.
+func (c ReviewContent) Diff(next ReviewContent) ReviewChanges {
+	changes := ReviewChanges{}
+
+	addChange := func(field string, oldValue, newValue interface{}) {
+		changes = append(changes, ReviewFieldChange{Field: field, Old: oldValue, New: newValue})
+	}
+
+	if c.Position != next.Position {
+		addChange("position", c.Position, next.Position)
+	}
+	if c.Pros != next.Pros {
+		addChange("pros", c.Pros, next.Pros)
+	}
+	if c.Cons != next.Cons {
+		addChange("cons", c.Cons, next.Cons)
+	}
+	if c.IsFormerEmployee != next.IsFormerEmployee {
+		addChange("is_former_employee", c.IsFormerEmployee, next.IsFormerEmployee)
+	}
+	if c.IsRecommended != next.IsRecommended {
+		addChange("is_recommended", c.IsRecommended, next.IsRecommended)
+	}
+
+	categoryIDs := make([]int, 0, len(c.CategoryRatings)+len(next.CategoryRatings))
+	for categoryID := range c.CategoryRatings {
+		categoryIDs = append(categoryIDs, categoryID)
+	}
+	for categoryID := range next.CategoryRatings {
+		if _, ok := c.CategoryRatings[categoryID]; !ok {
+			categoryIDs = append(categoryIDs, categoryID)
+		}
+	}
+	sort.Ints(categoryIDs)
+
+	for _, categoryID := range categoryIDs {
+		oldRating, hadOld := c.CategoryRatings[categoryID]
+		newRating, hasNew := next.CategoryRatings[categoryID]
+		if hadOld == hasNew && oldRating == newRating {
+			continue
+		}
+
+		var oldValue, newValue interface{}
+		if hadOld {
+			oldValue = oldRating
+		}
+		if hasNew {
+			newValue = newRating
+		}
+		addChange("category_ratings."+strconv.Itoa(categoryID), oldValue, newValue)
+	}
+
+	if !equalIDs(c.BenefitTypeIDs, next.BenefitTypeIDs) {
+		addChange("benefit_type_ids", c.BenefitTypeIDs, next.BenefitTypeIDs)
+	}
+
+	return changes
+}
+
+func (c ReviewContent) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+func (c *ReviewContent) Scan(src interface{}) error {
+	return scanJSON(src, c)
+}
+
+func (c ReviewChanges) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+func (c *ReviewChanges) Scan(src interface{}) error {
+	return scanJSON(src, c)
+}
+
+func scanJSON(src interface{}, dest interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	case nil:
+		return nil
+	default:
+		return fmt.Errorf("неподдерживаемый тип JSON значения: %T", src)
+	}
+}
+
+func uniqueSortedIDs(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	result := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	sort.Ints(result)
+	return result
+}
+
+func equalIDs(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// ReviewSimilarity - другой отзыв, текст которого почти совпадает с текстом проверяемого.
+type ReviewSimilarity struct {
+	ReviewID        int          `json:"-" db:"review_id"`
+	SimilarReviewID int          `json:"similar_review_id" db:"similar_review_id"`
+	UserID          int          `json:"user_id" db:"user_id"`
+	CompanyID       int          `json:"company_id" db:"company_id"`
+	Status          ReviewStatus `json:"status" db:"status"`
+	SameAuthor      bool         `json:"same_author" db:"same_author"`
+	Score           float64      `json:"score" db:"score"`
+	CreatedAt       time.Time    `json:"created_at" db:"created_at"`
+}
+
+// ModerationSignals - сведения для модератора, собранные автоматическими проверками отзыва.
+type ModerationSignals struct {
+	Suspicious     bool               `json:"is_suspicious"`
+	SimilarReviews []ReviewSimilarity `json:"similar_reviews,omitempty"`
+	Trace          *ModerationTrace   `json:"trace,omitempty"`
+	PII            []PIISpan          `json:"pii,omitempty"`
+}
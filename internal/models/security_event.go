@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+type SecurityEventType string
+
+const (
+	SecurityEventRefreshTokenReuse  SecurityEventType = "refresh_token_reuse"
+	SecurityEventTwoFactorEnabled   SecurityEventType = "two_factor_enabled"
+	SecurityEventTwoFactorDisabled  SecurityEventType = "two_factor_disabled"
+	SecurityEventRecoveryCodeUsed   SecurityEventType = "recovery_code_used"
+	SecurityEventRecoveryCodesReset SecurityEventType = "recovery_codes_regenerated"
+	SecurityEventEmailChanged       SecurityEventType = "email_changed"
+	SecurityEventDataExported       SecurityEventType = "data_export_requested"
+)
+
+type SecurityEvent struct {
+	ID        int               `json:"id" db:"id"`
+	UserID    *int              `json:"user_id,omitempty" db:"user_id"`
+	EventType SecurityEventType `json:"event_type" db:"event_type"`
+	IPAddress string            `json:"ip_address,omitempty" db:"ip_address"`
+	UserAgent string            `json:"user_agent,omitempty" db:"user_agent"`
+	Details   string            `json:"details,omitempty" db:"details"`
+	CreatedAt time.Time         `json:"created_at" db:"created_at"`
+}
+
+func NewSecurityEvent(userID int, eventType SecurityEventType, ipAddress, userAgent, details string) *SecurityEvent {
+	return &SecurityEvent{
+		UserID:    &userID,
+		EventType: eventType,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+		Details:   details,
+		CreatedAt: time.Now(),
+	}
+}
@@ -15,6 +15,7 @@ type Suggestion struct {
 	ID        int            `json:"id" db:"id"`
 	Type      SuggestionType `json:"type" db:"type"`
 	Text      string         `json:"text" db:"text"`
+	UserID    *int           `json:"user_id,omitempty" db:"user_id"`
 	CreatedAt time.Time      `json:"created_at" db:"created_at"`
 }
 
@@ -30,10 +31,11 @@ type SuggestionFilter struct {
 	Limit     int            `form:"limit" binding:"omitempty,min=1,max=100"`
 }
 
-func NewSuggestion(input SuggestionInput) *Suggestion {
+func NewSuggestion(input SuggestionInput, userID *int) *Suggestion {
 	return &Suggestion{
 		Type:      input.Type,
 		Text:      input.Text,
+		UserID:    userID,
 		CreatedAt: time.Now(),
 	}
 }
@@ -15,32 +15,54 @@ const (
 	RoleAdmin     UserRole = "admin"
 )
 
+// AnonymousUserEmail - email служебного пользователя, к которому привязываются одобренные отзывы
+// удаленных аккаунтов. Служебные пользователи не находятся по email, поэтому войти под ними нельзя.
+const AnonymousUserEmail = "deleted-user@jobsolution.invalid"
+
 type User struct {
-	ID           int       `json:"id" db:"id"`
-	Email        string    `json:"email" db:"email"`
-	Phone        string    `json:"phone,omitempty" db:"phone"`
-	PasswordHash string    `json:"-" db:"password_hash"`
-	FirstName    string    `json:"first_name,omitempty" db:"first_name"`
-	LastName     string    `json:"last_name,omitempty" db:"last_name"`
-	Role         UserRole  `json:"role" db:"role"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID              int        `json:"id" db:"id"`
+	Email           string     `json:"email" db:"email"`
+	Phone           string     `json:"phone,omitempty" db:"phone"`
+	PasswordHash    string     `json:"-" db:"password_hash"`
+	FirstName       string     `json:"first_name,omitempty" db:"first_name"`
+	LastName        string     `json:"last_name,omitempty" db:"last_name"`
+	Role            UserRole   `json:"role" db:"role"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty" db:"email_verified_at"`
+	TOTPSecret      *string    `json:"-" db:"totp_secret"`
+	TOTPEnabledAt   *time.Time `json:"-" db:"totp_enabled_at"`
+	TOTPLastStep    *int64     `json:"-" db:"totp_last_step"`
+	LoginUnlockedAt *time.Time `json:"-" db:"login_unlocked_at"`
+	TokenVersion    int        `json:"-" db:"token_version"`
+	IsSystem        bool       `json:"-" db:"is_system"`
+	DeletionDueAt   *time.Time `json:"-" db:"deletion_due_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// TokenState - текущие роль и версия токенов пользователя, с которыми сверяется каждый access токен.
+type TokenState struct {
+	UserID       int      `db:"id"`
+	Role         UserRole `db:"role"`
+	TokenVersion int      `db:"token_version"`
 }
 
 type UserProfile struct {
-	ID        int       `json:"id"`
-	Email     string    `json:"email"`
-	Phone     string    `json:"phone,omitempty"`
-	FirstName string    `json:"first_name,omitempty"`
-	LastName  string    `json:"last_name,omitempty"`
-	Role      UserRole  `json:"role"`
-	CreatedAt time.Time `json:"created_at"`
+	ID               int        `json:"id"`
+	Email            string     `json:"email"`
+	EmailVerified    bool       `json:"email_verified"`
+	TwoFactorEnabled bool       `json:"two_factor_enabled"`
+	Phone            string     `json:"phone,omitempty"`
+	FirstName        string     `json:"first_name,omitempty"`
+	LastName         string     `json:"last_name,omitempty"`
+	Role             UserRole   `json:"role"`
+	DeletionDueAt    *time.Time `json:"deletion_due_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
 }
 
 type UserRegisterInput struct {
 	Email           string `json:"email" binding:"required,email"`
 	Phone           string `json:"phone" binding:"omitempty,phone"`
-	Password        string `json:"password" binding:"required,min=8"`
+	Password        string `json:"password" binding:"required"`
 	PasswordConfirm string `json:"password_confirm" binding:"required,eqfield=Password"`
 	FirstName       string `json:"first_name" binding:"omitempty"`
 	LastName        string `json:"last_name" binding:"omitempty"`
@@ -55,16 +77,45 @@ type UserUpdateInput struct {
 	Phone           *string `json:"phone" binding:"omitempty,phone"`
 	FirstName       *string `json:"first_name" binding:"omitempty"`
 	LastName        *string `json:"last_name" binding:"omitempty"`
-	Password        *string `json:"password" binding:"omitempty,min=8"`
+	Password        *string `json:"password" binding:"omitempty"`
 	PasswordConfirm *string `json:"password_confirm" binding:"omitempty,eqfield=Password"`
 }
 
 type ForgotPasswordInput struct {
-	Email           string `json:"email" binding:"required,email"`
-	Password        string `json:"password" binding:"required,min=8"`
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordInput struct {
+	Token           string `json:"token" binding:"required"`
+	Password        string `json:"password" binding:"required"`
 	PasswordConfirm string `json:"password_confirm" binding:"required,eqfield=Password"`
 }
 
+type MagicLinkRequestInput struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type MagicLinkLoginInput struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type EmailChangeInput struct {
+	NewEmail string `json:"new_email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+type EmailChangeTokenInput struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type AccountDeletionInput struct {
+	Password string `json:"password" binding:"required"`
+}
+
+type VerifyEmailInput struct {
+	Token string `json:"token" binding:"required"`
+}
+
 type UserRoleUpdateInput struct {
 	Role UserRole `json:"role" binding:"required,oneof=admin moderator user"`
 }
@@ -77,7 +128,7 @@ type PasswordResetToken struct {
 	CreatedAt time.Time `db:"created_at"`
 }
 
-type RefreshToken struct {
+type EmailVerificationToken struct {
 	ID        int       `db:"id"`
 	UserID    int       `db:"user_id"`
 	Token     string    `db:"token"`
@@ -85,6 +136,52 @@ type RefreshToken struct {
 	CreatedAt time.Time `db:"created_at"`
 }
 
+type MagicLinkToken struct {
+	ID        int       `db:"id"`
+	UserID    int       `db:"user_id"`
+	Token     string    `db:"token"`
+	ExpiresAt time.Time `db:"expires_at"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// EmailChangeRequest - запрос на смену email. Token приходит на новый адрес и подтверждает смену,
+// CancelToken приходит на прежний адрес и позволяет отменить запрос.
+type EmailChangeRequest struct {
+	ID          int       `db:"id"`
+	UserID      int       `db:"user_id"`
+	NewEmail    string    `db:"new_email"`
+	Token       string    `db:"token"`
+	CancelToken string    `db:"cancel_token"`
+	ExpiresAt   time.Time `db:"expires_at"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+type RefreshToken struct {
+	ID                int        `db:"id"`
+	UserID            int        `db:"user_id"`
+	Token             string     `db:"token"`
+	FamilyID          string     `db:"family_id"`
+	UserAgent         string     `db:"user_agent"`
+	IPAddress         string     `db:"ip_address"`
+	SessionStartedAt  time.Time  `db:"session_started_at"`
+	ExpiresAt         time.Time  `db:"expires_at"`
+	RotatedAt         *time.Time `db:"rotated_at"`
+	RevokedAt         *time.Time `db:"revoked_at"`
+	TwoFactorVerified bool       `db:"two_factor_verified"`
+	CreatedAt         time.Time  `db:"created_at"`
+}
+
+// Session - активный вход пользователя, представленный последним токеном семейства.
+type Session struct {
+	ID         string    `json:"id" db:"family_id"`
+	UserAgent  string    `json:"user_agent" db:"user_agent"`
+	IPAddress  string    `json:"ip_address" db:"ip_address"`
+	CreatedAt  time.Time `json:"created_at" db:"session_started_at"`
+	LastUsedAt time.Time `json:"last_used_at" db:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at" db:"expires_at"`
+	IsCurrent  bool      `json:"is_current" db:"-"`
+}
+
 func NewUser(input UserRegisterInput) (*User, error) {
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -109,21 +206,105 @@ func (u *User) ComparePassword(password string) bool {
 	return err == nil
 }
 
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+func (u *User) IsTwoFactorEnabled() bool {
+	return u.TOTPEnabledAt != nil && u.TOTPSecret != nil
+}
+
+// LastTOTPStep возвращает номер последнего принятого шага TOTP (0, если кодов еще не было).
+func (u *User) LastTOTPStep() int64 {
+	if u.TOTPLastStep == nil {
+		return 0
+	}
+	return *u.TOTPLastStep
+}
+
+// IsStaff сообщает, относится ли роль к сотрудникам сайта (администраторы и модераторы).
+func (r UserRole) IsStaff() bool {
+	return r == RoleAdmin || r == RoleModerator
+}
+
 func (u *User) ToProfile() UserProfile {
 	return UserProfile{
-		ID:        u.ID,
-		Email:     u.Email,
-		Phone:     u.Phone,
-		FirstName: u.FirstName,
-		LastName:  u.LastName,
-		Role:      u.Role,
-		CreatedAt: u.CreatedAt,
+		ID:               u.ID,
+		Email:            u.Email,
+		EmailVerified:    u.IsEmailVerified(),
+		TwoFactorEnabled: u.IsTwoFactorEnabled(),
+		Phone:            u.Phone,
+		FirstName:        u.FirstName,
+		LastName:         u.LastName,
+		Role:             u.Role,
+		DeletionDueAt:    u.DeletionDueAt,
+		CreatedAt:        u.CreatedAt,
 	}
 }
 
+// NewRefreshToken создает первый токен нового семейства (одно семейство на один вход).
 func NewRefreshToken(userID int, expiresIn time.Duration) RefreshToken {
 	now := time.Now()
 	return RefreshToken{
+		UserID:           userID,
+		Token:            uuid.New().String(),
+		FamilyID:         uuid.New().String(),
+		SessionStartedAt: now,
+		ExpiresAt:        now.Add(expiresIn),
+		CreatedAt:        now,
+	}
+}
+
+// Rotate создает следующий токен того же семейства.
+func (t *RefreshToken) Rotate(expiresIn time.Duration) RefreshToken {
+	now := time.Now()
+	return RefreshToken{
+		UserID:            t.UserID,
+		Token:             uuid.New().String(),
+		FamilyID:          t.FamilyID,
+		UserAgent:         t.UserAgent,
+		IPAddress:         t.IPAddress,
+		SessionStartedAt:  t.SessionStartedAt,
+		TwoFactorVerified: t.TwoFactorVerified,
+		ExpiresAt:         now.Add(expiresIn),
+		CreatedAt:         now,
+	}
+}
+
+func (t *RefreshToken) SetClient(userAgent, ipAddress string) {
+	t.UserAgent = userAgent
+	t.IPAddress = ipAddress
+}
+
+func (t *RefreshToken) IsExpired() bool {
+	return t.ExpiresAt.Before(time.Now())
+}
+
+func (t *RefreshToken) IsRotated() bool {
+	return t.RotatedAt != nil
+}
+
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+func NewPasswordResetToken(userID int, expiresIn time.Duration) PasswordResetToken {
+	now := time.Now()
+	return PasswordResetToken{
+		UserID:    userID,
+		Token:     uuid.New().String(),
+		ExpiresAt: now.Add(expiresIn),
+		CreatedAt: now,
+	}
+}
+
+func (t *PasswordResetToken) IsExpired() bool {
+	return t.ExpiresAt.Before(time.Now())
+}
+
+func NewMagicLinkToken(userID int, expiresIn time.Duration) MagicLinkToken {
+	now := time.Now()
+	return MagicLinkToken{
 		UserID:    userID,
 		Token:     uuid.New().String(),
 		ExpiresAt: now.Add(expiresIn),
@@ -131,12 +312,36 @@ func NewRefreshToken(userID int, expiresIn time.Duration) RefreshToken {
 	}
 }
 
-func NewPasswordResetToken(userID int) PasswordResetToken {
+func (t *MagicLinkToken) IsExpired() bool {
+	return t.ExpiresAt.Before(time.Now())
+}
+
+func NewEmailChangeRequest(userID int, newEmail string, expiresIn time.Duration) EmailChangeRequest {
 	now := time.Now()
-	return PasswordResetToken{
+	return EmailChangeRequest{
+		UserID:      userID,
+		NewEmail:    newEmail,
+		Token:       uuid.New().String(),
+		CancelToken: uuid.New().String(),
+		ExpiresAt:   now.Add(expiresIn),
+		CreatedAt:   now,
+	}
+}
+
+func (r *EmailChangeRequest) IsExpired() bool {
+	return r.ExpiresAt.Before(time.Now())
+}
+
+func NewEmailVerificationToken(userID int, expiresIn time.Duration) EmailVerificationToken {
+	now := time.Now()
+	return EmailVerificationToken{
 		UserID:    userID,
 		Token:     uuid.New().String(),
-		ExpiresAt: now.Add(24 * time.Hour),
+		ExpiresAt: now.Add(expiresIn),
 		CreatedAt: now,
 	}
 }
+
+func (t *EmailVerificationToken) IsExpired() bool {
+	return t.ExpiresAt.Before(time.Now())
+}
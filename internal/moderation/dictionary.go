@@ -0,0 +1,20 @@
+package moderation
+
+// Словари нецензурной лексики. Слово со звездочкой на конце совпадает со всеми словами, которые
+// с него начинаются, остальные слова совпадают только целиком. Буква «ё» заменяется на «е» перед сравнением.
+var profanityRU = []string{
+	"хуй*", "хуе*", "хуя*", "хую*", "хуи*", "охуе*", "охуи*", "нахуй*", "нахуя*", "похуй*", "нихуя*",
+	"пизд*", "распизд*", "спизд*", "напизд*", "запизд*",
+	"ебат*", "ебан*", "ебал*", "ебну*", "ебуч*", "ебл*", "заеб*", "выеб*", "наеб*", "отъеб*", "поеб*",
+	"уеб*", "доеб*", "въеб*", "съеб*", "разъеб*",
+	"бля", "блять*", "блядь*", "бляд*",
+	"мудак*", "мудач*", "мудил*", "гандон*", "пидор*", "пидар*", "залуп*", "шлюх*",
+	"сука", "суки", "суку", "сукой", "сучка*", "сучар*",
+}
+
+var profanityKK = []string{
+	"сік", "сікт*", "сігу*", "сігей*", "сігіп*", "сіктір*",
+	"қотақ*", "қотағ*", "амың*", "амыңды*",
+	"жезөкше*", "қаншық*", "шешеңді*", "шешеңнің*",
+	"боқ", "көтің*", "көтіңе*",
+}
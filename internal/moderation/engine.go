@@ -0,0 +1,93 @@
+package moderation
+
+import (
+	"strings"
+	"time"
+
+	"job_solition/internal/config"
+	"job_solition/internal/models"
+)
+
+// Input - проверяемое содержимое отзыва и сведения об авторе.
+type Input struct {
+	Position string
+	Pros     string
+	Cons     string
+	Author   AuthorStats
+	// Suspicious - текст отзыва почти совпадает с другими отзывами.
+	Suspicious bool
+}
+
+// AuthorStats - история модерации отзывов автора без учета проверяемого отзыва.
+type AuthorStats struct {
+	ApprovedReviews int
+	RejectedReviews int
+}
+
+// Text возвращает весь проверяемый текст отзыва.
+func (i Input) Text() string {
+	return strings.Join([]string{i.Position, i.Pros, i.Cons}, "\n")
+}
+
+// Rule - правило предмодерации. Правило возвращает балл и коды причин, решение по сумме баллов
+// принимает Engine.
+type Rule interface {
+	Name() string
+	Check(input Input) models.ModerationRuleResult
+}
+
+// Engine прогоняет отзыв через правила и принимает решение по сумме их баллов: не выше approveScore -
+// публикация, не ниже rejectScore - отклонение, иначе отзыв ждет модератора.
+type Engine struct {
+	rules        []Rule
+	approveScore float64
+	rejectScore  float64
+}
+
+func NewEngine(approveScore, rejectScore float64, rules ...Rule) *Engine {
+	return &Engine{
+		rules:        rules,
+		approveScore: approveScore,
+		rejectScore:  rejectScore,
+	}
+}
+
+// New создает движок со стандартным набором правил.
+func New(cfg config.ModerationConfig) *Engine {
+	return NewEngine(cfg.AutoApproveScore, cfg.AutoRejectScore,
+		NewProfanityRule(),
+		NewContactRule(),
+		NewDensityRule(defaultMinMeaningfulWords),
+		NewCapsRule(),
+		NewRepetitionRule(),
+		NewDuplicateRule(),
+		NewTrustedAuthorRule(cfg.TrustedAuthorMinApproved),
+	)
+}
+
+// Evaluate проверяет отзыв всеми правилами. Почти дубликат другого отзыва никогда не публикуется
+// автоматически.
+func (e *Engine) Evaluate(input Input) models.ModerationTrace {
+	trace := models.ModerationTrace{
+		Rules:       make([]models.ModerationRuleResult, 0, len(e.rules)),
+		EvaluatedAt: time.Now(),
+	}
+
+	for _, rule := range e.rules {
+		result := rule.Check(input)
+		result.Rule = rule.Name()
+		trace.Score += result.Score
+		trace.Rules = append(trace.Rules, result)
+	}
+
+	switch {
+	case trace.Score >= e.rejectScore:
+		trace.Outcome = models.ModerationOutcomeReject
+	case trace.Score <= e.approveScore && !input.Suspicious:
+		trace.Outcome = models.ModerationOutcomeApprove
+	default:
+		trace.Outcome = models.ModerationOutcomeQueue
+	}
+
+	return trace
+}
@@ -0,0 +1,192 @@
+package moderation
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"job_solition/internal/models"
+)
+
+var (
+	emailPattern = regexp.MustCompile(`(?i)[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}`)
+	// phonePattern - казахстанские и российские номера: +7, 8 или 7 и десять цифр с любыми разделителями.
+	phonePattern = regexp.MustCompile(`(?:\+7|\b[78])[\s(-]*\d{3}[\s)-]*\d{3}[\s-]*\d{2}[\s-]*\d{2}\b`)
+	iinPattern   = regexp.MustCompile(`\b\d{12}\b`)
+	// idDocumentPattern - серия и номер российского паспорта или номер удостоверения личности РК.
+	idDocumentPattern = regexp.MustCompile(`\b\d{4} \d{6}\b|\b0\d{8}\b`)
+)
+
+// piiPlaceholders - чем заменяются персональные данные в публичном тексте отзыва.
+var piiPlaceholders = map[models.PIIType]string{
+	models.PIITypePhone:      "[телефон скрыт]",
+	models.PIITypeEmail:      "[email скрыт]",
+	models.PIITypeIIN:        "[ИИН скрыт]",
+	models.PIITypeIDDocument: "[номер документа скрыт]",
+	models.PIITypePerson:     "[имя скрыто]",
+}
+
+// maxNameSuffix - сколько букв окончания может добавиться к имени из списка, чтобы найти его
+// в других падежах (Иванов - Иванова, Ивановым).
+const maxNameSuffix = 2
+
+// PIIDetector находит в тексте отзыва телефоны, адреса почты, ИИН, номера документов и имена людей
+// из заданного списка.
+type PIIDetector struct {
+	names []string
+}
+
+// NewPIIDetector создает детектор. Имена из нескольких слов разбиваются на отдельные слова,
+// регистр и разница между «е» и «ё» не учитываются.
+func NewPIIDetector(names []string) *PIIDetector {
+	detector := &PIIDetector{}
+	seen := make(map[string]bool)
+	for _, name := range names {
+		for _, word := range words(name) {
+			if !seen[word] {
+				seen[word] = true
+				detector.names = append(detector.names, word)
+			}
+		}
+	}
+	return detector
+}
+
+// piiMatch - найденный фрагмент с позициями в байтах.
+type piiMatch struct {
+	piiType    models.PIIType
+	start, end int
+}
+
+func (d *PIIDetector) find(text string) []piiMatch {
+	var matches []piiMatch
+	add := func(piiType models.PIIType, start, end int) {
+		for _, match := range matches {
+			if start < match.end && match.start < end {
+				return
+			}
+		}
+		matches = append(matches, piiMatch{piiType: piiType, start: start, end: end})
+	}
+
+	for _, loc := range emailPattern.FindAllStringIndex(text, -1) {
+		add(models.PIITypeEmail, loc[0], loc[1])
+	}
+	for _, loc := range phonePattern.FindAllStringIndex(text, -1) {
+		add(models.PIITypePhone, loc[0], loc[1])
+	}
+	for _, loc := range iinPattern.FindAllStringIndex(text, -1) {
+		if validIIN(text[loc[0]:loc[1]]) {
+			add(models.PIITypeIIN, loc[0], loc[1])
+		}
+	}
+	for _, loc := range idDocumentPattern.FindAllStringIndex(text, -1) {
+		add(models.PIITypeIDDocument, loc[0], loc[1])
+	}
+	if len(d.names) > 0 {
+		for _, loc := range wordIndexes(text) {
+			if d.isName(text[loc[0]:loc[1]]) {
+				add(models.PIITypePerson, loc[0], loc[1])
+			}
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].start < matches[j].start
+	})
+	return matches
+}
+
+func (d *PIIDetector) isName(word string) bool {
+	word = strings.ReplaceAll(strings.ToLower(word), "ё", "е")
+	for _, name := range d.names {
+		if word == name {
+			return true
+		}
+		suffix := utf8.RuneCountInString(word) - utf8.RuneCountInString(name)
+		if utf8.RuneCountInString(name) >= 3 && suffix > 0 && suffix <= maxNameSuffix && strings.HasPrefix(word, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Detect возвращает фрагменты с персональными данными в тексте поля field.
+func (d *PIIDetector) Detect(field, text string) []models.PIISpan {
+	spans := []models.PIISpan{}
+	for _, match := range d.find(text) {
+		start := utf8.RuneCountInString(text[:match.start])
+		spans = append(spans, models.PIISpan{
+			Field: field,
+			Type:  match.piiType,
+			Start: start,
+			End:   start + utf8.RuneCountInString(text[match.start:match.end]),
+			Text:  text[match.start:match.end],
+		})
+	}
+	return spans
+}
+
+// Redact заменяет персональные данные в тексте на пометки о том, что они скрыты.
+func (d *PIIDetector) Redact(text string) string {
+	matches := d.find(text)
+	if len(matches) == 0 {
+		return text
+	}
+
+	var builder strings.Builder
+	last := 0
+	for _, match := range matches {
+		builder.WriteString(text[last:match.start])
+		builder.WriteString(piiPlaceholders[match.piiType])
+		last = match.end
+	}
+	builder.WriteString(text[last:])
+	return builder.String()
+}
+
+// wordIndexes возвращает позиции слов из букв в байтах.
+func wordIndexes(text string) [][2]int {
+	var indexes [][2]int
+	start := -1
+	for i, char := range text {
+		if unicode.IsLetter(char) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			indexes = append(indexes, [2]int{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		indexes = append(indexes, [2]int{start, len(text)})
+	}
+	return indexes
+}
+
+// validIIN проверяет контрольную цифру ИИН.
+func validIIN(iin string) bool {
+	digits := make([]int, len(iin))
+	for i, char := range iin {
+		digits[i] = int(char - '0')
+	}
+
+	checksum := func(weights []int) int {
+		sum := 0
+		for i, weight := range weights {
+			sum += digits[i] * weight
+		}
+		return sum % 11
+	}
+
+	control := checksum([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11})
+	if control == 10 {
+		control = checksum([]int{3, 4, 5, 6, 7, 8, 9, 10, 11, 1, 2})
+	}
+	return control != 10 && control == digits[11]
+}
@@ -0,0 +1,293 @@
+package moderation
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"job_solition/internal/models"
+)
+
+// defaultMinMeaningfulWords - сколько разных слов из трех и более букв должно быть в отзыве.
+const defaultMinMeaningfulWords = 6
+
+// words разбивает текст на слова в нижнем регистре. Буква «ё» заменяется на «е».
+func words(text string) []string {
+	text = strings.ReplaceAll(strings.ToLower(text), "ё", "е")
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+}
+
+// dictionary - словарь слов и префиксов.
+type dictionary struct {
+	exact    map[string]bool
+	prefixes []string
+}
+
+func newDictionary(entries []string) dictionary {
+	d := dictionary{exact: make(map[string]bool, len(entries))}
+	for _, entry := range entries {
+		if strings.HasSuffix(entry, "*") {
+			d.prefixes = append(d.prefixes, strings.TrimSuffix(entry, "*"))
+		} else {
+			d.exact[entry] = true
+		}
+	}
+	return d
+}
+
+func (d dictionary) contains(word string) bool {
+	if d.exact[word] {
+		return true
+	}
+	for _, prefix := range d.prefixes {
+		if strings.HasPrefix(word, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ProfanityRule ищет нецензурную лексику на русском и казахском языках.
+type ProfanityRule struct {
+	ru dictionary
+	kk dictionary
+}
+
+func NewProfanityRule() *ProfanityRule {
+	return &ProfanityRule{
+		ru: newDictionary(profanityRU),
+		kk: newDictionary(profanityKK),
+	}
+}
+
+func (r *ProfanityRule) Name() string {
+	return "profanity"
+}
+
+func (r *ProfanityRule) Check(input Input) models.ModerationRuleResult {
+	var foundRU, foundKK bool
+	for _, word := range words(input.Text()) {
+		foundRU = foundRU || r.ru.contains(word)
+		foundKK = foundKK || r.kk.contains(word)
+	}
+
+	result := models.ModerationRuleResult{}
+	if foundRU {
+		result.Score += 5
+		result.Reasons = append(result.Reasons, models.ModerationReasonProfanityRU)
+	}
+	if foundKK {
+		result.Score += 5
+		result.Reasons = append(result.Reasons, models.ModerationReasonProfanityKK)
+	}
+	return result
+}
+
+var (
+	urlPattern       = regexp.MustCompile(`(?i)(?:https?://|www\.)\S+|\b[a-z0-9][a-z0-9-]*\.(?:kz|ru|com|net|org|io|me|info|biz|su)\b`)
+	messengerPattern = regexp.MustCompile(`(?i)(?:t\.me/|wa\.me/|(?:^|\s)@[a-z0-9_]{5,})`)
+)
+
+// ContactRule ищет ссылки и контакты: адреса почты, телефоны и аккаунты в мессенджерах.
+type ContactRule struct{}
+
+func NewContactRule() *ContactRule {
+	return &ContactRule{}
+}
+
+func (r *ContactRule) Name() string {
+	return "contacts"
+}
+
+func (r *ContactRule) Check(input Input) models.ModerationRuleResult {
+	text := input.Text()
+	result := models.ModerationRuleResult{}
+
+	if emailPattern.MatchString(text) {
+		result.Score += 3
+		result.Reasons = append(result.Reasons, models.ModerationReasonEmail)
+		text = emailPattern.ReplaceAllString(text, " ")
+	}
+	if messengerPattern.MatchString(text) {
+		result.Score += 3
+		result.Reasons = append(result.Reasons, models.ModerationReasonMessenger)
+		text = messengerPattern.ReplaceAllString(text, " ")
+	}
+	if urlPattern.MatchString(text) {
+		result.Score += 4
+		result.Reasons = append(result.Reasons, models.ModerationReasonLink)
+	}
+	if phonePattern.MatchString(text) {
+		result.Score += 3
+		result.Reasons = append(result.Reasons, models.ModerationReasonPhone)
+	}
+	return result
+}
+
+// DensityRule проверяет, что отзыв содержит достаточно осмысленного текста.
+type DensityRule struct {
+	minWords int
+}
+
+func NewDensityRule(minWords int) *DensityRule {
+	return &DensityRule{minWords: minWords}
+}
+
+func (r *DensityRule) Name() string {
+	return "density"
+}
+
+func (r *DensityRule) Check(input Input) models.ModerationRuleResult {
+	result := models.ModerationRuleResult{}
+	text := input.Pros + " " + input.Cons
+
+	unique := make(map[string]bool)
+	for _, word := range words(text) {
+		if len([]rune(word)) >= 3 {
+			unique[word] = true
+		}
+	}
+	if len(unique) < r.minWords {
+		result.Score += 3
+		result.Reasons = append(result.Reasons, models.ModerationReasonLowInformation)
+	}
+
+	var letters, visible int
+	for _, char := range text {
+		if unicode.IsSpace(char) {
+			continue
+		}
+		visible++
+		if unicode.IsLetter(char) {
+			letters++
+		}
+	}
+	if visible > 0 && float64(letters)/float64(visible) < 0.5 {
+		result.Score += 2
+		result.Reasons = append(result.Reasons, models.ModerationReasonLowLetterRatio)
+	}
+	return result
+}
+
+// CapsRule отмечает отзывы, набранные в основном заглавными буквами.
+type CapsRule struct{}
+
+func NewCapsRule() *CapsRule {
+	return &CapsRule{}
+}
+
+func (r *CapsRule) Name() string {
+	return "caps"
+}
+
+func (r *CapsRule) Check(input Input) models.ModerationRuleResult {
+	var letters, upper int
+	for _, char := range input.Pros + input.Cons {
+		if unicode.IsLetter(char) {
+			letters++
+			if unicode.IsUpper(char) {
+				upper++
+			}
+		}
+	}
+
+	result := models.ModerationRuleResult{}
+	if letters >= 20 && float64(upper)/float64(letters) > 0.7 {
+		result.Score += 2
+		result.Reasons = append(result.Reasons, models.ModerationReasonAllCaps)
+	}
+	return result
+}
+
+// RepetitionRule ищет длинные повторы одного символа и текст из одного и того же слова.
+type RepetitionRule struct{}
+
+func NewRepetitionRule() *RepetitionRule {
+	return &RepetitionRule{}
+}
+
+func (r *RepetitionRule) Name() string {
+	return "repetition"
+}
+
+func (r *RepetitionRule) Check(input Input) models.ModerationRuleResult {
+	text := input.Pros + "\n" + input.Cons
+	result := models.ModerationRuleResult{}
+
+	var previous rune
+	run := 0
+	for _, char := range text {
+		if char == previous && !unicode.IsSpace(char) && !unicode.IsDigit(char) {
+			run++
+		} else {
+			run = 1
+		}
+		previous = char
+		if run >= 5 {
+			result.Score += 1
+			result.Reasons = append(result.Reasons, models.ModerationReasonRepeatedChars)
+			break
+		}
+	}
+
+	counts := make(map[string]int)
+	total, top := 0, 0
+	for _, word := range words(text) {
+		if len([]rune(word)) < 3 {
+			continue
+		}
+		total++
+		counts[word]++
+		top = max(top, counts[word])
+	}
+	if total >= 10 && float64(top)/float64(total) > 0.3 {
+		result.Score += 2
+		result.Reasons = append(result.Reasons, models.ModerationReasonRepeatedWords)
+	}
+	return result
+}
+
+// DuplicateRule учитывает почти совпадение текста с другими отзывами.
+type DuplicateRule struct{}
+
+func NewDuplicateRule() *DuplicateRule {
+	return &DuplicateRule{}
+}
+
+func (r *DuplicateRule) Name() string {
+	return "duplicate"
+}
+
+func (r *DuplicateRule) Check(input Input) models.ModerationRuleResult {
+	result := models.ModerationRuleResult{}
+	if input.Suspicious {
+		result.Score += 2
+		result.Reasons = append(result.Reasons, models.ModerationReasonNearDuplicate)
+	}
+	return result
+}
+
+// TrustedAuthorRule снижает балл отзывов авторов, у которых достаточно одобренных отзывов
+// и нет отклоненных. 0 отключает правило.
+type TrustedAuthorRule struct {
+	minApproved int
+}
+
+func NewTrustedAuthorRule(minApproved int) *TrustedAuthorRule {
+	return &TrustedAuthorRule{minApproved: minApproved}
+}
+
+func (r *TrustedAuthorRule) Name() string {
+	return "trusted_author"
+}
+
+func (r *TrustedAuthorRule) Check(input Input) models.ModerationRuleResult {
+	result := models.ModerationRuleResult{}
+	if r.minApproved > 0 && input.Author.ApprovedReviews >= r.minApproved && input.Author.RejectedReviews == 0 {
+		result.Score -= 3
+		result.Reasons = append(result.Reasons, models.ModerationReasonTrustedAuthor)
+	}
+	return result
+}
@@ -0,0 +1,203 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"job_solition/internal/config"
+)
+
+// UserInfo - данные пользователя, полученные от провайдера и приведенные к общему виду.
+type UserInfo struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	FirstName     string
+	LastName      string
+}
+
+// Provider выполняет OAuth2 authorization code flow с PKCE у одного внешнего провайдера.
+type Provider struct {
+	cfg    config.OIDCProviderConfig
+	client *http.Client
+}
+
+func NewProvider(cfg config.OIDCProviderConfig, client *http.Client) *Provider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &Provider{
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+// AuthCodeURL возвращает адрес страницы входа провайдера.
+func (p *Provider) AuthCodeURL(state, codeChallenge string) string {
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", p.cfg.ClientID)
+	params.Set("redirect_uri", p.cfg.RedirectURL)
+	params.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	params.Set("state", state)
+	params.Set("code_challenge", codeChallenge)
+	params.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(p.cfg.AuthURL, "?") {
+		separator = "&"
+	}
+
+	return p.cfg.AuthURL + separator + params.Encode()
+}
+
+// Exchange обменивает код авторизации на access токен провайдера.
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("client_id", p.cfg.ClientID)
+	form.Set("client_secret", p.cfg.ClientSecret)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("ошибка при создании запроса токена: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var response struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error"`
+	}
+	if err := p.do(req, &response); err != nil {
+		return "", fmt.Errorf("ошибка при получении токена провайдера %s: %w", p.cfg.Name, err)
+	}
+
+	if response.AccessToken == "" {
+		return "", fmt.Errorf("провайдер %s не вернул access токен: %s", p.cfg.Name, response.Error)
+	}
+
+	return response.AccessToken, nil
+}
+
+// UserInfo запрашивает профиль пользователя и сопоставляет поля согласно настройкам провайдера.
+func (p *Provider) UserInfo(ctx context.Context, accessToken string) (*UserInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.UserInfoURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при создании запроса профиля: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	claims := map[string]interface{}{}
+	if err := p.do(req, &claims); err != nil {
+		return nil, fmt.Errorf("ошибка при получении профиля у провайдера %s: %w", p.cfg.Name, err)
+	}
+
+	info := &UserInfo{
+		Subject:       stringClaim(claims, p.cfg.SubjectClaim),
+		Email:         strings.ToLower(stringClaim(claims, p.cfg.EmailClaim)),
+		EmailVerified: boolClaim(claims, p.cfg.EmailVerifiedClaim),
+		FirstName:     stringClaim(claims, p.cfg.FirstNameClaim),
+		LastName:      stringClaim(claims, p.cfg.LastNameClaim),
+	}
+
+	if info.Subject == "" {
+		return nil, fmt.Errorf("провайдер %s не вернул идентификатор пользователя", p.cfg.Name)
+	}
+
+	return info, nil
+}
+
+func (p *Provider) do(req *http.Request, out interface{}) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("неожиданный статус ответа %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return json.Unmarshal(body, out)
+}
+
+func stringClaim(claims map[string]interface{}, name string) string {
+	switch value := claims[name].(type) {
+	case string:
+		return value
+	case float64:
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return ""
+	}
+}
+
+func boolClaim(claims map[string]interface{}, name string) bool {
+	switch value := claims[name].(type) {
+	case bool:
+		return value
+	case string:
+		return value == "true"
+	default:
+		return false
+	}
+}
+
+// Registry - настроенные провайдеры по имени.
+type Registry map[string]*Provider
+
+func NewRegistry(cfg config.OIDCConfig) Registry {
+	registry := Registry{}
+	for _, providerCfg := range cfg.Providers {
+		registry[providerCfg.Name] = NewProvider(providerCfg, nil)
+	}
+	return registry
+}
+
+func (r Registry) Names() []string {
+	names := make([]string, 0, len(r))
+	for name := range r {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewPKCE создает пару code_verifier / code_challenge (метод S256).
+func NewPKCE() (verifier, challenge string, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("ошибка при генерации PKCE: %w", err)
+	}
+
+	verifier = base64.RawURLEncoding.EncodeToString(raw)
+	sum := sha256.Sum256([]byte(verifier))
+	challenge = base64.RawURLEncoding.EncodeToString(sum[:])
+
+	return verifier, challenge, nil
+}
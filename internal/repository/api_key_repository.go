@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"job_solition/internal/db"
+	"job_solition/internal/models"
+)
+
+type APIKeyRepositoryImpl struct {
+	postgres *db.PostgreSQL
+}
+
+func NewAPIKeyRepository(postgres *db.PostgreSQL) APIKeyRepository {
+	return &APIKeyRepositoryImpl{
+		postgres: postgres,
+	}
+}
+
+func (r *APIKeyRepositoryImpl) Create(ctx context.Context, key *models.APIKey) (int, error) {
+	query := `
+		INSERT INTO api_keys (name, prefix, key_hash, scopes, daily_quota, expires_at, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+
+	var id int
+	err := r.postgres.QueryRowContext(
+		ctx,
+		query,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		key.Scopes,
+		key.DailyQuota,
+		key.ExpiresAt,
+		key.CreatedBy,
+		key.CreatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при создании API ключа: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *APIKeyRepositoryImpl) GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	query := `
+		SELECT id, name, prefix, key_hash, scopes, daily_quota, expires_at, last_used_at, revoked_at, created_by, created_at
+		FROM api_keys
+		WHERE key_hash = $1
+	`
+
+	var key models.APIKey
+	err := r.postgres.GetContext(ctx, &key, query, keyHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("API ключ не найден")
+		}
+		return nil, fmt.Errorf("ошибка при получении API ключа: %w", err)
+	}
+
+	return &key, nil
+}
+
+func (r *APIKeyRepositoryImpl) GetAll(ctx context.Context) ([]models.APIKey, error) {
+	query := `
+		SELECT id, name, prefix, key_hash, scopes, daily_quota, expires_at, last_used_at, revoked_at, created_by, created_at
+		FROM api_keys
+		ORDER BY created_at DESC
+	`
+
+	keys := []models.APIKey{}
+	if err := r.postgres.SelectContext(ctx, &keys, query); err != nil {
+		return nil, fmt.Errorf("ошибка при получении API ключей: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Revoke отзывает ключ. Возвращает false, если ключа нет или он уже отозван.
+func (r *APIKeyRepositoryImpl) Revoke(ctx context.Context, id int) (bool, error) {
+	query := `
+		UPDATE api_keys
+		SET revoked_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+
+	result, err := r.postgres.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("ошибка при отзыве API ключа: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ошибка при получении количества затронутых строк: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// RecordUsage учитывает запрос по ключу и возвращает число запросов за сутки, включая текущий.
+func (r *APIKeyRepositoryImpl) RecordUsage(ctx context.Context, id int, now time.Time) (int, error) {
+	tx, err := r.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при начале транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO api_key_usage (api_key_id, day, requests)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (api_key_id, day) DO UPDATE SET requests = api_key_usage.requests + 1
+		RETURNING requests
+	`
+
+	var requests int
+	if err = tx.QueryRowContext(ctx, query, id, now.UTC().Format("2006-01-02")).Scan(&requests); err != nil {
+		return 0, fmt.Errorf("ошибка при учете использования API ключа: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, "UPDATE api_keys SET last_used_at = $1 WHERE id = $2", now, id); err != nil {
+		return 0, fmt.Errorf("ошибка при обновлении времени использования API ключа: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("ошибка при коммите транзакции: %w", err)
+	}
+
+	return requests, nil
+}
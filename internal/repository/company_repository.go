@@ -9,6 +9,8 @@ import (
 
 	"job_solition/internal/db"
 	"job_solition/internal/models"
+
+	"github.com/jmoiron/sqlx"
 )
 
 type CompanyRepositoryImpl struct {
@@ -370,28 +372,41 @@ func (r *CompanyRepositoryImpl) UpdateRating(ctx context.Context, companyID int)
 	}
 	defer tx.Rollback()
 
+	if err = updateCompanyRating(ctx, tx, companyID); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка при коммите транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// updateCompanyRating пересчитывает рейтинг компании и ее рейтинги по категориям в транзакции tx.
+func updateCompanyRating(ctx context.Context, tx *sqlx.Tx, companyID int) error {
 	updateRatingQuery := `
 		UPDATE companies
 		SET average_rating = COALESCE((
 			SELECT AVG(rating)
 			FROM reviews
-			WHERE company_id = $1 AND status = 'approved'
+			WHERE company_id = $1 AND status = 'approved' AND NOT is_hidden
 		), 0),
 		reviews_count = (
 			SELECT COUNT(*)
 			FROM reviews
-			WHERE company_id = $1 AND status = 'approved'
+			WHERE company_id = $1 AND status = 'approved' AND NOT is_hidden
 		),
 		recommendation_percentage = COALESCE((
 			SELECT (SUM(CASE WHEN is_recommended THEN 1 ELSE 0 END) * 100.0 / COUNT(*))
 			FROM reviews
-			WHERE company_id = $1 AND status = 'approved'
+			WHERE company_id = $1 AND status = 'approved' AND NOT is_hidden
 		), 0),
 		updated_at = NOW()
 		WHERE id = $1
 	`
 
-	_, err = tx.Exec(updateRatingQuery, companyID)
+	_, err := tx.ExecContext(ctx, updateRatingQuery, companyID)
 	if err != nil {
 		return fmt.Errorf("ошибка при обновлении рейтинга компании: %w", err)
 	}
@@ -400,7 +415,7 @@ func (r *CompanyRepositoryImpl) UpdateRating(ctx context.Context, companyID int)
 		DELETE FROM company_category_ratings
 		WHERE company_id = $1
 	`
-	_, err = tx.Exec(deleteRatingsQuery, companyID)
+	_, err = tx.ExecContext(ctx, deleteRatingsQuery, companyID)
 	if err != nil {
 		return fmt.Errorf("ошибка при удалении рейтингов компании по категориям: %w", err)
 	}
@@ -410,18 +425,14 @@ func (r *CompanyRepositoryImpl) UpdateRating(ctx context.Context, companyID int)
 		SELECT r.company_id, rcr.category_id, AVG(rcr.rating)
 		FROM reviews r
 		JOIN review_category_ratings rcr ON r.id = rcr.review_id
-		WHERE r.company_id = $1 AND r.status = 'approved'
+		WHERE r.company_id = $1 AND r.status = 'approved' AND NOT r.is_hidden
 		GROUP BY r.company_id, rcr.category_id
 	`
-	_, err = tx.Exec(insertRatingsQuery, companyID)
+	_, err = tx.ExecContext(ctx, insertRatingsQuery, companyID)
 	if err != nil {
 		return fmt.Errorf("ошибка при обновлении рейтингов компании по категориям: %w", err)
 	}
 
-	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("ошибка при коммите транзакции: %w", err)
-	}
-
 	return nil
 }
 
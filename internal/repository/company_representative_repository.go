@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"job_solition/internal/db"
+	"job_solition/internal/models"
+
+	"github.com/lib/pq"
+)
+
+const companyRepresentativeColumns = `id, user_id, company_id, status, corporate_email, code_hash, code_expires_at, code_attempts,
+		       email_confirmed_at, permissions, comment, review_comment, verified_by, created_at, verified_at, revoked_at`
+
+type CompanyRepresentativeRepositoryImpl struct {
+	postgres *db.PostgreSQL
+}
+
+func NewCompanyRepresentativeRepository(postgres *db.PostgreSQL) CompanyRepresentativeRepository {
+	return &CompanyRepresentativeRepositoryImpl{
+		postgres: postgres,
+	}
+}
+
+// Grant делает пользователя подтвержденным представителем компании без заявки. Отозванные ранее
+// полномочия восстанавливаются.
+func (r *CompanyRepresentativeRepositoryImpl) Grant(ctx context.Context, representative *models.CompanyRepresentative) (int, error) {
+	query := `
+		INSERT INTO company_representatives (user_id, company_id, status, permissions, verified_by, created_at, verified_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id, company_id) DO UPDATE
+		SET status = EXCLUDED.status, permissions = EXCLUDED.permissions, verified_by = EXCLUDED.verified_by,
+		    verified_at = EXCLUDED.verified_at, code_hash = NULL, code_expires_at = NULL, revoked_at = NULL
+		RETURNING ` + companyRepresentativeColumns
+
+	err := r.postgres.GetContext(
+		ctx,
+		representative,
+		query,
+		representative.UserID,
+		representative.CompanyID,
+		models.CompanyRepresentativeStatusVerified,
+		representative.Permissions,
+		representative.VerifiedBy,
+		representative.CreatedAt,
+		representative.VerifiedAt,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при назначении представителя компании: %w", err)
+	}
+
+	return representative.ID, nil
+}
+
+// SubmitClaim сохраняет заявку пользователя на представительство компании. Неподтвержденная,
+// отклоненная или отозванная заявка того же пользователя заменяется новой.
+func (r *CompanyRepresentativeRepositoryImpl) SubmitClaim(ctx context.Context, claim *models.CompanyRepresentative) error {
+	query := `
+		INSERT INTO company_representatives (user_id, company_id, status, corporate_email, code_hash, code_expires_at, comment, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id, company_id) DO UPDATE
+		SET status = EXCLUDED.status, corporate_email = EXCLUDED.corporate_email, code_hash = EXCLUDED.code_hash,
+		    code_expires_at = EXCLUDED.code_expires_at, code_attempts = 0, email_confirmed_at = NULL,
+		    comment = EXCLUDED.comment, review_comment = NULL, verified_by = NULL, verified_at = NULL,
+		    revoked_at = NULL, created_at = EXCLUDED.created_at
+		WHERE company_representatives.status IN ($9, $10, $11)
+		RETURNING ` + companyRepresentativeColumns
+
+	err := r.postgres.GetContext(
+		ctx,
+		claim,
+		query,
+		claim.UserID,
+		claim.CompanyID,
+		models.CompanyRepresentativeStatusUnconfirmed,
+		claim.CorporateEmail,
+		claim.CodeHash,
+		claim.CodeExpiresAt,
+		claim.Comment,
+		claim.CreatedAt,
+		models.CompanyRepresentativeStatusUnconfirmed,
+		models.CompanyRepresentativeStatusRejected,
+		models.CompanyRepresentativeStatusRevoked,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("заявка уже подана")
+		}
+		return fmt.Errorf("ошибка при сохранении заявки представителя компании: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CompanyRepresentativeRepositoryImpl) GetByID(ctx context.Context, id int) (*models.CompanyRepresentative, error) {
+	query := `SELECT ` + companyRepresentativeColumns + ` FROM company_representatives WHERE id = $1`
+
+	var representative models.CompanyRepresentative
+	if err := r.postgres.GetContext(ctx, &representative, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("заявка не найдена")
+		}
+		return nil, fmt.Errorf("ошибка при получении заявки представителя компании: %w", err)
+	}
+
+	return &representative, nil
+}
+
+func (r *CompanyRepresentativeRepositoryImpl) GetByUserAndCompany(ctx context.Context, userID, companyID int) (*models.CompanyRepresentative, error) {
+	query := `SELECT ` + companyRepresentativeColumns + ` FROM company_representatives WHERE user_id = $1 AND company_id = $2`
+
+	var representative models.CompanyRepresentative
+	if err := r.postgres.GetContext(ctx, &representative, query, userID, companyID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("заявка не найдена")
+		}
+		return nil, fmt.Errorf("ошибка при получении заявки представителя компании: %w", err)
+	}
+
+	return &representative, nil
+}
+
+func (r *CompanyRepresentativeRepositoryImpl) GetByUser(ctx context.Context, userID int) ([]models.CompanyRepresentative, error) {
+	query := `SELECT ` + companyRepresentativeColumns + ` FROM company_representatives WHERE user_id = $1 ORDER BY created_at DESC`
+
+	representatives := []models.CompanyRepresentative{}
+	if err := r.postgres.SelectContext(ctx, &representatives, query, userID); err != nil {
+		return nil, fmt.Errorf("ошибка при получении заявок представителя компании: %w", err)
+	}
+
+	return representatives, nil
+}
+
+func (r *CompanyRepresentativeRepositoryImpl) GetByCompany(ctx context.Context, companyID int) ([]models.CompanyRepresentative, error) {
+	query := `SELECT ` + companyRepresentativeColumns + ` FROM company_representatives WHERE company_id = $1 ORDER BY created_at DESC`
+
+	representatives := []models.CompanyRepresentative{}
+	if err := r.postgres.SelectContext(ctx, &representatives, query, companyID); err != nil {
+		return nil, fmt.Errorf("ошибка при получении представителей компании: %w", err)
+	}
+
+	return representatives, nil
+}
+
+func (r *CompanyRepresentativeRepositoryImpl) GetAll(ctx context.Context, filter models.CompanyClaimFilter) ([]models.CompanyRepresentative, int, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Status != nil {
+		args = append(args, *filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.CompanyID != nil {
+		args = append(args, *filter.CompanyID)
+		conditions = append(conditions, fmt.Sprintf("company_id = $%d", len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.postgres.GetContext(ctx, &total, "SELECT COUNT(*) FROM company_representatives"+where, args...); err != nil {
+		return nil, 0, fmt.Errorf("ошибка при подсчете заявок представителей компаний: %w", err)
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	args = append(args, filter.Limit, offset)
+	query := fmt.Sprintf(`SELECT `+companyRepresentativeColumns+`
+		FROM company_representatives%s
+		ORDER BY created_at ASC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)-1, len(args))
+
+	representatives := []models.CompanyRepresentative{}
+	if err := r.postgres.SelectContext(ctx, &representatives, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("ошибка при получении заявок представителей компаний: %w", err)
+	}
+
+	return representatives, total, nil
+}
+
+// RecordCodeAttempt увеличивает счетчик неудачных попыток ввода кода и возвращает его новое значение.
+func (r *CompanyRepresentativeRepositoryImpl) RecordCodeAttempt(ctx context.Context, id int) (int, error) {
+	query := `
+		UPDATE company_representatives
+		SET code_attempts = code_attempts + 1
+		WHERE id = $1 AND status = $2
+		RETURNING code_attempts
+	`
+
+	var attempts int
+	if err := r.postgres.GetContext(ctx, &attempts, query, id, models.CompanyRepresentativeStatusUnconfirmed); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("заявка не ожидает подтверждения email")
+		}
+		return 0, fmt.Errorf("ошибка при обновлении заявки представителя компании: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// ConfirmEmail отмечает корпоративный email заявки подтвержденным и передает заявку администраторам.
+// Код проверяется в том же запросе: заявка подтверждается, только если она ждет подтверждения,
+// хеш кода совпадает, код не истек и неудачных попыток было меньше maxAttempts.
+func (r *CompanyRepresentativeRepositoryImpl) ConfirmEmail(ctx context.Context, id int, codeHash string, maxAttempts int) (bool, error) {
+	query := `
+		UPDATE company_representatives
+		SET status = $1, email_confirmed_at = NOW(), code_hash = NULL, code_expires_at = NULL
+		WHERE id = $2 AND status = $3 AND code_hash = $4 AND code_expires_at > NOW() AND code_attempts < $5
+	`
+
+	result, err := r.postgres.ExecContext(ctx, query, models.CompanyRepresentativeStatusPending, id, models.CompanyRepresentativeStatusUnconfirmed, codeHash, maxAttempts)
+	if err != nil {
+		return false, fmt.Errorf("ошибка при подтверждении заявки представителя компании: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ошибка при получении количества затронутых строк: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Approve одобряет заявку с подтвержденным email и выдает представителю права permissions.
+func (r *CompanyRepresentativeRepositoryImpl) Approve(ctx context.Context, id, adminID int, permissions pq.StringArray, comment string) (*models.CompanyRepresentative, error) {
+	query := `
+		UPDATE company_representatives
+		SET status = $1, permissions = $2, verified_by = $3, verified_at = NOW(), review_comment = $4
+		WHERE id = $5 AND status = $6
+		RETURNING ` + companyRepresentativeColumns
+
+	return r.review(ctx, query, models.CompanyRepresentativeStatusVerified, permissions, adminID, comment, id)
+}
+
+func (r *CompanyRepresentativeRepositoryImpl) Reject(ctx context.Context, id, adminID int, comment string) (*models.CompanyRepresentative, error) {
+	query := `
+		UPDATE company_representatives
+		SET status = $1, permissions = $2, verified_by = $3, review_comment = $4
+		WHERE id = $5 AND status = $6
+		RETURNING ` + companyRepresentativeColumns
+
+	return r.review(ctx, query, models.CompanyRepresentativeStatusRejected, pq.StringArray{}, adminID, comment, id)
+}
+
+func (r *CompanyRepresentativeRepositoryImpl) review(ctx context.Context, query string, status models.CompanyRepresentativeStatus, permissions pq.StringArray, adminID int, comment string, id int) (*models.CompanyRepresentative, error) {
+	var reviewComment *string
+	if comment != "" {
+		reviewComment = &comment
+	}
+
+	var representative models.CompanyRepresentative
+	err := r.postgres.GetContext(ctx, &representative, query, status, permissions, adminID, reviewComment, id, models.CompanyRepresentativeStatusPending)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("заявка не ожидает рассмотрения")
+		}
+		return nil, fmt.Errorf("ошибка при рассмотрении заявки представителя компании: %w", err)
+	}
+
+	return &representative, nil
+}
+
+func (r *CompanyRepresentativeRepositoryImpl) Revoke(ctx context.Context, companyID, userID int) (bool, error) {
+	query := `
+		UPDATE company_representatives
+		SET status = $1, revoked_at = NOW()
+		WHERE company_id = $2 AND user_id = $3 AND status = $4
+	`
+
+	result, err := r.postgres.ExecContext(ctx, query, models.CompanyRepresentativeStatusRevoked, companyID, userID, models.CompanyRepresentativeStatusVerified)
+	if err != nil {
+		return false, fmt.Errorf("ошибка при отзыве полномочий представителя компании: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ошибка при получении количества затронутых строк: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// HasPermission сообщает, является ли пользователь подтвержденным представителем компании с правом permission.
+func (r *CompanyRepresentativeRepositoryImpl) HasPermission(ctx context.Context, userID, companyID int, permission models.RepresentativePermission) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM company_representatives
+			WHERE user_id = $1 AND company_id = $2 AND status = $3 AND $4 = ANY(permissions)
+		)
+	`
+
+	var allowed bool
+	if err := r.postgres.GetContext(ctx, &allowed, query, userID, companyID, models.CompanyRepresentativeStatusVerified, string(permission)); err != nil {
+		return false, fmt.Errorf("ошибка при проверке прав представителя компании: %w", err)
+	}
+
+	return allowed, nil
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"job_solition/internal/db"
+	"job_solition/internal/models"
+)
+
+type DataExportRepositoryImpl struct {
+	postgres *db.PostgreSQL
+}
+
+func NewDataExportRepository(postgres *db.PostgreSQL) DataExportRepository {
+	return &DataExportRepositoryImpl{
+		postgres: postgres,
+	}
+}
+
+// Create сохраняет новый запрос на выгрузку. Заодно удаляются архивы с истекшими ссылками.
+// Если для пользователя уже собирается архив, запрос не сохраняется.
+func (r *DataExportRepositoryImpl) Create(ctx context.Context, export *models.DataExport) (int, error) {
+	if err := r.DeleteExpired(ctx); err != nil {
+		return 0, err
+	}
+
+	query := `
+		INSERT INTO data_exports (user_id, requested_by, status, token, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) WHERE status = 'pending' DO NOTHING
+		RETURNING id
+	`
+
+	var id int
+	err := r.postgres.GetContext(
+		ctx,
+		&id,
+		query,
+		export.UserID,
+		export.RequestedBy,
+		export.Status,
+		export.Token,
+		export.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("выгрузка данных уже готовится")
+		}
+		return 0, fmt.Errorf("ошибка при создании выгрузки данных: %w", err)
+	}
+
+	export.ID = id
+	return id, nil
+}
+
+func (r *DataExportRepositoryImpl) GetByUser(ctx context.Context, userID int) ([]models.DataExport, error) {
+	query := `
+		SELECT id, user_id, requested_by, status, token, error, expires_at, created_at, completed_at
+		FROM data_exports
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	exports := []models.DataExport{}
+	if err := r.postgres.SelectContext(ctx, &exports, query, userID); err != nil {
+		return nil, fmt.Errorf("ошибка при получении выгрузок данных: %w", err)
+	}
+
+	return exports, nil
+}
+
+func (r *DataExportRepositoryImpl) GetByToken(ctx context.Context, token string) (*models.DataExport, error) {
+	query := `
+		SELECT id, user_id, requested_by, status, token, archive, error, expires_at, created_at, completed_at
+		FROM data_exports
+		WHERE token = $1
+	`
+
+	var export models.DataExport
+	if err := r.postgres.GetContext(ctx, &export, query, token); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("выгрузка не найдена")
+		}
+		return nil, fmt.Errorf("ошибка при получении выгрузки данных: %w", err)
+	}
+
+	return &export, nil
+}
+
+// FailStale отмечает неудавшимися архивы, которые собираются с момента раньше startedBefore.
+// Такая сборка уже не завершится, например, если сервер был перезапущен во время нее.
+func (r *DataExportRepositoryImpl) FailStale(ctx context.Context, startedBefore time.Time) error {
+	query := `
+		UPDATE data_exports
+		SET status = $1, error = $2, completed_at = NOW()
+		WHERE status = $3 AND created_at < $4
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, models.DataExportStatusFailed, "сборка архива не завершилась", models.DataExportStatusPending, startedBefore)
+	if err != nil {
+		return fmt.Errorf("ошибка при обновлении статуса зависших выгрузок: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DataExportRepositoryImpl) MarkReady(ctx context.Context, id int, archive []byte, expiresAt time.Time) error {
+	query := `
+		UPDATE data_exports
+		SET status = $1, archive = $2, expires_at = $3, completed_at = NOW()
+		WHERE id = $4 AND status = $5
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, models.DataExportStatusReady, archive, expiresAt, id, models.DataExportStatusPending)
+	if err != nil {
+		return fmt.Errorf("ошибка при сохранении архива выгрузки: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DataExportRepositoryImpl) MarkFailed(ctx context.Context, id int, reason string) error {
+	query := `
+		UPDATE data_exports
+		SET status = $1, error = $2, completed_at = NOW()
+		WHERE id = $3 AND status = $4
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, models.DataExportStatusFailed, reason, id, models.DataExportStatusPending)
+	if err != nil {
+		return fmt.Errorf("ошибка при обновлении статуса выгрузки: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DataExportRepositoryImpl) DeleteExpired(ctx context.Context) error {
+	_, err := r.postgres.ExecContext(ctx, "DELETE FROM data_exports WHERE expires_at < NOW()")
+	if err != nil {
+		return fmt.Errorf("ошибка при удалении устаревших выгрузок данных: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"job_solition/internal/db"
+	"job_solition/internal/models"
+)
+
+type EmailChangeRepositoryImpl struct {
+	postgres *db.PostgreSQL
+}
+
+func NewEmailChangeRepository(postgres *db.PostgreSQL) EmailChangeRepository {
+	return &EmailChangeRepositoryImpl{
+		postgres: postgres,
+	}
+}
+
+// Create сохраняет новый запрос на смену email. Прежний незавершенный запрос пользователя отменяется.
+func (r *EmailChangeRepositoryImpl) Create(ctx context.Context, request *models.EmailChangeRequest) (int, error) {
+	if err := r.DeleteByUserID(ctx, request.UserID); err != nil {
+		return 0, fmt.Errorf("ошибка при удалении существующих запросов: %w", err)
+	}
+
+	query := `
+		INSERT INTO email_change_requests (user_id, new_email, token, cancel_token, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	var id int
+	err := r.postgres.GetContext(
+		ctx,
+		&id,
+		query,
+		request.UserID,
+		request.NewEmail,
+		request.Token,
+		request.CancelToken,
+		request.ExpiresAt,
+		request.CreatedAt,
+	)
+
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при создании запроса на смену email: %w", err)
+	}
+
+	return id, nil
+}
+
+// ConsumeByToken возвращает и сразу удаляет запрос по токену подтверждения.
+func (r *EmailChangeRepositoryImpl) ConsumeByToken(ctx context.Context, token string) (*models.EmailChangeRequest, error) {
+	return r.consume(ctx, "token", token)
+}
+
+// ConsumeByCancelToken возвращает и сразу удаляет запрос по токену отмены.
+func (r *EmailChangeRepositoryImpl) ConsumeByCancelToken(ctx context.Context, cancelToken string) (*models.EmailChangeRequest, error) {
+	return r.consume(ctx, "cancel_token", cancelToken)
+}
+
+func (r *EmailChangeRepositoryImpl) consume(ctx context.Context, column, value string) (*models.EmailChangeRequest, error) {
+	query := fmt.Sprintf(`
+		DELETE FROM email_change_requests
+		WHERE %s = $1
+		RETURNING id, user_id, new_email, token, cancel_token, expires_at, created_at
+	`, column)
+
+	var request models.EmailChangeRequest
+	err := r.postgres.GetContext(ctx, &request, query, value)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("запрос на смену email не найден")
+		}
+		return nil, fmt.Errorf("ошибка при получении запроса на смену email: %w", err)
+	}
+
+	return &request, nil
+}
+
+func (r *EmailChangeRepositoryImpl) DeleteByUserID(ctx context.Context, userID int) error {
+	query := `
+		DELETE FROM email_change_requests
+		WHERE user_id = $1
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("ошибка при удалении запросов на смену email: %w", err)
+	}
+
+	return nil
+}
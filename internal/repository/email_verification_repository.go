@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"job_solition/internal/db"
+	"job_solition/internal/models"
+)
+
+type EmailVerificationRepositoryImpl struct {
+	postgres *db.PostgreSQL
+}
+
+func NewEmailVerificationRepository(postgres *db.PostgreSQL) EmailVerificationRepository {
+	return &EmailVerificationRepositoryImpl{
+		postgres: postgres,
+	}
+}
+
+func (r *EmailVerificationRepositoryImpl) Create(ctx context.Context, token *models.EmailVerificationToken) (int, error) {
+	if err := r.DeleteByUserID(ctx, token.UserID); err != nil {
+		return 0, fmt.Errorf("ошибка при удалении существующих токенов: %w", err)
+	}
+
+	query := `
+		INSERT INTO email_verification_tokens (user_id, token, expires_at, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	var id int
+	err := r.postgres.GetContext(
+		ctx,
+		&id,
+		query,
+		token.UserID,
+		token.Token,
+		token.ExpiresAt,
+		token.CreatedAt,
+	)
+
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при создании токена подтверждения email: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *EmailVerificationRepositoryImpl) GetByToken(ctx context.Context, token string) (*models.EmailVerificationToken, error) {
+	query := `
+		SELECT id, user_id, token, expires_at, created_at
+		FROM email_verification_tokens
+		WHERE token = $1
+	`
+
+	var verificationToken models.EmailVerificationToken
+	err := r.postgres.GetContext(ctx, &verificationToken, query, token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("токен подтверждения email не найден")
+		}
+		return nil, fmt.Errorf("ошибка при получении токена подтверждения email: %w", err)
+	}
+
+	return &verificationToken, nil
+}
+
+func (r *EmailVerificationRepositoryImpl) DeleteByUserID(ctx context.Context, userID int) error {
+	query := `
+		DELETE FROM email_verification_tokens
+		WHERE user_id = $1
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("ошибка при удалении токенов подтверждения email пользователя: %w", err)
+	}
+
+	return nil
+}
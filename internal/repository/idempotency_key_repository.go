@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"job_solition/internal/db"
+	"job_solition/internal/models"
+)
+
+const idempotencyKeyColumns = `id, user_id, key, request_hash, status_code, response_body, created_at, completed_at`
+
+type IdempotencyKeyRepositoryImpl struct {
+	postgres *db.PostgreSQL
+}
+
+func NewIdempotencyKeyRepository(postgres *db.PostgreSQL) IdempotencyKeyRepository {
+	return &IdempotencyKeyRepositoryImpl{
+		postgres: postgres,
+	}
+}
+
+// Reserve занимает ключ идемпотентности за запросом. Запись с тем же ключом, созданная раньше
+// expiresBefore, считается истекшей и перезаписывается. Если ключ уже занят, возвращает
+// существующую запись и false.
+func (r *IdempotencyKeyRepositoryImpl) Reserve(ctx context.Context, key *models.IdempotencyKey, expiresBefore time.Time) (*models.IdempotencyKey, bool, error) {
+	query := `
+		INSERT INTO idempotency_keys (user_id, key, request_hash, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, status_code = NULL, response_body = NULL,
+		    created_at = EXCLUDED.created_at, completed_at = NULL
+		WHERE idempotency_keys.created_at < $5
+		RETURNING ` + idempotencyKeyColumns
+
+	var record models.IdempotencyKey
+	created := true
+	err := r.postgres.GetContext(ctx, &record, query, key.UserID, key.Key, key.RequestHash, key.CreatedAt, expiresBefore)
+	if errors.Is(err, sql.ErrNoRows) {
+		created = false
+		query = `SELECT ` + idempotencyKeyColumns + ` FROM idempotency_keys WHERE user_id = $1 AND key = $2`
+		err = r.postgres.GetContext(ctx, &record, query, key.UserID, key.Key)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("ошибка при сохранении ключа идемпотентности: %w", err)
+	}
+
+	return &record, created, nil
+}
+
+// Complete сохраняет ответ на запрос, чтобы отдавать его при повторах с тем же ключом.
+func (r *IdempotencyKeyRepositoryImpl) Complete(ctx context.Context, id, statusCode int, body []byte) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status_code = $1, response_body = $2, completed_at = NOW()
+		WHERE id = $3
+	`
+
+	if _, err := r.postgres.ExecContext(ctx, query, statusCode, body, id); err != nil {
+		return fmt.Errorf("ошибка при сохранении ответа для ключа идемпотентности: %w", err)
+	}
+
+	return nil
+}
+
+// Delete освобождает ключ, например после внутренней ошибки, чтобы запрос можно было повторить.
+func (r *IdempotencyKeyRepositoryImpl) Delete(ctx context.Context, id int) error {
+	if _, err := r.postgres.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE id = $1", id); err != nil {
+		return fmt.Errorf("ошибка при удалении ключа идемпотентности: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpired удаляет ключи, созданные раньше before. Возвращает количество удаленных ключей.
+func (r *IdempotencyKeyRepositoryImpl) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.postgres.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE created_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при удалении устаревших ключей идемпотентности: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при получении количества затронутых строк: %w", err)
+	}
+
+	return deleted, nil
+}
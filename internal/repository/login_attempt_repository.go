@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"job_solition/internal/db"
+	"job_solition/internal/models"
+)
+
+type LoginAttemptRepositoryImpl struct {
+	postgres *db.PostgreSQL
+}
+
+func NewLoginAttemptRepository(postgres *db.PostgreSQL) LoginAttemptRepository {
+	return &LoginAttemptRepositoryImpl{
+		postgres: postgres,
+	}
+}
+
+func (r *LoginAttemptRepositoryImpl) Create(ctx context.Context, attempt *models.LoginAttempt) (int, error) {
+	query := `
+		INSERT INTO login_attempts (user_id, email, ip_address, user_agent, success, failure_reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	var id int
+	err := r.postgres.GetContext(
+		ctx,
+		&id,
+		query,
+		attempt.UserID,
+		attempt.Email,
+		attempt.IPAddress,
+		attempt.UserAgent,
+		attempt.Success,
+		attempt.FailureReason,
+		attempt.CreatedAt,
+	)
+
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при записи попытки входа: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetFailureStatsByEmail считает неудачные попытки входа в аккаунт после since и после последнего успешного входа.
+func (r *LoginAttemptRepositoryImpl) GetFailureStatsByEmail(ctx context.Context, email string, since time.Time) (*models.LoginFailureStats, error) {
+	query := `
+		SELECT COUNT(*) AS count, MAX(created_at) AS last_at
+		FROM login_attempts
+		WHERE email = $1 AND success = FALSE AND created_at > $2
+		  AND created_at > COALESCE(
+		      (SELECT MAX(created_at) FROM login_attempts WHERE email = $1 AND success = TRUE),
+		      '-infinity'::timestamp
+		  )
+	`
+
+	var stats models.LoginFailureStats
+	err := r.postgres.GetContext(ctx, &stats, query, models.NormalizeEmail(email), since)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при подсчете неудачных попыток входа: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// GetFailureStatsByIP считает неудачные попытки входа с IP-адреса после since и после последнего успешного входа с него.
+func (r *LoginAttemptRepositoryImpl) GetFailureStatsByIP(ctx context.Context, ipAddress string, since time.Time) (*models.LoginFailureStats, error) {
+	query := `
+		SELECT COUNT(*) AS count, MAX(created_at) AS last_at
+		FROM login_attempts
+		WHERE ip_address = $1 AND success = FALSE AND created_at > $2
+		  AND created_at > COALESCE(
+		      (SELECT MAX(created_at) FROM login_attempts WHERE ip_address = $1 AND success = TRUE),
+		      '-infinity'::timestamp
+		  )
+	`
+
+	var stats models.LoginFailureStats
+	err := r.postgres.GetContext(ctx, &stats, query, ipAddress, since)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при подсчете неудачных попыток входа: %w", err)
+	}
+
+	return &stats, nil
+}
+
+func (r *LoginAttemptRepositoryImpl) GetByUser(ctx context.Context, userID, page, limit int) ([]models.LoginAttempt, int, error) {
+	var total int
+	countQuery := "SELECT COUNT(*) FROM login_attempts WHERE user_id = $1"
+	if err := r.postgres.GetContext(ctx, &total, countQuery, userID); err != nil {
+		return nil, 0, fmt.Errorf("ошибка при подсчете попыток входа: %w", err)
+	}
+
+	offset := (page - 1) * limit
+
+	query := `
+		SELECT id, user_id, email, ip_address, COALESCE(user_agent, '') AS user_agent, success, failure_reason, created_at
+		FROM login_attempts
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	attempts := []models.LoginAttempt{}
+	err := r.postgres.SelectContext(ctx, &attempts, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка при получении попыток входа: %w", err)
+	}
+
+	return attempts, total, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"job_solition/internal/db"
+	"job_solition/internal/models"
+)
+
+type MagicLinkRepositoryImpl struct {
+	postgres *db.PostgreSQL
+}
+
+func NewMagicLinkRepository(postgres *db.PostgreSQL) MagicLinkRepository {
+	return &MagicLinkRepositoryImpl{
+		postgres: postgres,
+	}
+}
+
+// Create сохраняет новый токен входа. Ранее выданные пользователю ссылки перестают действовать.
+func (r *MagicLinkRepositoryImpl) Create(ctx context.Context, token *models.MagicLinkToken) (int, error) {
+	if err := r.DeleteByUserID(ctx, token.UserID); err != nil {
+		return 0, fmt.Errorf("ошибка при удалении существующих токенов: %w", err)
+	}
+
+	query := `
+		INSERT INTO magic_link_tokens (user_id, token, expires_at, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	var id int
+	err := r.postgres.GetContext(
+		ctx,
+		&id,
+		query,
+		token.UserID,
+		token.Token,
+		token.ExpiresAt,
+		token.CreatedAt,
+	)
+
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при создании токена входа: %w", err)
+	}
+
+	return id, nil
+}
+
+// Consume возвращает и сразу удаляет токен входа, так что ссылка срабатывает только один раз.
+func (r *MagicLinkRepositoryImpl) Consume(ctx context.Context, token string) (*models.MagicLinkToken, error) {
+	query := `
+		DELETE FROM magic_link_tokens
+		WHERE token = $1
+		RETURNING id, user_id, token, expires_at, created_at
+	`
+
+	var magicLinkToken models.MagicLinkToken
+	err := r.postgres.GetContext(ctx, &magicLinkToken, query, token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("токен входа не найден")
+		}
+		return nil, fmt.Errorf("ошибка при получении токена входа: %w", err)
+	}
+
+	return &magicLinkToken, nil
+}
+
+func (r *MagicLinkRepositoryImpl) DeleteByUserID(ctx context.Context, userID int) error {
+	query := `
+		DELETE FROM magic_link_tokens
+		WHERE user_id = $1
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("ошибка при удалении токенов входа пользователя: %w", err)
+	}
+
+	return nil
+}
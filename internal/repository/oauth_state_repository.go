@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"job_solition/internal/db"
+	"job_solition/internal/models"
+)
+
+type OAuthStateRepositoryImpl struct {
+	postgres *db.PostgreSQL
+}
+
+func NewOAuthStateRepository(postgres *db.PostgreSQL) OAuthStateRepository {
+	return &OAuthStateRepositoryImpl{
+		postgres: postgres,
+	}
+}
+
+func (r *OAuthStateRepositoryImpl) Create(ctx context.Context, state *models.OAuthState) error {
+	if err := r.DeleteExpired(ctx); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO oauth_states (state, provider, code_verifier, user_id, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.postgres.ExecContext(
+		ctx,
+		query,
+		state.State,
+		state.Provider,
+		state.CodeVerifier,
+		state.UserID,
+		state.ExpiresAt,
+		state.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при сохранении состояния входа: %w", err)
+	}
+
+	return nil
+}
+
+// Consume возвращает и сразу удаляет состояние входа, так что каждый state используется один раз.
+func (r *OAuthStateRepositoryImpl) Consume(ctx context.Context, state string) (*models.OAuthState, error) {
+	query := `
+		DELETE FROM oauth_states
+		WHERE state = $1
+		RETURNING state, provider, code_verifier, user_id, expires_at, created_at
+	`
+
+	var oauthState models.OAuthState
+	err := r.postgres.GetContext(ctx, &oauthState, query, state)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("состояние входа не найдено")
+		}
+		return nil, fmt.Errorf("ошибка при получении состояния входа: %w", err)
+	}
+
+	return &oauthState, nil
+}
+
+func (r *OAuthStateRepositoryImpl) DeleteExpired(ctx context.Context) error {
+	query := "DELETE FROM oauth_states WHERE expires_at < NOW()"
+
+	_, err := r.postgres.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("ошибка при удалении просроченных состояний входа: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"job_solition/internal/db"
+)
+
+type PasswordHistoryRepositoryImpl struct {
+	postgres *db.PostgreSQL
+}
+
+func NewPasswordHistoryRepository(postgres *db.PostgreSQL) PasswordHistoryRepository {
+	return &PasswordHistoryRepositoryImpl{
+		postgres: postgres,
+	}
+}
+
+// Add сохраняет хеш нового пароля и удаляет записи старше последних keep.
+func (r *PasswordHistoryRepositoryImpl) Add(ctx context.Context, userID int, passwordHash string, keep int) error {
+	tx, err := r.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("ошибка при начале транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO password_history (user_id, password_hash, created_at)
+		VALUES ($1, $2, NOW())
+	`
+
+	if _, err = tx.ExecContext(ctx, query, userID, passwordHash); err != nil {
+		return fmt.Errorf("ошибка при сохранении истории паролей: %w", err)
+	}
+
+	query = `
+		DELETE FROM password_history
+		WHERE user_id = $1 AND id NOT IN (
+			SELECT id FROM password_history
+			WHERE user_id = $1
+			ORDER BY created_at DESC, id DESC
+			LIMIT $2
+		)
+	`
+
+	if _, err = tx.ExecContext(ctx, query, userID, keep); err != nil {
+		return fmt.Errorf("ошибка при очистке истории паролей: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка при коммите транзакции: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PasswordHistoryRepositoryImpl) GetRecent(ctx context.Context, userID int, limit int) ([]string, error) {
+	query := `
+		SELECT password_hash
+		FROM password_history
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+
+	hashes := []string{}
+	if err := r.postgres.SelectContext(ctx, &hashes, query, userID, limit); err != nil {
+		return nil, fmt.Errorf("ошибка при получении истории паролей: %w", err)
+	}
+
+	return hashes, nil
+}
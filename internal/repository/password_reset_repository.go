@@ -68,6 +68,27 @@ func (r *PasswordResetRepositoryImpl) GetByToken(ctx context.Context, token stri
 	return &resetToken, nil
 }
 
+// Consume удаляет токен сброса пароля и возвращает его. Из параллельных запросов с одним токеном
+// токен получает только один.
+func (r *PasswordResetRepositoryImpl) Consume(ctx context.Context, token string) (*models.PasswordResetToken, error) {
+	query := `
+		DELETE FROM password_reset_tokens
+		WHERE token = $1
+		RETURNING id, user_id, token, expires_at, created_at
+	`
+
+	var resetToken models.PasswordResetToken
+	err := r.postgres.GetContext(ctx, &resetToken, query, token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("токен сброса пароля не найден")
+		}
+		return nil, fmt.Errorf("ошибка при получении токена сброса пароля: %w", err)
+	}
+
+	return &resetToken, nil
+}
+
 func (r *PasswordResetRepositoryImpl) DeleteByToken(ctx context.Context, token string) error {
 	query := `
 		DELETE FROM password_reset_tokens
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"job_solition/internal/db"
+)
+
+type RecoveryCodeRepositoryImpl struct {
+	postgres *db.PostgreSQL
+}
+
+func NewRecoveryCodeRepository(postgres *db.PostgreSQL) RecoveryCodeRepository {
+	return &RecoveryCodeRepositoryImpl{
+		postgres: postgres,
+	}
+}
+
+// Replace удаляет все прежние коды восстановления пользователя и сохраняет новые.
+func (r *RecoveryCodeRepositoryImpl) Replace(ctx context.Context, userID int, codeHashes []string) error {
+	tx, err := r.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("ошибка при начале транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM two_factor_recovery_codes WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("ошибка при удалении кодов восстановления: %w", err)
+	}
+
+	query := `
+		INSERT INTO two_factor_recovery_codes (user_id, code_hash, created_at)
+		VALUES ($1, $2, NOW())
+	`
+
+	for _, codeHash := range codeHashes {
+		if _, err = tx.ExecContext(ctx, query, userID, codeHash); err != nil {
+			return fmt.Errorf("ошибка при сохранении кода восстановления: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка при коммите транзакции: %w", err)
+	}
+
+	return nil
+}
+
+// Use помечает код восстановления использованным. Возвращает false, если такого неиспользованного кода нет.
+func (r *RecoveryCodeRepositoryImpl) Use(ctx context.Context, userID int, codeHash string) (bool, error) {
+	query := `
+		UPDATE two_factor_recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+
+	result, err := r.postgres.ExecContext(ctx, query, userID, codeHash)
+	if err != nil {
+		return false, fmt.Errorf("ошибка при использовании кода восстановления: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ошибка при получении количества обновленных строк: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *RecoveryCodeRepositoryImpl) CountUnused(ctx context.Context, userID int) (int, error) {
+	query := "SELECT COUNT(*) FROM two_factor_recovery_codes WHERE user_id = $1 AND used_at IS NULL"
+
+	var count int
+	if err := r.postgres.GetContext(ctx, &count, query, userID); err != nil {
+		return 0, fmt.Errorf("ошибка при подсчете кодов восстановления: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *RecoveryCodeRepositoryImpl) DeleteByUserID(ctx context.Context, userID int) error {
+	query := "DELETE FROM two_factor_recovery_codes WHERE user_id = $1"
+
+	_, err := r.postgres.ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("ошибка при удалении кодов восстановления: %w", err)
+	}
+
+	return nil
+}
@@ -23,9 +23,9 @@ func NewRefreshTokenRepository(postgres *db.PostgreSQL) RefreshTokenRepository {
 func (r *RefreshTokenRepositoryImpl) Create(ctx context.Context, token *models.RefreshToken) (int, error) {
 	query := `
 		INSERT INTO refresh_tokens 
-		(user_id, token, expires_at, created_at)
+		(user_id, token, family_id, user_agent, ip_address, session_started_at, two_factor_verified, expires_at, created_at)
 		VALUES 
-		($1, $2, $3, $4)
+		($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING id
 	`
 
@@ -35,6 +35,11 @@ func (r *RefreshTokenRepositoryImpl) Create(ctx context.Context, token *models.R
 		query,
 		token.UserID,
 		token.Token,
+		token.FamilyID,
+		token.UserAgent,
+		token.IPAddress,
+		token.SessionStartedAt,
+		token.TwoFactorVerified,
 		token.ExpiresAt,
 		token.CreatedAt,
 	).Scan(&id)
@@ -48,7 +53,9 @@ func (r *RefreshTokenRepositoryImpl) Create(ctx context.Context, token *models.R
 
 func (r *RefreshTokenRepositoryImpl) GetByToken(ctx context.Context, token string) (*models.RefreshToken, error) {
 	query := `
-		SELECT id, user_id, token, expires_at, created_at
+		SELECT id, user_id, token, family_id, COALESCE(user_agent, '') AS user_agent,
+		       COALESCE(ip_address, '') AS ip_address, session_started_at, two_factor_verified,
+		       expires_at, rotated_at, revoked_at, created_at
 		FROM refresh_tokens 
 		WHERE token = $1
 	`
@@ -79,6 +86,109 @@ func (r *RefreshTokenRepositoryImpl) DeleteByToken(ctx context.Context, token st
 	return nil
 }
 
+// MarkRotated помечает токен как замененный. Возвращает false, если токен уже был
+// ротирован или отозван (например, параллельным запросом).
+func (r *RefreshTokenRepositoryImpl) MarkRotated(ctx context.Context, id int) (bool, error) {
+	query := `
+		UPDATE refresh_tokens
+		SET rotated_at = NOW()
+		WHERE id = $1 AND rotated_at IS NULL AND revoked_at IS NULL
+	`
+
+	result, err := r.postgres.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("ошибка при ротации refresh токена: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ошибка при получении количества обновленных строк: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (r *RefreshTokenRepositoryImpl) RevokeFamily(ctx context.Context, familyID string) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE family_id = $1 AND revoked_at IS NULL
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, familyID)
+	if err != nil {
+		return fmt.Errorf("ошибка при отзыве семейства refresh токенов: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRepositoryImpl) DeleteByFamily(ctx context.Context, familyID string) error {
+	query := `
+		DELETE FROM refresh_tokens
+		WHERE family_id = $1
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, familyID)
+	if err != nil {
+		return fmt.Errorf("ошибка при удалении семейства refresh токенов: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRepositoryImpl) GetSessionsByUser(ctx context.Context, userID int) ([]models.Session, error) {
+	query := `
+		SELECT family_id, COALESCE(user_agent, '') AS user_agent, COALESCE(ip_address, '') AS ip_address,
+		       session_started_at, created_at AS last_used_at, expires_at
+		FROM refresh_tokens
+		WHERE user_id = $1 AND rotated_at IS NULL AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY created_at DESC
+	`
+
+	sessions := []models.Session{}
+	err := r.postgres.SelectContext(ctx, &sessions, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении сессий пользователя: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// DeleteSession удаляет все токены сессии пользователя. Возвращает false, если сессия не найдена.
+func (r *RefreshTokenRepositoryImpl) DeleteSession(ctx context.Context, userID int, familyID string) (bool, error) {
+	query := `
+		DELETE FROM refresh_tokens
+		WHERE user_id = $1 AND family_id = $2
+	`
+
+	result, err := r.postgres.ExecContext(ctx, query, userID, familyID)
+	if err != nil {
+		return false, fmt.Errorf("ошибка при завершении сессии: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ошибка при получении количества удаленных строк: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *RefreshTokenRepositoryImpl) DeleteOtherSessions(ctx context.Context, userID int, keepFamilyID string) error {
+	query := `
+		DELETE FROM refresh_tokens
+		WHERE user_id = $1 AND family_id <> $2
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, userID, keepFamilyID)
+	if err != nil {
+		return fmt.Errorf("ошибка при завершении остальных сессий: %w", err)
+	}
+
+	return nil
+}
+
 func (r *RefreshTokenRepositoryImpl) DeleteByUserID(ctx context.Context, userID int) error {
 	query := `
 		DELETE FROM refresh_tokens
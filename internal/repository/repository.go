@@ -2,8 +2,12 @@ package repository
 
 import (
 	"context"
+	"time"
+
 	"job_solition/internal/db"
 	"job_solition/internal/models"
+
+	"github.com/lib/pq"
 )
 
 type Repository struct {
@@ -12,6 +16,7 @@ type Repository struct {
 	Reviews             ReviewRepository
 	RefreshTokens       RefreshTokenRepository
 	PasswordResetTokens PasswordResetRepository
+	EmailVerifications  EmailVerificationRepository
 	Cities              CityRepository
 	Industries          IndustryRepository
 	RatingCategories    RatingCategoryRepository
@@ -19,6 +24,23 @@ type Repository struct {
 	EmploymentPeriods   EmploymentPeriodRepository
 	EmploymentTypes     EmploymentTypeRepository
 	Suggestions         SuggestionRepository
+	SecurityEvents      SecurityEventRepository
+	RecoveryCodes       RecoveryCodeRepository
+	UserIdentities      UserIdentityRepository
+	OAuthStates         OAuthStateRepository
+	LoginAttempts       LoginAttemptRepository
+	PasswordHistory     PasswordHistoryRepository
+	APIKeys             APIKeyRepository
+	MagicLinkTokens     MagicLinkRepository
+	EmailChanges        EmailChangeRepository
+	DataExports         DataExportRepository
+	ReviewRevisions     ReviewRevisionRepository
+	Representatives     CompanyRepresentativeRepository
+	ReviewResponses     ReviewResponseRepository
+	ReviewFlags         ReviewFlagRepository
+	ReviewSimilarities  ReviewSimilarityRepository
+	IdempotencyKeys     IdempotencyKeyRepository
+	ReviewDrafts        ReviewDraftRepository
 }
 
 func NewRepository(postgres *db.PostgreSQL) *Repository {
@@ -28,6 +50,7 @@ func NewRepository(postgres *db.PostgreSQL) *Repository {
 		Reviews:             NewReviewRepository(postgres),
 		RefreshTokens:       NewRefreshTokenRepository(postgres),
 		PasswordResetTokens: NewPasswordResetRepository(postgres),
+		EmailVerifications:  NewEmailVerificationRepository(postgres),
 		Cities:              NewCityRepository(postgres),
 		Industries:          NewIndustryRepository(postgres),
 		RatingCategories:    NewRatingCategoryRepository(postgres),
@@ -35,6 +58,23 @@ func NewRepository(postgres *db.PostgreSQL) *Repository {
 		EmploymentPeriods:   NewEmploymentPeriodRepository(postgres),
 		EmploymentTypes:     NewEmploymentTypeRepository(postgres),
 		Suggestions:         NewSuggestionRepository(postgres),
+		SecurityEvents:      NewSecurityEventRepository(postgres),
+		RecoveryCodes:       NewRecoveryCodeRepository(postgres),
+		UserIdentities:      NewUserIdentityRepository(postgres),
+		OAuthStates:         NewOAuthStateRepository(postgres),
+		LoginAttempts:       NewLoginAttemptRepository(postgres),
+		PasswordHistory:     NewPasswordHistoryRepository(postgres),
+		APIKeys:             NewAPIKeyRepository(postgres),
+		MagicLinkTokens:     NewMagicLinkRepository(postgres),
+		EmailChanges:        NewEmailChangeRepository(postgres),
+		DataExports:         NewDataExportRepository(postgres),
+		ReviewRevisions:     NewReviewRevisionRepository(postgres),
+		Representatives:     NewCompanyRepresentativeRepository(postgres),
+		ReviewResponses:     NewReviewResponseRepository(postgres),
+		ReviewFlags:         NewReviewFlagRepository(postgres),
+		ReviewSimilarities:  NewReviewSimilarityRepository(postgres),
+		IdempotencyKeys:     NewIdempotencyKeyRepository(postgres),
+		ReviewDrafts:        NewReviewDraftRepository(postgres),
 	}
 }
 
@@ -43,6 +83,19 @@ type UserRepository interface {
 	GetByID(ctx context.Context, id int) (*models.User, error)
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
 	Update(ctx context.Context, user *models.User) error
+	MarkEmailVerified(ctx context.Context, id int) error
+	SetTOTPSecret(ctx context.Context, id int, secret string) error
+	EnableTOTP(ctx context.Context, id int, step int64) error
+	DisableTOTP(ctx context.Context, id int) error
+	UseTOTPStep(ctx context.Context, id int, step int64) (bool, error)
+	UnlockLogin(ctx context.Context, id int) error
+	UpdateEmail(ctx context.Context, id int, email string) error
+	IncrementTokenVersion(ctx context.Context, id int) error
+	GetTokenState(ctx context.Context, id int) (*models.TokenState, error)
+	ScheduleDeletion(ctx context.Context, id int, dueAt time.Time) error
+	CancelDeletion(ctx context.Context, id int) error
+	GetDueForDeletion(ctx context.Context, now time.Time, limit int) ([]int, error)
+	Purge(ctx context.Context, id int) error
 	Delete(ctx context.Context, id int) error
 	Count(ctx context.Context) (int, error)
 	GetAll(ctx context.Context, page, limit int) ([]models.User, int, error)
@@ -64,7 +117,7 @@ type CompanyRepository interface {
 }
 
 type ReviewRepository interface {
-	Create(ctx context.Context, review *models.Review) (int, error)
+	Create(ctx context.Context, review *models.Review, categoryRatings map[int]float64, benefitTypeIDs []int, cooldown time.Duration) (int, error)
 	GetByID(ctx context.Context, id int) (*models.ReviewWithDetails, error)
 	GetByCompany(ctx context.Context, companyID int, filter models.ReviewFilter) ([]models.ReviewWithDetails, int, error)
 	GetByUser(ctx context.Context, userID int, filter models.ReviewFilter) ([]models.ReviewWithDetails, int, error)
@@ -72,9 +125,12 @@ type ReviewRepository interface {
 	GetApproved(ctx context.Context, filter models.ReviewFilter) ([]models.ReviewWithDetails, int, error)
 	GetRejected(ctx context.Context, filter models.ReviewFilter) ([]models.ReviewWithDetails, int, error)
 	Update(ctx context.Context, review *models.Review) error
+	GetLatestActive(ctx context.Context, userID, companyID int) (*models.Review, error)
+	ApplyModeration(ctx context.Context, reviewID int, trace models.ModerationTrace) (models.ReviewStatus, error)
+	SaveModerationTrace(ctx context.Context, reviewID int, trace models.ModerationTrace) error
+	GetModerationTraces(ctx context.Context, reviewIDs []int) (map[int]models.ModerationTrace, error)
+	Withdraw(ctx context.Context, id int) (models.ReviewStatus, bool, error)
 	Delete(ctx context.Context, id int) error
-	AddCategoryRating(ctx context.Context, reviewID int, categoryID int, rating float64) error
-	AddBenefit(ctx context.Context, reviewID int, benefitTypeID int) error
 	GetCategoryRatings(ctx context.Context, reviewID int) ([]models.ReviewCategoryRating, error)
 	GetBenefits(ctx context.Context, reviewID int) ([]models.ReviewBenefit, error)
 	MarkReviewAsUseful(ctx context.Context, reviewID int) error
@@ -82,16 +138,24 @@ type ReviewRepository interface {
 	RemoveUsefulMark(ctx context.Context, userID, reviewID int) error
 	HasUserMarkedReviewAsUseful(ctx context.Context, userID, reviewID int) (bool, error)
 	GetUsefulMarksByReviews(ctx context.Context, userID int, reviewIDs []int) (map[int]bool, error)
+	GetUsefulMarksByUser(ctx context.Context, userID int) ([]models.UsefulMark, error)
 	Count(ctx context.Context) (int, error)
 	CountPending(ctx context.Context) (int, error)
 	CountApproved(ctx context.Context) (int, error)
 	CountRejected(ctx context.Context) (int, error)
+	CountByUser(ctx context.Context, userID int) (map[models.ReviewStatus]int, error)
 }
 
 type RefreshTokenRepository interface {
 	Create(ctx context.Context, token *models.RefreshToken) (int, error)
 	GetByToken(ctx context.Context, token string) (*models.RefreshToken, error)
 	DeleteByToken(ctx context.Context, token string) error
+	MarkRotated(ctx context.Context, id int) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+	DeleteByFamily(ctx context.Context, familyID string) error
+	GetSessionsByUser(ctx context.Context, userID int) ([]models.Session, error)
+	DeleteSession(ctx context.Context, userID int, familyID string) (bool, error)
+	DeleteOtherSessions(ctx context.Context, userID int, keepFamilyID string) error
 	DeleteByUserID(ctx context.Context, userID int) error
 }
 
@@ -123,10 +187,17 @@ type IndustryRepository interface {
 type PasswordResetRepository interface {
 	Create(ctx context.Context, token *models.PasswordResetToken) (int, error)
 	GetByToken(ctx context.Context, token string) (*models.PasswordResetToken, error)
+	Consume(ctx context.Context, token string) (*models.PasswordResetToken, error)
 	DeleteByToken(ctx context.Context, token string) error
 	DeleteByUserID(ctx context.Context, userID int) error
 }
 
+type EmailVerificationRepository interface {
+	Create(ctx context.Context, token *models.EmailVerificationToken) (int, error)
+	GetByToken(ctx context.Context, token string) (*models.EmailVerificationToken, error)
+	DeleteByUserID(ctx context.Context, userID int) error
+}
+
 type RatingCategoryRepository interface {
 	GetAll(ctx context.Context) ([]models.RatingCategory, error)
 	GetByID(ctx context.Context, id int) (*models.RatingCategory, error)
@@ -167,9 +238,145 @@ type EmploymentTypeRepository interface {
 	Delete(ctx context.Context, id int) error
 }
 
+type SecurityEventRepository interface {
+	Create(ctx context.Context, event *models.SecurityEvent) (int, error)
+	GetByUser(ctx context.Context, userID int) ([]models.SecurityEvent, error)
+}
+
 type SuggestionRepository interface {
 	Create(ctx context.Context, suggestion *models.Suggestion) (int, error)
 	GetAll(ctx context.Context, filter models.SuggestionFilter) ([]models.Suggestion, int, error)
+	GetByUser(ctx context.Context, userID int) ([]models.Suggestion, error)
 	Delete(ctx context.Context, id int) error
 	Count(ctx context.Context) (int, error)
 }
+
+type RecoveryCodeRepository interface {
+	Replace(ctx context.Context, userID int, codeHashes []string) error
+	Use(ctx context.Context, userID int, codeHash string) (bool, error)
+	CountUnused(ctx context.Context, userID int) (int, error)
+	DeleteByUserID(ctx context.Context, userID int) error
+}
+
+type UserIdentityRepository interface {
+	Create(ctx context.Context, identity *models.UserIdentity) (int, error)
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*models.UserIdentity, error)
+	GetByUser(ctx context.Context, userID int) ([]models.UserIdentity, error)
+	Delete(ctx context.Context, userID int, provider string) (bool, error)
+}
+
+type OAuthStateRepository interface {
+	Create(ctx context.Context, state *models.OAuthState) error
+	Consume(ctx context.Context, state string) (*models.OAuthState, error)
+	DeleteExpired(ctx context.Context) error
+}
+
+type LoginAttemptRepository interface {
+	Create(ctx context.Context, attempt *models.LoginAttempt) (int, error)
+	GetFailureStatsByEmail(ctx context.Context, email string, since time.Time) (*models.LoginFailureStats, error)
+	GetFailureStatsByIP(ctx context.Context, ipAddress string, since time.Time) (*models.LoginFailureStats, error)
+	GetByUser(ctx context.Context, userID, page, limit int) ([]models.LoginAttempt, int, error)
+}
+
+type PasswordHistoryRepository interface {
+	Add(ctx context.Context, userID int, passwordHash string, keep int) error
+	GetRecent(ctx context.Context, userID int, limit int) ([]string, error)
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *models.APIKey) (int, error)
+	GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+	GetAll(ctx context.Context) ([]models.APIKey, error)
+	Revoke(ctx context.Context, id int) (bool, error)
+	RecordUsage(ctx context.Context, id int, now time.Time) (int, error)
+}
+
+type MagicLinkRepository interface {
+	Create(ctx context.Context, token *models.MagicLinkToken) (int, error)
+	Consume(ctx context.Context, token string) (*models.MagicLinkToken, error)
+	DeleteByUserID(ctx context.Context, userID int) error
+}
+
+type EmailChangeRepository interface {
+	Create(ctx context.Context, request *models.EmailChangeRequest) (int, error)
+	ConsumeByToken(ctx context.Context, token string) (*models.EmailChangeRequest, error)
+	ConsumeByCancelToken(ctx context.Context, cancelToken string) (*models.EmailChangeRequest, error)
+	DeleteByUserID(ctx context.Context, userID int) error
+}
+
+type DataExportRepository interface {
+	Create(ctx context.Context, export *models.DataExport) (int, error)
+	GetByUser(ctx context.Context, userID int) ([]models.DataExport, error)
+	GetByToken(ctx context.Context, token string) (*models.DataExport, error)
+	FailStale(ctx context.Context, startedBefore time.Time) error
+	MarkReady(ctx context.Context, id int, archive []byte, expiresAt time.Time) error
+	MarkFailed(ctx context.Context, id int, reason string) error
+	DeleteExpired(ctx context.Context) error
+}
+
+type ReviewRevisionRepository interface {
+	Create(ctx context.Context, revision *models.ReviewRevision) (int, error)
+	GetByID(ctx context.Context, id int) (*models.ReviewRevision, error)
+	GetByReview(ctx context.Context, reviewID int) ([]models.ReviewRevision, error)
+	GetPendingByReview(ctx context.Context, reviewID int) (*models.ReviewRevision, error)
+	GetLatestByReviews(ctx context.Context, reviewIDs []int) (map[int]models.ReviewRevision, error)
+	GetPending(ctx context.Context, page, limit int) ([]models.ReviewRevision, int, error)
+	Approve(ctx context.Context, id int, moderatorID *int, comment string) (*models.ReviewRevision, error)
+	Reject(ctx context.Context, id int, moderatorID *int, comment string) (*models.ReviewRevision, error)
+}
+
+type CompanyRepresentativeRepository interface {
+	Grant(ctx context.Context, representative *models.CompanyRepresentative) (int, error)
+	SubmitClaim(ctx context.Context, claim *models.CompanyRepresentative) error
+	GetByID(ctx context.Context, id int) (*models.CompanyRepresentative, error)
+	GetByUserAndCompany(ctx context.Context, userID, companyID int) (*models.CompanyRepresentative, error)
+	GetByUser(ctx context.Context, userID int) ([]models.CompanyRepresentative, error)
+	GetByCompany(ctx context.Context, companyID int) ([]models.CompanyRepresentative, error)
+	GetAll(ctx context.Context, filter models.CompanyClaimFilter) ([]models.CompanyRepresentative, int, error)
+	RecordCodeAttempt(ctx context.Context, id int) (int, error)
+	ConfirmEmail(ctx context.Context, id int, codeHash string, maxAttempts int) (bool, error)
+	Approve(ctx context.Context, id, adminID int, permissions pq.StringArray, comment string) (*models.CompanyRepresentative, error)
+	Reject(ctx context.Context, id, adminID int, comment string) (*models.CompanyRepresentative, error)
+	Revoke(ctx context.Context, companyID, userID int) (bool, error)
+	HasPermission(ctx context.Context, userID, companyID int, permission models.RepresentativePermission) (bool, error)
+}
+
+type ReviewResponseRepository interface {
+	Save(ctx context.Context, response *models.ReviewResponse) error
+	GetByID(ctx context.Context, id int) (*models.ReviewResponse, error)
+	GetByReview(ctx context.Context, reviewID int) (*models.ReviewResponse, error)
+	GetPublishedByReviews(ctx context.Context, reviewIDs []int) (map[int]models.PublishedReviewResponse, error)
+	GetPending(ctx context.Context, page, limit int) ([]models.ReviewResponse, int, error)
+	Approve(ctx context.Context, id, moderatorID int, comment string) (*models.ReviewResponse, error)
+	Reject(ctx context.Context, id, moderatorID int, comment string) (*models.ReviewResponse, error)
+	DeleteByReview(ctx context.Context, reviewID int) (bool, error)
+}
+
+type ReviewFlagRepository interface {
+	Create(ctx context.Context, flag *models.ReviewFlag, hideThreshold int) (bool, error)
+	GetOpenByReview(ctx context.Context, reviewID int) ([]models.ReviewFlag, error)
+	GetFlagged(ctx context.Context, page, limit int) ([]models.ReviewFlagSummary, int, error)
+	Resolve(ctx context.Context, reviewID int, status models.ReviewFlagStatus, moderatorID int, comment string) ([]models.ReviewFlag, error)
+}
+
+type ReviewSimilarityRepository interface {
+	Detect(ctx context.Context, reviewID int, threshold float64) ([]models.ReviewSimilarity, error)
+	GetByReviews(ctx context.Context, reviewIDs []int) (map[int][]models.ReviewSimilarity, error)
+}
+
+type IdempotencyKeyRepository interface {
+	Reserve(ctx context.Context, key *models.IdempotencyKey, expiresBefore time.Time) (*models.IdempotencyKey, bool, error)
+	Complete(ctx context.Context, id, statusCode int, body []byte) error
+	Delete(ctx context.Context, id int) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+type ReviewDraftRepository interface {
+	Create(ctx context.Context, draft *models.ReviewDraft) (int, error)
+	GetByID(ctx context.Context, id int) (*models.ReviewDraft, error)
+	GetByUser(ctx context.Context, userID int) ([]models.ReviewDraft, error)
+	CountByUser(ctx context.Context, userID int) (int, error)
+	Update(ctx context.Context, draft *models.ReviewDraft) error
+	Delete(ctx context.Context, id int) error
+	DeleteStale(ctx context.Context, before time.Time) (int64, error)
+}
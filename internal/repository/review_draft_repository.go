@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"job_solition/internal/db"
+	"job_solition/internal/models"
+)
+
+const reviewDraftColumns = `id, user_id, payload, created_at, updated_at`
+
+type ReviewDraftRepositoryImpl struct {
+	postgres *db.PostgreSQL
+}
+
+func NewReviewDraftRepository(postgres *db.PostgreSQL) ReviewDraftRepository {
+	return &ReviewDraftRepositoryImpl{
+		postgres: postgres,
+	}
+}
+
+func (r *ReviewDraftRepositoryImpl) Create(ctx context.Context, draft *models.ReviewDraft) (int, error) {
+	query := `
+		INSERT INTO review_drafts (user_id, payload, created_at, updated_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	var id int
+	if err := r.postgres.GetContext(ctx, &id, query, draft.UserID, draft.Payload, draft.CreatedAt, draft.UpdatedAt); err != nil {
+		return 0, fmt.Errorf("ошибка при создании черновика отзыва: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *ReviewDraftRepositoryImpl) GetByID(ctx context.Context, id int) (*models.ReviewDraft, error) {
+	query := `SELECT ` + reviewDraftColumns + ` FROM review_drafts WHERE id = $1`
+
+	var draft models.ReviewDraft
+	if err := r.postgres.GetContext(ctx, &draft, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("черновик не найден")
+		}
+		return nil, fmt.Errorf("ошибка при получении черновика отзыва: %w", err)
+	}
+
+	return &draft, nil
+}
+
+// GetByUser возвращает черновики пользователя, начиная с последних измененных.
+func (r *ReviewDraftRepositoryImpl) GetByUser(ctx context.Context, userID int) ([]models.ReviewDraft, error) {
+	query := `SELECT ` + reviewDraftColumns + ` FROM review_drafts WHERE user_id = $1 ORDER BY updated_at DESC`
+
+	drafts := []models.ReviewDraft{}
+	if err := r.postgres.SelectContext(ctx, &drafts, query, userID); err != nil {
+		return nil, fmt.Errorf("ошибка при получении черновиков отзывов: %w", err)
+	}
+
+	return drafts, nil
+}
+
+func (r *ReviewDraftRepositoryImpl) CountByUser(ctx context.Context, userID int) (int, error) {
+	var count int
+	if err := r.postgres.GetContext(ctx, &count, "SELECT COUNT(*) FROM review_drafts WHERE user_id = $1", userID); err != nil {
+		return 0, fmt.Errorf("ошибка при подсчете черновиков отзывов: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *ReviewDraftRepositoryImpl) Update(ctx context.Context, draft *models.ReviewDraft) error {
+	query := `
+		UPDATE review_drafts
+		SET payload = $1, updated_at = $2
+		WHERE id = $3
+	`
+
+	if _, err := r.postgres.ExecContext(ctx, query, draft.Payload, draft.UpdatedAt, draft.ID); err != nil {
+		return fmt.Errorf("ошибка при обновлении черновика отзыва: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReviewDraftRepositoryImpl) Delete(ctx context.Context, id int) error {
+	if _, err := r.postgres.ExecContext(ctx, "DELETE FROM review_drafts WHERE id = $1", id); err != nil {
+		return fmt.Errorf("ошибка при удалении черновика отзыва: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteStale удаляет черновики, которые не менялись с момента before, и возвращает их количество.
+func (r *ReviewDraftRepositoryImpl) DeleteStale(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.postgres.ExecContext(ctx, "DELETE FROM review_drafts WHERE updated_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при удалении устаревших черновиков отзывов: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при получении количества затронутых строк: %w", err)
+	}
+
+	return affected, nil
+}
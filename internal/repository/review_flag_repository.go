@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"job_solition/internal/db"
+	"job_solition/internal/models"
+)
+
+const reviewFlagColumns = `id, review_id, user_id, reason, comment, status, resolution_comment, resolved_by, created_at, resolved_at`
+
+type ReviewFlagRepositoryImpl struct {
+	postgres *db.PostgreSQL
+}
+
+func NewReviewFlagRepository(postgres *db.PostgreSQL) ReviewFlagRepository {
+	return &ReviewFlagRepositoryImpl{
+		postgres: postgres,
+	}
+}
+
+// Create сохраняет жалобу на отзыв. Когда число открытых жалоб достигает hideThreshold, отзыв скрывается
+// из публичной выдачи. Возвращает true, если отзыв был скрыт этой жалобой.
+func (r *ReviewFlagRepositoryImpl) Create(ctx context.Context, flag *models.ReviewFlag, hideThreshold int) (bool, error) {
+	tx, err := r.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("ошибка при начале транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO review_flags (review_id, user_id, reason, comment, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (review_id, user_id) DO NOTHING
+		RETURNING id
+	`
+	err = tx.QueryRowxContext(ctx, query, flag.ReviewID, flag.UserID, flag.Reason, flag.Comment, flag.Status, flag.CreatedAt).Scan(&flag.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("жалоба уже подана")
+		}
+		return false, fmt.Errorf("ошибка при сохранении жалобы на отзыв: %w", err)
+	}
+
+	hidden := false
+	if hideThreshold > 0 {
+		query = `
+			UPDATE reviews
+			SET is_hidden = TRUE, hidden_at = NOW()
+			WHERE id = $1 AND NOT is_hidden
+			  AND (SELECT COUNT(*) FROM review_flags WHERE review_id = $1 AND status = $2) >= $3
+		`
+		result, err := tx.ExecContext(ctx, query, flag.ReviewID, models.ReviewFlagStatusOpen, hideThreshold)
+		if err != nil {
+			return false, fmt.Errorf("ошибка при скрытии отзыва: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return false, fmt.Errorf("ошибка при получении количества затронутых строк: %w", err)
+		}
+		hidden = rowsAffected > 0
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf("ошибка при коммите транзакции: %w", err)
+	}
+
+	return hidden, nil
+}
+
+func (r *ReviewFlagRepositoryImpl) GetOpenByReview(ctx context.Context, reviewID int) ([]models.ReviewFlag, error) {
+	query := `SELECT ` + reviewFlagColumns + ` FROM review_flags WHERE review_id = $1 AND status = $2 ORDER BY created_at ASC`
+
+	flags := []models.ReviewFlag{}
+	if err := r.postgres.SelectContext(ctx, &flags, query, reviewID, models.ReviewFlagStatusOpen); err != nil {
+		return nil, fmt.Errorf("ошибка при получении жалоб на отзыв: %w", err)
+	}
+
+	return flags, nil
+}
+
+// GetFlagged возвращает отзывы с открытыми жалобами: сначала с наибольшим числом жалоб, затем более старые.
+func (r *ReviewFlagRepositoryImpl) GetFlagged(ctx context.Context, page, limit int) ([]models.ReviewFlagSummary, int, error) {
+	var total int
+	countQuery := "SELECT COUNT(DISTINCT review_id) FROM review_flags WHERE status = $1"
+	if err := r.postgres.GetContext(ctx, &total, countQuery, models.ReviewFlagStatusOpen); err != nil {
+		return nil, 0, fmt.Errorf("ошибка при подсчете отзывов с жалобами: %w", err)
+	}
+
+	offset := (page - 1) * limit
+
+	query := `
+		SELECT review_id, COUNT(*) AS open_flags, MIN(created_at) AS first_flagged_at
+		FROM review_flags
+		WHERE status = $1
+		GROUP BY review_id
+		ORDER BY open_flags DESC, first_flagged_at ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	summaries := []models.ReviewFlagSummary{}
+	if err := r.postgres.SelectContext(ctx, &summaries, query, models.ReviewFlagStatusOpen, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("ошибка при получении отзывов с жалобами: %w", err)
+	}
+
+	return summaries, total, nil
+}
+
+// Resolve закрывает открытые жалобы на отзыв. При status = dismissed отзыв снова показывается публично,
+// при status = actioned отзыв отклоняется с комментарием модератора, если автор еще не снял его сам.
+// Возвращает закрытые жалобы.
+func (r *ReviewFlagRepositoryImpl) Resolve(ctx context.Context, reviewID int, status models.ReviewFlagStatus, moderatorID int, comment string) ([]models.ReviewFlag, error) {
+	tx, err := r.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при начале транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	var resolutionComment *string
+	if comment != "" {
+		resolutionComment = &comment
+	}
+
+	query := `
+		UPDATE review_flags
+		SET status = $1, resolution_comment = $2, resolved_by = $3, resolved_at = NOW()
+		WHERE review_id = $4 AND status = $5
+		RETURNING ` + reviewFlagColumns
+
+	flags := []models.ReviewFlag{}
+	if err = tx.SelectContext(ctx, &flags, query, status, resolutionComment, moderatorID, reviewID, models.ReviewFlagStatusOpen); err != nil {
+		return nil, fmt.Errorf("ошибка при закрытии жалоб на отзыв: %w", err)
+	}
+
+	if len(flags) == 0 {
+		return nil, fmt.Errorf("нет открытых жалоб")
+	}
+
+	if status == models.ReviewFlagStatusActioned {
+		query = `
+			UPDATE reviews
+			SET status = $1, moderation_comment = $2, is_hidden = FALSE, hidden_at = NULL, updated_at = NOW()
+			WHERE id = $3 AND status <> $4
+		`
+		_, err = tx.ExecContext(ctx, query, models.ReviewStatusRejected, resolutionComment, reviewID, models.ReviewStatusWithdrawn)
+	} else {
+		_, err = tx.ExecContext(ctx, "UPDATE reviews SET is_hidden = FALSE, hidden_at = NULL WHERE id = $1", reviewID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при обновлении отзыва: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка при коммите транзакции: %w", err)
+	}
+
+	return flags, nil
+}
@@ -6,9 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"job_solition/internal/db"
 	"job_solition/internal/models"
+
+	"github.com/lib/pq"
 )
 
 type ReviewRepositoryImpl struct {
@@ -21,14 +24,36 @@ func NewReviewRepository(postgres *db.PostgreSQL) ReviewRepository {
 	}
 }
 
-func (r *ReviewRepositoryImpl) Create(ctx context.Context, review *models.Review) (int, error) {
+// Create сохраняет отзыв вместе с оценками по категориям и льготами в одной транзакции.
+// Параллельные отзывы одного пользователя об одной компании сериализуются блокировкой, и отзыв
+// не сохраняется, если у пользователя уже есть ожидающий модерации или опубликованный отзыв
+// о компании, оставленный менее cooldown назад (при cooldown <= 0 - оставленный когда-либо).
+func (r *ReviewRepositoryImpl) Create(ctx context.Context, review *models.Review, categoryRatings map[int]float64, benefitTypeIDs []int, cooldown time.Duration) (int, error) {
 	tx, err := r.postgres.BeginTx(ctx, nil)
 	if err != nil {
 		return 0, fmt.Errorf("ошибка при начале транзакции: %w", err)
 	}
 	defer tx.Rollback()
 
+	if _, err = tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1, $2)", review.UserID, review.CompanyID); err != nil {
+		return 0, fmt.Errorf("ошибка при блокировке отзывов пользователя о компании: %w", err)
+	}
+
+	var latestCreatedAt sql.NullTime
 	query := `
+		SELECT MAX(created_at)
+		FROM reviews
+		WHERE user_id = $1 AND company_id = $2 AND status IN ($3, $4)
+	`
+	err = tx.QueryRowxContext(ctx, query, review.UserID, review.CompanyID, models.ReviewStatusPending, models.ReviewStatusApproved).Scan(&latestCreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при проверке отзывов пользователя о компании: %w", err)
+	}
+	if latestCreatedAt.Valid && (cooldown <= 0 || time.Now().Before(latestCreatedAt.Time.Add(cooldown))) {
+		return 0, fmt.Errorf("отзыв о компании уже оставлен")
+	}
+
+	query = `
 		INSERT INTO reviews 
 		(user_id, company_id, position, employment_type_id, employment_period_id, city_id, rating, pros, cons, is_former_employee, is_recommended, status, created_at, updated_at)
 		VALUES 
@@ -37,7 +62,8 @@ func (r *ReviewRepositoryImpl) Create(ctx context.Context, review *models.Review
 	`
 
 	var id int
-	err = tx.QueryRowx(
+	err = tx.QueryRowxContext(
+		ctx,
 		query,
 		review.UserID,
 		review.CompanyID,
@@ -59,6 +85,27 @@ func (r *ReviewRepositoryImpl) Create(ctx context.Context, review *models.Review
 		return 0, fmt.Errorf("ошибка при создании отзыва: %w", err)
 	}
 
+	for categoryID, rating := range categoryRatings {
+		query = `
+			INSERT INTO review_category_ratings (review_id, category_id, rating)
+			VALUES ($1, $2, $3)
+		`
+		if _, err = tx.ExecContext(ctx, query, id, categoryID, rating); err != nil {
+			return 0, fmt.Errorf("ошибка при добавлении рейтинга отзыва по категории: %w", err)
+		}
+	}
+
+	for _, benefitTypeID := range benefitTypeIDs {
+		query = `
+			INSERT INTO review_benefits (review_id, benefit_type_id)
+			VALUES ($1, $2)
+			ON CONFLICT (review_id, benefit_type_id) DO NOTHING
+		`
+		if _, err = tx.ExecContext(ctx, query, id, benefitTypeID); err != nil {
+			return 0, fmt.Errorf("ошибка при добавлении льготы к отзыву: %w", err)
+		}
+	}
+
 	if err = tx.Commit(); err != nil {
 		return 0, fmt.Errorf("ошибка при коммите транзакции: %w", err)
 	}
@@ -70,7 +117,7 @@ func (r *ReviewRepositoryImpl) GetByID(ctx context.Context, id int) (*models.Rev
 	query := `
 		SELECT id, user_id, company_id, position, employment_type_id, employment_period_id,
 		       city_id, rating, pros, cons, is_former_employee, is_recommended, status, moderation_comment, 
-		       useful_count, created_at, updated_at, approved_at
+		       useful_count, created_at, updated_at, approved_at, is_hidden, is_suspicious
 		FROM reviews
 		WHERE id = $1
 	`
@@ -131,6 +178,14 @@ func (r *ReviewRepositoryImpl) GetByID(ctx context.Context, id int) (*models.Rev
 		IsMarkedAsUseful: false,
 	}
 
+	responses, err := getPublishedResponses(ctx, r.postgres, []int{id})
+	if err != nil {
+		return nil, err
+	}
+	if response, ok := responses[id]; ok {
+		result.Response = &response
+	}
+
 	userID, exists := ctx.Value("user_id").(int)
 	if exists && userID > 0 {
 		isMarked, err := r.HasUserMarkedReviewAsUseful(ctx, userID, id)
@@ -221,6 +276,10 @@ func (r *ReviewRepositoryImpl) getReviews(ctx context.Context, filter models.Rev
 		argID++
 	}
 
+	if filter.ExcludeHidden {
+		conditions = append(conditions, "NOT is_hidden")
+	}
+
 	queryConditions := baseQuery
 	if len(conditions) > 0 {
 		queryConditions += " AND " + strings.Join(conditions, " AND ")
@@ -249,7 +308,7 @@ func (r *ReviewRepositoryImpl) getReviews(ctx context.Context, filter models.Rev
 
 	dataQuery := fmt.Sprintf(`
 		SELECT id, user_id, company_id, position, employment_type_id, employment_period_id, city_id, rating,
-		       pros, cons, is_former_employee, is_recommended, status, moderation_comment, useful_count, created_at, updated_at, approved_at
+		       pros, cons, is_former_employee, is_recommended, status, moderation_comment, useful_count, created_at, updated_at, approved_at, is_hidden, is_suspicious
 		%s
 		ORDER BY %s %s
 		LIMIT $%d OFFSET $%d
@@ -328,6 +387,10 @@ func (r *ReviewRepositoryImpl) getReviews(ctx context.Context, filter models.Rev
 		}
 	}
 
+	if err := attachPublishedResponses(ctx, r.postgres, result); err != nil {
+		return nil, 0, err
+	}
+
 	return result, total, nil
 }
 
@@ -347,6 +410,16 @@ func (r *ReviewRepositoryImpl) GetByUser(ctx context.Context, userID int, filter
 		argID++
 	}
 
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		conditions = append(conditions, fmt.Sprintf("status::text = ANY($%d)", argID))
+		args = append(args, pq.Array(statuses))
+		argID++
+	}
+
 	if filter.CompanyID != nil {
 		conditions = append(conditions, fmt.Sprintf("company_id = $%d", argID))
 		args = append(args, *filter.CompanyID)
@@ -393,7 +466,7 @@ func (r *ReviewRepositoryImpl) GetByUser(ctx context.Context, userID int, filter
 
 	dataQuery := fmt.Sprintf(`
 		SELECT id, user_id, company_id, position, employment_type_id, employment_period_id, city_id, rating,
-		       pros, cons, is_former_employee, is_recommended, status, moderation_comment, useful_count, created_at, updated_at, approved_at
+		       pros, cons, is_former_employee, is_recommended, status, moderation_comment, useful_count, created_at, updated_at, approved_at, is_hidden, is_suspicious
 		%s
 		ORDER BY %s %s
 		LIMIT $%d OFFSET $%d
@@ -472,6 +545,10 @@ func (r *ReviewRepositoryImpl) GetByUser(ctx context.Context, userID int, filter
 		}
 	}
 
+	if err := attachPublishedResponses(ctx, r.postgres, result); err != nil {
+		return nil, 0, err
+	}
+
 	return result, total, nil
 }
 
@@ -525,7 +602,7 @@ func (r *ReviewRepositoryImpl) GetPending(ctx context.Context, filter models.Rev
 
 	dataQuery := fmt.Sprintf(`
 		SELECT id, user_id, company_id, position, employment_type_id, employment_period_id, city_id, rating,
-		       pros, cons, is_former_employee, is_recommended, status, moderation_comment, useful_count, created_at, updated_at, approved_at
+		       pros, cons, is_former_employee, is_recommended, status, moderation_comment, useful_count, created_at, updated_at, approved_at, is_hidden, is_suspicious
 		%s
 		ORDER BY %s %s
 		LIMIT $%d OFFSET $%d
@@ -604,6 +681,10 @@ func (r *ReviewRepositoryImpl) GetPending(ctx context.Context, filter models.Rev
 		}
 	}
 
+	if err := attachPublishedResponses(ctx, r.postgres, result); err != nil {
+		return nil, 0, err
+	}
+
 	return result, total, nil
 }
 
@@ -641,44 +722,195 @@ func (r *ReviewRepositoryImpl) Update(ctx context.Context, review *models.Review
 	return nil
 }
 
-func (r *ReviewRepositoryImpl) Delete(ctx context.Context, id int) error {
+// CountByUser возвращает количество отзывов пользователя по статусам.
+func (r *ReviewRepositoryImpl) CountByUser(ctx context.Context, userID int) (map[models.ReviewStatus]int, error) {
 	query := `
-		DELETE FROM reviews
-		WHERE id = $1
+		SELECT status, COUNT(*) AS count
+		FROM reviews
+		WHERE user_id = $1
+		GROUP BY status
 	`
 
-	_, err := r.postgres.ExecContext(ctx, query, id)
+	var rows []struct {
+		Status models.ReviewStatus `db:"status"`
+		Count  int                 `db:"count"`
+	}
+	if err := r.postgres.SelectContext(ctx, &rows, query, userID); err != nil {
+		return nil, fmt.Errorf("ошибка при подсчете отзывов пользователя: %w", err)
+	}
+
+	counts := map[models.ReviewStatus]int{
+		models.ReviewStatusPending:   0,
+		models.ReviewStatusApproved:  0,
+		models.ReviewStatusRejected:  0,
+		models.ReviewStatusWithdrawn: 0,
+	}
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+
+	return counts, nil
+}
+
+// GetLatestActive возвращает последний отзыв пользователя о компании, который ожидает модерации
+// или опубликован. Отозванные и отклоненные отзывы не учитываются.
+func (r *ReviewRepositoryImpl) GetLatestActive(ctx context.Context, userID, companyID int) (*models.Review, error) {
+	query := `
+		SELECT id, user_id, company_id, position, employment_type_id, employment_period_id,
+		       city_id, rating, pros, cons, is_former_employee, is_recommended, status, moderation_comment,
+		       useful_count, created_at, updated_at, approved_at, is_hidden, is_suspicious
+		FROM reviews
+		WHERE user_id = $1 AND company_id = $2 AND status IN ($3, $4)
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	var review models.Review
+	err := r.postgres.GetContext(ctx, &review, query, userID, companyID, models.ReviewStatusPending, models.ReviewStatusApproved)
 	if err != nil {
-		return fmt.Errorf("ошибка при удалении отзыва: %w", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("отзыв не найден")
+		}
+		return nil, fmt.Errorf("ошибка при получении отзыва: %w", err)
 	}
 
-	return nil
+	return &review, nil
 }
 
-func (r *ReviewRepositoryImpl) AddCategoryRating(ctx context.Context, reviewID int, categoryID int, rating float64) error {
+// ApplyModeration сохраняет результат автоматической предмодерации отзыва. Если отзыв еще ожидает
+// модерации, решение approve публикует его, а reject отклоняет с перечнем причин. Возвращает
+// итоговый статус отзыва.
+func (r *ReviewRepositoryImpl) ApplyModeration(ctx context.Context, reviewID int, trace models.ModerationTrace) (models.ReviewStatus, error) {
 	query := `
-		INSERT INTO review_category_ratings (review_id, category_id, rating)
-		VALUES ($1, $2, $3)
-		ON CONFLICT (review_id, category_id) DO UPDATE SET rating = $3
+		UPDATE reviews
+		SET moderation_trace = $1,
+		    status = CASE WHEN status = $2 AND $3 THEN $5 WHEN status = $2 AND $4 THEN $6 ELSE status END,
+		    approved_at = CASE WHEN status = $2 AND $3 THEN NOW() ELSE approved_at END,
+		    moderation_comment = CASE WHEN status = $2 AND $4 THEN $7 ELSE moderation_comment END,
+		    updated_at = CASE WHEN status = $2 AND ($3 OR $4) THEN NOW() ELSE updated_at END
+		WHERE id = $8
+		RETURNING status
 	`
 
-	_, err := r.postgres.ExecContext(ctx, query, reviewID, categoryID, rating)
+	var status models.ReviewStatus
+	err := r.postgres.GetContext(
+		ctx,
+		&status,
+		query,
+		trace,
+		models.ReviewStatusPending,
+		trace.Outcome == models.ModerationOutcomeApprove,
+		trace.Outcome == models.ModerationOutcomeReject,
+		models.ReviewStatusApproved,
+		models.ReviewStatusRejected,
+		trace.RejectionComment(),
+		reviewID,
+	)
 	if err != nil {
-		return fmt.Errorf("ошибка при добавлении рейтинга отзыва по категории: %w", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("отзыв не найден")
+		}
+		return "", fmt.Errorf("ошибка при сохранении результата предмодерации: %w", err)
+	}
+
+	return status, nil
+}
+
+// SaveModerationTrace сохраняет результат автоматической предмодерации, не меняя статус отзыва.
+func (r *ReviewRepositoryImpl) SaveModerationTrace(ctx context.Context, reviewID int, trace models.ModerationTrace) error {
+	_, err := r.postgres.ExecContext(ctx, "UPDATE reviews SET moderation_trace = $1 WHERE id = $2", trace, reviewID)
+	if err != nil {
+		return fmt.Errorf("ошибка при сохранении результата предмодерации: %w", err)
 	}
 
 	return nil
 }
 
-func (r *ReviewRepositoryImpl) AddBenefit(ctx context.Context, reviewID int, benefitTypeID int) error {
+// GetModerationTraces возвращает результаты последней предмодерации отзывов reviewIDs.
+func (r *ReviewRepositoryImpl) GetModerationTraces(ctx context.Context, reviewIDs []int) (map[int]models.ModerationTrace, error) {
+	result := make(map[int]models.ModerationTrace, len(reviewIDs))
+	if len(reviewIDs) == 0 {
+		return result, nil
+	}
+
 	query := `
-		INSERT INTO review_benefits (review_id, benefit_type_id)
-		VALUES ($1, $2)
+		SELECT id, moderation_trace
+		FROM reviews
+		WHERE id = ANY($1) AND moderation_trace IS NOT NULL
+	`
+
+	var rows []struct {
+		ID    int                    `db:"id"`
+		Trace models.ModerationTrace `db:"moderation_trace"`
+	}
+	if err := r.postgres.SelectContext(ctx, &rows, query, pq.Array(reviewIDs)); err != nil {
+		return nil, fmt.Errorf("ошибка при получении результатов предмодерации: %w", err)
+	}
+
+	for _, row := range rows {
+		result[row.ID] = row.Trace
+	}
+
+	return result, nil
+}
+
+// Withdraw снимает отзыв с публикации по просьбе автора. Ожидающие модерации правки отзыва
+// помечаются замененными. Возвращает статус отзыва до снятия и false, если отзыв уже отозван или отклонен.
+func (r *ReviewRepositoryImpl) Withdraw(ctx context.Context, id int) (models.ReviewStatus, bool, error) {
+	tx, err := r.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("ошибка при начале транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	var previous models.ReviewStatus
+	err = tx.GetContext(ctx, &previous, "SELECT status FROM reviews WHERE id = $1 FOR UPDATE", id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", false, fmt.Errorf("отзыв не найден")
+		}
+		return "", false, fmt.Errorf("ошибка при получении отзыва: %w", err)
+	}
+
+	if previous != models.ReviewStatusPending && previous != models.ReviewStatusApproved {
+		return previous, false, nil
+	}
+
+	query := `
+		UPDATE reviews
+		SET status = $1, updated_at = NOW()
+		WHERE id = $2
 	`
+	if _, err = tx.ExecContext(ctx, query, models.ReviewStatusWithdrawn, id); err != nil {
+		return "", false, fmt.Errorf("ошибка при отзыве отзыва: %w", err)
+	}
 
-	_, err := r.postgres.ExecContext(ctx, query, reviewID, benefitTypeID)
+	query = `
+		UPDATE review_revisions
+		SET status = $1
+		WHERE review_id = $2 AND status = $3
+	`
+	_, err = tx.ExecContext(ctx, query, models.ReviewRevisionStatusSuperseded, id, models.ReviewRevisionStatusPending)
 	if err != nil {
-		return fmt.Errorf("ошибка при добавлении льготы к отзыву: %w", err)
+		return "", false, fmt.Errorf("ошибка при обновлении правок отзыва: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", false, fmt.Errorf("ошибка при коммите транзакции: %w", err)
+	}
+
+	return previous, true, nil
+}
+
+func (r *ReviewRepositoryImpl) Delete(ctx context.Context, id int) error {
+	query := `
+		DELETE FROM reviews
+		WHERE id = $1
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("ошибка при удалении отзыва: %w", err)
 	}
 
 	return nil
@@ -788,6 +1020,22 @@ func (r *ReviewRepositoryImpl) HasUserMarkedReviewAsUseful(ctx context.Context,
 	return exists, nil
 }
 
+func (r *ReviewRepositoryImpl) GetUsefulMarksByUser(ctx context.Context, userID int) ([]models.UsefulMark, error) {
+	query := `
+		SELECT id, user_id, review_id, created_at
+		FROM useful_marks
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	marks := []models.UsefulMark{}
+	if err := r.postgres.SelectContext(ctx, &marks, query, userID); err != nil {
+		return nil, fmt.Errorf("ошибка при получении отметок полезности: %w", err)
+	}
+
+	return marks, nil
+}
+
 func (r *ReviewRepositoryImpl) GetUsefulMarksByReviews(ctx context.Context, userID int, reviewIDs []int) (map[int]bool, error) {
 	if len(reviewIDs) == 0 {
 		return make(map[int]bool), nil
@@ -896,7 +1144,7 @@ func (r *ReviewRepositoryImpl) GetApproved(ctx context.Context, filter models.Re
 
 	dataQuery := fmt.Sprintf(`
 		SELECT id, user_id, company_id, position, employment_type_id, employment_period_id, city_id, rating,
-		       pros, cons, is_former_employee, is_recommended, status, moderation_comment, useful_count, created_at, updated_at, approved_at
+		       pros, cons, is_former_employee, is_recommended, status, moderation_comment, useful_count, created_at, updated_at, approved_at, is_hidden, is_suspicious
 		%s
 		ORDER BY %s %s
 		LIMIT $%d OFFSET $%d
@@ -975,6 +1223,10 @@ func (r *ReviewRepositoryImpl) GetApproved(ctx context.Context, filter models.Re
 		}
 	}
 
+	if err := attachPublishedResponses(ctx, r.postgres, result); err != nil {
+		return nil, 0, err
+	}
+
 	return result, total, nil
 }
 
@@ -1028,7 +1280,7 @@ func (r *ReviewRepositoryImpl) GetRejected(ctx context.Context, filter models.Re
 
 	dataQuery := fmt.Sprintf(`
 		SELECT id, user_id, company_id, position, employment_type_id, employment_period_id, city_id, rating,
-		       pros, cons, is_former_employee, is_recommended, status, moderation_comment, useful_count, created_at, updated_at, approved_at
+		       pros, cons, is_former_employee, is_recommended, status, moderation_comment, useful_count, created_at, updated_at, approved_at, is_hidden, is_suspicious
 		%s
 		ORDER BY %s %s
 		LIMIT $%d OFFSET $%d
@@ -1107,6 +1359,10 @@ func (r *ReviewRepositoryImpl) GetRejected(ctx context.Context, filter models.Re
 		}
 	}
 
+	if err := attachPublishedResponses(ctx, r.postgres, result); err != nil {
+		return nil, 0, err
+	}
+
 	return result, total, nil
 }
 
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"job_solition/internal/db"
+	"job_solition/internal/models"
+
+	"github.com/lib/pq"
+)
+
+const reviewResponseColumns = `id, review_id, company_id, author_id, text, published_text, status, moderation_comment,
+		       moderated_by, created_at, updated_at, moderated_at, published_at`
+
+type ReviewResponseRepositoryImpl struct {
+	postgres *db.PostgreSQL
+}
+
+func NewReviewResponseRepository(postgres *db.PostgreSQL) ReviewResponseRepository {
+	return &ReviewResponseRepositoryImpl{
+		postgres: postgres,
+	}
+}
+
+// Save создает ответ на отзыв или заменяет текст существующего. Новая версия ответа всегда
+// отправляется на модерацию, опубликованная версия при этом не меняется.
+func (r *ReviewResponseRepositoryImpl) Save(ctx context.Context, response *models.ReviewResponse) error {
+	query := `
+		INSERT INTO review_responses (review_id, company_id, author_id, text, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		ON CONFLICT (review_id) DO UPDATE
+		SET author_id = EXCLUDED.author_id, text = EXCLUDED.text, status = EXCLUDED.status,
+		    moderation_comment = NULL, moderated_by = NULL, moderated_at = NULL, updated_at = EXCLUDED.updated_at
+		RETURNING ` + reviewResponseColumns
+
+	err := r.postgres.GetContext(
+		ctx,
+		response,
+		query,
+		response.ReviewID,
+		response.CompanyID,
+		response.AuthorID,
+		response.Text,
+		models.ReviewResponseStatusPending,
+		response.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка при сохранении ответа на отзыв: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReviewResponseRepositoryImpl) GetByID(ctx context.Context, id int) (*models.ReviewResponse, error) {
+	query := `SELECT ` + reviewResponseColumns + ` FROM review_responses WHERE id = $1`
+
+	var response models.ReviewResponse
+	if err := r.postgres.GetContext(ctx, &response, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("ответ не найден")
+		}
+		return nil, fmt.Errorf("ошибка при получении ответа на отзыв: %w", err)
+	}
+
+	return &response, nil
+}
+
+func (r *ReviewResponseRepositoryImpl) GetByReview(ctx context.Context, reviewID int) (*models.ReviewResponse, error) {
+	query := `SELECT ` + reviewResponseColumns + ` FROM review_responses WHERE review_id = $1`
+
+	var response models.ReviewResponse
+	if err := r.postgres.GetContext(ctx, &response, query, reviewID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("ответ не найден")
+		}
+		return nil, fmt.Errorf("ошибка при получении ответа на отзыв: %w", err)
+	}
+
+	return &response, nil
+}
+
+// GetPublishedByReviews возвращает опубликованные ответы на отзывы reviewIDs.
+func (r *ReviewResponseRepositoryImpl) GetPublishedByReviews(ctx context.Context, reviewIDs []int) (map[int]models.PublishedReviewResponse, error) {
+	return getPublishedResponses(ctx, r.postgres, reviewIDs)
+}
+
+func (r *ReviewResponseRepositoryImpl) GetPending(ctx context.Context, page, limit int) ([]models.ReviewResponse, int, error) {
+	var total int
+	countQuery := "SELECT COUNT(*) FROM review_responses WHERE status = $1"
+	if err := r.postgres.GetContext(ctx, &total, countQuery, models.ReviewResponseStatusPending); err != nil {
+		return nil, 0, fmt.Errorf("ошибка при подсчете ответов на отзывы: %w", err)
+	}
+
+	offset := (page - 1) * limit
+
+	query := `SELECT ` + reviewResponseColumns + `
+		FROM review_responses
+		WHERE status = $1
+		ORDER BY updated_at ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	responses := []models.ReviewResponse{}
+	if err := r.postgres.SelectContext(ctx, &responses, query, models.ReviewResponseStatusPending, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("ошибка при получении ответов на отзывы: %w", err)
+	}
+
+	return responses, total, nil
+}
+
+// Approve одобряет ожидающую модерации версию ответа и публикует ее.
+func (r *ReviewResponseRepositoryImpl) Approve(ctx context.Context, id, moderatorID int, comment string) (*models.ReviewResponse, error) {
+	query := `
+		UPDATE review_responses
+		SET status = $1, published_text = text, published_at = NOW(), moderated_by = $2, moderation_comment = $3, moderated_at = NOW()
+		WHERE id = $4 AND status = $5
+		RETURNING ` + reviewResponseColumns
+
+	return r.moderate(ctx, query, models.ReviewResponseStatusApproved, id, moderatorID, comment)
+}
+
+// Reject отклоняет ожидающую модерации версию ответа. Опубликованная ранее версия остается.
+func (r *ReviewResponseRepositoryImpl) Reject(ctx context.Context, id, moderatorID int, comment string) (*models.ReviewResponse, error) {
+	query := `
+		UPDATE review_responses
+		SET status = $1, moderated_by = $2, moderation_comment = $3, moderated_at = NOW()
+		WHERE id = $4 AND status = $5
+		RETURNING ` + reviewResponseColumns
+
+	return r.moderate(ctx, query, models.ReviewResponseStatusRejected, id, moderatorID, comment)
+}
+
+func (r *ReviewResponseRepositoryImpl) moderate(ctx context.Context, query string, status models.ReviewResponseStatus, id, moderatorID int, comment string) (*models.ReviewResponse, error) {
+	var moderationComment *string
+	if comment != "" {
+		moderationComment = &comment
+	}
+
+	var response models.ReviewResponse
+	err := r.postgres.GetContext(ctx, &response, query, status, moderatorID, moderationComment, id, models.ReviewResponseStatusPending)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("ответ не ожидает модерации")
+		}
+		return nil, fmt.Errorf("ошибка при модерации ответа на отзыв: %w", err)
+	}
+
+	return &response, nil
+}
+
+func (r *ReviewResponseRepositoryImpl) DeleteByReview(ctx context.Context, reviewID int) (bool, error) {
+	result, err := r.postgres.ExecContext(ctx, "DELETE FROM review_responses WHERE review_id = $1", reviewID)
+	if err != nil {
+		return false, fmt.Errorf("ошибка при удалении ответа на отзыв: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ошибка при получении количества затронутых строк: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// attachPublishedResponses добавляет к отзывам опубликованные ответы компаний.
+func attachPublishedResponses(ctx context.Context, postgres *db.PostgreSQL, reviews []models.ReviewWithDetails) error {
+	reviewIDs := make([]int, len(reviews))
+	for i := range reviews {
+		reviewIDs[i] = reviews[i].Review.ID
+	}
+
+	responses, err := getPublishedResponses(ctx, postgres, reviewIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range reviews {
+		if response, ok := responses[reviews[i].Review.ID]; ok {
+			reviews[i].Response = &response
+		}
+	}
+
+	return nil
+}
+
+// getPublishedResponses возвращает опубликованные ответы компаний на отзывы по ID отзывов.
+func getPublishedResponses(ctx context.Context, postgres *db.PostgreSQL, reviewIDs []int) (map[int]models.PublishedReviewResponse, error) {
+	result := make(map[int]models.PublishedReviewResponse, len(reviewIDs))
+	if len(reviewIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT id, review_id, company_id, published_text, published_at
+		FROM review_responses
+		WHERE review_id = ANY($1) AND published_text IS NOT NULL
+	`
+
+	responses := []models.PublishedReviewResponse{}
+	if err := postgres.SelectContext(ctx, &responses, query, pq.Array(reviewIDs)); err != nil {
+		return nil, fmt.Errorf("ошибка при получении ответов на отзывы: %w", err)
+	}
+
+	for _, response := range responses {
+		result[response.ReviewID] = response
+	}
+
+	return result, nil
+}
@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"job_solition/internal/db"
+	"job_solition/internal/models"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const reviewRevisionColumns = `id, review_id, revision, editor_id, status, content, changes, moderation_comment,
+		       moderated_by, created_at, moderated_at`
+
+type ReviewRevisionRepositoryImpl struct {
+	postgres *db.PostgreSQL
+}
+
+func NewReviewRevisionRepository(postgres *db.PostgreSQL) ReviewRevisionRepository {
+	return &ReviewRevisionRepositoryImpl{
+		postgres: postgres,
+	}
+}
+
+// Create сохраняет правку отзыва. Статус правки выбирается по статусу заблокированного отзыва: правка
+// неодобренного отзыва получает статус applied и сразу применяется к нему, правка одобренного - pending,
+// а ожидающая модерации правка того же отзыва помечается замененной. Отклоненный или отозванный отзыв
+// изменить нельзя.
+func (r *ReviewRevisionRepositoryImpl) Create(ctx context.Context, revision *models.ReviewRevision) (int, error) {
+	tx, err := r.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при начале транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	var reviewStatus models.ReviewStatus
+	if err = tx.QueryRowxContext(ctx, "SELECT status FROM reviews WHERE id = $1 FOR UPDATE", revision.ReviewID).Scan(&reviewStatus); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("отзыв не найден")
+		}
+		return 0, fmt.Errorf("ошибка при блокировке отзыва: %w", err)
+	}
+
+	switch reviewStatus {
+	case models.ReviewStatusPending:
+		revision.Status = models.ReviewRevisionStatusApplied
+	case models.ReviewStatusApproved:
+		revision.Status = models.ReviewRevisionStatusPending
+	default:
+		return 0, fmt.Errorf("отзыв нельзя изменить")
+	}
+
+	query := `
+		UPDATE review_revisions
+		SET status = $1
+		WHERE review_id = $2 AND status = $3
+	`
+	_, err = tx.ExecContext(ctx, query, models.ReviewRevisionStatusSuperseded, revision.ReviewID, models.ReviewRevisionStatusPending)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при обновлении предыдущей правки отзыва: %w", err)
+	}
+
+	query = `
+		INSERT INTO review_revisions (review_id, revision, editor_id, status, content, changes, created_at)
+		VALUES ($1, (SELECT COALESCE(MAX(revision), 0) + 1 FROM review_revisions WHERE review_id = $1), $2, $3, $4, $5, $6)
+		RETURNING id, revision
+	`
+	err = tx.QueryRowxContext(
+		ctx,
+		query,
+		revision.ReviewID,
+		revision.EditorID,
+		revision.Status,
+		revision.Content,
+		revision.Changes,
+		revision.CreatedAt,
+	).Scan(&revision.ID, &revision.Revision)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при сохранении правки отзыва: %w", err)
+	}
+
+	if revision.Status == models.ReviewRevisionStatusApplied {
+		if err = applyReviewContent(ctx, tx, revision.ReviewID, revision.Content); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("ошибка при коммите транзакции: %w", err)
+	}
+
+	return revision.ID, nil
+}
+
+func (r *ReviewRevisionRepositoryImpl) GetByID(ctx context.Context, id int) (*models.ReviewRevision, error) {
+	query := `SELECT ` + reviewRevisionColumns + ` FROM review_revisions WHERE id = $1`
+
+	var revision models.ReviewRevision
+	if err := r.postgres.GetContext(ctx, &revision, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("правка не найдена")
+		}
+		return nil, fmt.Errorf("ошибка при получении правки отзыва: %w", err)
+	}
+
+	return &revision, nil
+}
+
+func (r *ReviewRevisionRepositoryImpl) GetByReview(ctx context.Context, reviewID int) ([]models.ReviewRevision, error) {
+	query := `SELECT ` + reviewRevisionColumns + ` FROM review_revisions WHERE review_id = $1 ORDER BY revision DESC`
+
+	revisions := []models.ReviewRevision{}
+	if err := r.postgres.SelectContext(ctx, &revisions, query, reviewID); err != nil {
+		return nil, fmt.Errorf("ошибка при получении правок отзыва: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (r *ReviewRevisionRepositoryImpl) GetPendingByReview(ctx context.Context, reviewID int) (*models.ReviewRevision, error) {
+	query := `SELECT ` + reviewRevisionColumns + ` FROM review_revisions WHERE review_id = $1 AND status = $2`
+
+	var revision models.ReviewRevision
+	if err := r.postgres.GetContext(ctx, &revision, query, reviewID, models.ReviewRevisionStatusPending); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("правка не найдена")
+		}
+		return nil, fmt.Errorf("ошибка при получении правки отзыва: %w", err)
+	}
+
+	return &revision, nil
+}
+
+// GetLatestByReviews возвращает последнюю правку каждого из отзывов reviewIDs.
+func (r *ReviewRevisionRepositoryImpl) GetLatestByReviews(ctx context.Context, reviewIDs []int) (map[int]models.ReviewRevision, error) {
+	result := make(map[int]models.ReviewRevision, len(reviewIDs))
+	if len(reviewIDs) == 0 {
+		return result, nil
+	}
+
+	query := `SELECT DISTINCT ON (review_id) ` + reviewRevisionColumns + `
+		FROM review_revisions
+		WHERE review_id = ANY($1)
+		ORDER BY review_id, revision DESC
+	`
+
+	revisions := []models.ReviewRevision{}
+	if err := r.postgres.SelectContext(ctx, &revisions, query, pq.Array(reviewIDs)); err != nil {
+		return nil, fmt.Errorf("ошибка при получении правок отзывов: %w", err)
+	}
+
+	for _, revision := range revisions {
+		result[revision.ReviewID] = revision
+	}
+
+	return result, nil
+}
+
+func (r *ReviewRevisionRepositoryImpl) GetPending(ctx context.Context, page, limit int) ([]models.ReviewRevision, int, error) {
+	var total int
+	countQuery := "SELECT COUNT(*) FROM review_revisions WHERE status = $1"
+	if err := r.postgres.GetContext(ctx, &total, countQuery, models.ReviewRevisionStatusPending); err != nil {
+		return nil, 0, fmt.Errorf("ошибка при подсчете правок отзывов: %w", err)
+	}
+
+	offset := (page - 1) * limit
+
+	query := `SELECT ` + reviewRevisionColumns + `
+		FROM review_revisions
+		WHERE status = $1
+		ORDER BY created_at ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	revisions := []models.ReviewRevision{}
+	if err := r.postgres.SelectContext(ctx, &revisions, query, models.ReviewRevisionStatusPending, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("ошибка при получении правок отзывов: %w", err)
+	}
+
+	return revisions, total, nil
+}
+
+// Approve одобряет ожидающую модерации правку и применяет ее к отзыву. moderatorID равен nil,
+// если решение приняла автоматическая предмодерация.
+func (r *ReviewRevisionRepositoryImpl) Approve(ctx context.Context, id int, moderatorID *int, comment string) (*models.ReviewRevision, error) {
+	tx, err := r.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при начале транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	revision, err := moderateRevision(ctx, tx, id, models.ReviewRevisionStatusApproved, moderatorID, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = applyReviewContent(ctx, tx, revision.ReviewID, revision.Content); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка при коммите транзакции: %w", err)
+	}
+
+	return revision, nil
+}
+
+// Reject отклоняет ожидающую модерации правку. Опубликованная версия отзыва не меняется.
+// moderatorID равен nil, если решение приняла автоматическая предмодерация.
+func (r *ReviewRevisionRepositoryImpl) Reject(ctx context.Context, id int, moderatorID *int, comment string) (*models.ReviewRevision, error) {
+	tx, err := r.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при начале транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	revision, err := moderateRevision(ctx, tx, id, models.ReviewRevisionStatusRejected, moderatorID, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка при коммите транзакции: %w", err)
+	}
+
+	return revision, nil
+}
+
+func moderateRevision(ctx context.Context, tx *sqlx.Tx, id int, status models.ReviewRevisionStatus, moderatorID *int, comment string) (*models.ReviewRevision, error) {
+	var moderationComment *string
+	if comment != "" {
+		moderationComment = &comment
+	}
+
+	query := `
+		UPDATE review_revisions
+		SET status = $1, moderated_by = $2, moderation_comment = $3, moderated_at = NOW()
+		WHERE id = $4 AND status = $5
+		RETURNING ` + reviewRevisionColumns
+
+	var revision models.ReviewRevision
+	err := tx.GetContext(ctx, &revision, query, status, moderatorID, moderationComment, id, models.ReviewRevisionStatusPending)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("правка не ожидает модерации")
+		}
+		return nil, fmt.Errorf("ошибка при модерации правки отзыва: %w", err)
+	}
+
+	return &revision, nil
+}
+
+// applyReviewContent записывает содержимое правки в отзыв: текст, средний рейтинг, оценки по категориям и льготы.
+func applyReviewContent(ctx context.Context, tx *sqlx.Tx, reviewID int, content models.ReviewContent) error {
+	query := `
+		UPDATE reviews
+		SET position = $1, pros = $2, cons = $3, is_former_employee = $4, is_recommended = $5, rating = $6, updated_at = NOW()
+		WHERE id = $7
+	`
+	_, err := tx.ExecContext(
+		ctx,
+		query,
+		content.Position,
+		content.Pros,
+		content.Cons,
+		content.IsFormerEmployee,
+		content.IsRecommended,
+		content.Rating(),
+		reviewID,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка при обновлении отзыва: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM review_category_ratings WHERE review_id = $1", reviewID); err != nil {
+		return fmt.Errorf("ошибка при обновлении рейтингов отзыва по категориям: %w", err)
+	}
+	for categoryID, rating := range content.CategoryRatings {
+		query = `INSERT INTO review_category_ratings (review_id, category_id, rating) VALUES ($1, $2, $3)`
+		if _, err = tx.ExecContext(ctx, query, reviewID, categoryID, rating); err != nil {
+			return fmt.Errorf("ошибка при обновлении рейтингов отзыва по категориям: %w", err)
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM review_benefits WHERE review_id = $1", reviewID); err != nil {
+		return fmt.Errorf("ошибка при обновлении льгот отзыва: %w", err)
+	}
+	for _, benefitTypeID := range content.BenefitTypeIDs {
+		query = `INSERT INTO review_benefits (review_id, benefit_type_id) VALUES ($1, $2)`
+		if _, err = tx.ExecContext(ctx, query, reviewID, benefitTypeID); err != nil {
+			return fmt.Errorf("ошибка при обновлении льгот отзыва: %w", err)
+		}
+	}
+
+	return nil
+}
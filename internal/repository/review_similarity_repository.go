@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"job_solition/internal/db"
+	"job_solition/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// maxSimilarReviews - сколько самых похожих отзывов сохраняется для одного отзыва.
+const maxSimilarReviews = 5
+
+type ReviewSimilarityRepositoryImpl struct {
+	postgres *db.PostgreSQL
+}
+
+func NewReviewSimilarityRepository(postgres *db.PostgreSQL) ReviewSimilarityRepository {
+	return &ReviewSimilarityRepositoryImpl{
+		postgres: postgres,
+	}
+}
+
+// Detect ищет отзывы с почти совпадающим текстом плюсов и минусов (триграммное сходство pg_trgm не ниже
+// threshold), сохраняет найденные совпадения вместо прежних и помечает отзыв подозрительным, если они есть.
+func (r *ReviewSimilarityRepositoryImpl) Detect(ctx context.Context, reviewID int, threshold float64) ([]models.ReviewSimilarity, error) {
+	tx, err := r.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при начале транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	thresholdValue := strconv.FormatFloat(threshold, 'f', -1, 64)
+	if _, err = tx.ExecContext(ctx, "SELECT set_config('pg_trgm.similarity_threshold', $1, true)", thresholdValue); err != nil {
+		return nil, fmt.Errorf("ошибка при настройке поиска похожих отзывов: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM review_similarities WHERE review_id = $1", reviewID); err != nil {
+		return nil, fmt.Errorf("ошибка при удалении похожих отзывов: %w", err)
+	}
+
+	query := `
+		INSERT INTO review_similarities (review_id, similar_review_id, score)
+		SELECT src.id, other.id, similarity(other.pros || ' ' || other.cons, src.pros || ' ' || src.cons) AS score
+		FROM reviews src
+		JOIN reviews other ON other.id <> src.id
+		     AND (other.pros || ' ' || other.cons) % (src.pros || ' ' || src.cons)
+		WHERE src.id = $1
+		ORDER BY score DESC
+		LIMIT $2
+	`
+	if _, err = tx.ExecContext(ctx, query, reviewID, maxSimilarReviews); err != nil {
+		return nil, fmt.Errorf("ошибка при поиске похожих отзывов: %w", err)
+	}
+
+	query = `
+		UPDATE reviews
+		SET is_suspicious = EXISTS(SELECT 1 FROM review_similarities WHERE review_id = $1)
+		WHERE id = $1
+	`
+	if _, err = tx.ExecContext(ctx, query, reviewID); err != nil {
+		return nil, fmt.Errorf("ошибка при обновлении отзыва: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("ошибка при коммите транзакции: %w", err)
+	}
+
+	similarities, err := r.GetByReviews(ctx, []int{reviewID})
+	if err != nil {
+		return nil, err
+	}
+
+	return similarities[reviewID], nil
+}
+
+// GetByReviews возвращает сохраненные почти дубликаты для каждого из отзывов reviewIDs.
+func (r *ReviewSimilarityRepositoryImpl) GetByReviews(ctx context.Context, reviewIDs []int) (map[int][]models.ReviewSimilarity, error) {
+	result := make(map[int][]models.ReviewSimilarity, len(reviewIDs))
+	if len(reviewIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT s.review_id, s.similar_review_id, other.user_id, other.company_id, other.status,
+		       other.user_id = src.user_id AS same_author, s.score, s.created_at
+		FROM review_similarities s
+		JOIN reviews src ON src.id = s.review_id
+		JOIN reviews other ON other.id = s.similar_review_id
+		WHERE s.review_id = ANY($1)
+		ORDER BY s.review_id, s.score DESC
+	`
+
+	similarities := []models.ReviewSimilarity{}
+	if err := r.postgres.SelectContext(ctx, &similarities, query, pq.Array(reviewIDs)); err != nil {
+		return nil, fmt.Errorf("ошибка при получении похожих отзывов: %w", err)
+	}
+
+	for _, similarity := range similarities {
+		result[similarity.ReviewID] = append(result[similarity.ReviewID], similarity)
+	}
+
+	return result, nil
+}
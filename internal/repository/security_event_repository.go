@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"job_solition/internal/db"
+	"job_solition/internal/models"
+)
+
+type SecurityEventRepositoryImpl struct {
+	postgres *db.PostgreSQL
+}
+
+func NewSecurityEventRepository(postgres *db.PostgreSQL) SecurityEventRepository {
+	return &SecurityEventRepositoryImpl{
+		postgres: postgres,
+	}
+}
+
+func (r *SecurityEventRepositoryImpl) Create(ctx context.Context, event *models.SecurityEvent) (int, error) {
+	query := `
+		INSERT INTO security_events (user_id, event_type, ip_address, user_agent, details, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	var id int
+	err := r.postgres.GetContext(
+		ctx,
+		&id,
+		query,
+		event.UserID,
+		event.EventType,
+		event.IPAddress,
+		event.UserAgent,
+		event.Details,
+		event.CreatedAt,
+	)
+
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при записи события безопасности: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *SecurityEventRepositoryImpl) GetByUser(ctx context.Context, userID int) ([]models.SecurityEvent, error) {
+	query := `
+		SELECT id, user_id, event_type, COALESCE(ip_address, '') AS ip_address,
+		       COALESCE(user_agent, '') AS user_agent, COALESCE(details, '') AS details, created_at
+		FROM security_events
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	var events []models.SecurityEvent
+	err := r.postgres.SelectContext(ctx, &events, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении событий безопасности: %w", err)
+	}
+
+	return events, nil
+}
@@ -19,8 +19,8 @@ func NewSuggestionRepository(postgres *db.PostgreSQL) SuggestionRepository {
 
 func (r *SuggestionRepositoryImpl) Create(ctx context.Context, suggestion *models.Suggestion) (int, error) {
 	query := `
-		INSERT INTO suggestions (type, text, created_at)
-		VALUES ($1, $2, $3)
+		INSERT INTO suggestions (type, text, user_id, created_at)
+		VALUES ($1, $2, $3, $4)
 		RETURNING id
 	`
 
@@ -30,6 +30,7 @@ func (r *SuggestionRepositoryImpl) Create(ctx context.Context, suggestion *model
 		query,
 		suggestion.Type,
 		suggestion.Text,
+		suggestion.UserID,
 		suggestion.CreatedAt,
 	).Scan(&id)
 
@@ -74,7 +75,7 @@ func (r *SuggestionRepositoryImpl) GetAll(ctx context.Context, filter models.Sug
 	}
 
 	query := fmt.Sprintf(`
-		SELECT id, type, text, created_at
+		SELECT id, type, text, user_id, created_at
 		%s%s
 		ORDER BY created_at %s
 		LIMIT $%d OFFSET $%d
@@ -91,6 +92,22 @@ func (r *SuggestionRepositoryImpl) GetAll(ctx context.Context, filter models.Sug
 	return suggestions, total, nil
 }
 
+func (r *SuggestionRepositoryImpl) GetByUser(ctx context.Context, userID int) ([]models.Suggestion, error) {
+	query := `
+		SELECT id, type, text, user_id, created_at
+		FROM suggestions
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	suggestions := []models.Suggestion{}
+	if err := r.postgres.SelectContext(ctx, &suggestions, query, userID); err != nil {
+		return nil, fmt.Errorf("ошибка при получении предложений пользователя: %w", err)
+	}
+
+	return suggestions, nil
+}
+
 func (r *SuggestionRepositoryImpl) Delete(ctx context.Context, id int) error {
 	query := `DELETE FROM suggestions WHERE id = $1`
 	_, err := r.postgres.ExecContext(ctx, query, id)
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"job_solition/internal/db"
+	"job_solition/internal/models"
+)
+
+type UserIdentityRepositoryImpl struct {
+	postgres *db.PostgreSQL
+}
+
+func NewUserIdentityRepository(postgres *db.PostgreSQL) UserIdentityRepository {
+	return &UserIdentityRepositoryImpl{
+		postgres: postgres,
+	}
+}
+
+func (r *UserIdentityRepositoryImpl) Create(ctx context.Context, identity *models.UserIdentity) (int, error) {
+	query := `
+		INSERT INTO user_identities (user_id, provider, subject, email, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	var id int
+	err := r.postgres.GetContext(
+		ctx,
+		&id,
+		query,
+		identity.UserID,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+		identity.CreatedAt,
+	)
+
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при привязке внешнего аккаунта: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *UserIdentityRepositoryImpl) GetByProviderSubject(ctx context.Context, provider, subject string) (*models.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, subject, COALESCE(email, '') AS email, created_at
+		FROM user_identities
+		WHERE provider = $1 AND subject = $2
+	`
+
+	var identity models.UserIdentity
+	err := r.postgres.GetContext(ctx, &identity, query, provider, subject)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("внешний аккаунт не найден")
+		}
+		return nil, fmt.Errorf("ошибка при получении внешнего аккаунта: %w", err)
+	}
+
+	return &identity, nil
+}
+
+func (r *UserIdentityRepositoryImpl) GetByUser(ctx context.Context, userID int) ([]models.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, subject, COALESCE(email, '') AS email, created_at
+		FROM user_identities
+		WHERE user_id = $1
+		ORDER BY created_at
+	`
+
+	identities := []models.UserIdentity{}
+	err := r.postgres.SelectContext(ctx, &identities, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении внешних аккаунтов пользователя: %w", err)
+	}
+
+	return identities, nil
+}
+
+// Delete отвязывает провайдера от пользователя. Возвращает false, если привязки не было.
+func (r *UserIdentityRepositoryImpl) Delete(ctx context.Context, userID int, provider string) (bool, error) {
+	query := `
+		DELETE FROM user_identities
+		WHERE user_id = $1 AND provider = $2
+	`
+
+	result, err := r.postgres.ExecContext(ctx, query, userID, provider)
+	if err != nil {
+		return false, fmt.Errorf("ошибка при отвязке внешнего аккаунта: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ошибка при получении количества удаленных строк: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
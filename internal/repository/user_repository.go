@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"job_solition/internal/db"
 	"job_solition/internal/models"
@@ -23,10 +24,10 @@ func NewUserRepository(postgres *db.PostgreSQL) UserRepository {
 func (r *UserRepositoryImpl) Create(ctx context.Context, user *models.User) (int, error) {
 	query := `
 		INSERT INTO users 
-		(email, phone, password_hash, first_name, last_name, role, created_at, updated_at)
+		(email, phone, password_hash, first_name, last_name, role, email_verified_at, created_at, updated_at)
 		VALUES 
-		($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id
+		($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, token_version
 	`
 
 	var id int
@@ -39,9 +40,10 @@ func (r *UserRepositoryImpl) Create(ctx context.Context, user *models.User) (int
 		user.FirstName,
 		user.LastName,
 		user.Role,
+		user.EmailVerifiedAt,
 		user.CreatedAt,
 		user.UpdatedAt,
-	).Scan(&id)
+	).Scan(&id, &user.TokenVersion)
 
 	if err != nil {
 		return 0, fmt.Errorf("ошибка при создании пользователя: %w", err)
@@ -52,7 +54,8 @@ func (r *UserRepositoryImpl) Create(ctx context.Context, user *models.User) (int
 
 func (r *UserRepositoryImpl) GetByID(ctx context.Context, id int) (*models.User, error) {
 	query := `
-		SELECT id, email, phone, password_hash, first_name, last_name, role, created_at, updated_at
+		SELECT id, email, phone, password_hash, first_name, last_name, role, email_verified_at,
+		       totp_secret, totp_enabled_at, totp_last_step, login_unlocked_at, token_version, is_system, deletion_due_at, created_at, updated_at
 		FROM users 
 		WHERE id = $1
 	`
@@ -71,9 +74,10 @@ func (r *UserRepositoryImpl) GetByID(ctx context.Context, id int) (*models.User,
 
 func (r *UserRepositoryImpl) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	query := `
-		SELECT id, email, phone, password_hash, first_name, last_name, role, created_at, updated_at
+		SELECT id, email, phone, password_hash, first_name, last_name, role, email_verified_at,
+		       totp_secret, totp_enabled_at, totp_last_step, login_unlocked_at, token_version, is_system, deletion_due_at, created_at, updated_at
 		FROM users 
-		WHERE email = $1
+		WHERE email = $1 AND NOT is_system
 	`
 
 	var user models.User
@@ -114,6 +118,314 @@ func (r *UserRepositoryImpl) Update(ctx context.Context, user *models.User) erro
 	return nil
 }
 
+func (r *UserRepositoryImpl) MarkEmailVerified(ctx context.Context, id int) error {
+	query := `
+		UPDATE users
+		SET email_verified_at = NOW()
+		WHERE id = $1 AND email_verified_at IS NULL
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("ошибка при подтверждении email пользователя: %w", err)
+	}
+
+	return nil
+}
+
+// SetTOTPSecret сохраняет новый секрет TOTP. 2FA при этом остается выключенной до подтверждения кодом.
+func (r *UserRepositoryImpl) SetTOTPSecret(ctx context.Context, id int, secret string) error {
+	query := `
+		UPDATE users
+		SET totp_secret = $2, totp_enabled_at = NULL, totp_last_step = NULL, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, id, secret)
+	if err != nil {
+		return fmt.Errorf("ошибка при сохранении секрета TOTP: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepositoryImpl) EnableTOTP(ctx context.Context, id int, step int64) error {
+	query := `
+		UPDATE users
+		SET totp_enabled_at = NOW(), totp_last_step = $2, updated_at = NOW()
+		WHERE id = $1 AND totp_secret IS NOT NULL
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, id, step)
+	if err != nil {
+		return fmt.Errorf("ошибка при включении двухфакторной аутентификации: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepositoryImpl) DisableTOTP(ctx context.Context, id int) error {
+	query := `
+		UPDATE users
+		SET totp_secret = NULL, totp_enabled_at = NULL, totp_last_step = NULL, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("ошибка при отключении двухфакторной аутентификации: %w", err)
+	}
+
+	return nil
+}
+
+// UseTOTPStep атомарно фиксирует использованный шаг TOTP. Возвращает false, если код
+// этого или более позднего шага уже был принят (повторное использование кода).
+func (r *UserRepositoryImpl) UseTOTPStep(ctx context.Context, id int, step int64) (bool, error) {
+	query := `
+		UPDATE users
+		SET totp_last_step = $2
+		WHERE id = $1 AND (totp_last_step IS NULL OR totp_last_step < $2)
+	`
+
+	result, err := r.postgres.ExecContext(ctx, query, id, step)
+	if err != nil {
+		return false, fmt.Errorf("ошибка при сохранении шага TOTP: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ошибка при получении количества обновленных строк: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// UnlockLogin снимает блокировку входа: неудачные попытки до текущего момента больше не учитываются.
+func (r *UserRepositoryImpl) UnlockLogin(ctx context.Context, id int) error {
+	query := `
+		UPDATE users
+		SET login_unlocked_at = NOW()
+		WHERE id = $1
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("ошибка при разблокировке входа пользователя: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateEmail меняет email пользователя. Новый адрес считается подтвержденным, так как смена
+// выполняется по ссылке из письма на этот адрес.
+func (r *UserRepositoryImpl) UpdateEmail(ctx context.Context, id int, email string) error {
+	query := `
+		UPDATE users
+		SET email = $1, email_verified_at = NOW(), updated_at = NOW()
+		WHERE id = $2
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, email, id)
+	if err != nil {
+		return fmt.Errorf("ошибка при изменении email пользователя: %w", err)
+	}
+
+	return nil
+}
+
+// IncrementTokenVersion делает недействительными все выданные пользователю access токены.
+func (r *UserRepositoryImpl) IncrementTokenVersion(ctx context.Context, id int) error {
+	query := `
+		UPDATE users
+		SET token_version = token_version + 1
+		WHERE id = $1
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("ошибка при отзыве access токенов пользователя: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepositoryImpl) GetTokenState(ctx context.Context, id int) (*models.TokenState, error) {
+	query := "SELECT id, role, token_version FROM users WHERE id = $1"
+
+	var state models.TokenState
+	err := r.postgres.GetContext(ctx, &state, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("пользователь не найден")
+		}
+		return nil, fmt.Errorf("ошибка при получении версии токенов пользователя: %w", err)
+	}
+
+	return &state, nil
+}
+
+// ScheduleDeletion назначает удаление аккаунта на dueAt. До этого момента удаление можно отменить.
+func (r *UserRepositoryImpl) ScheduleDeletion(ctx context.Context, id int, dueAt time.Time) error {
+	query := `
+		UPDATE users
+		SET deletion_due_at = $1, updated_at = NOW()
+		WHERE id = $2
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, dueAt, id)
+	if err != nil {
+		return fmt.Errorf("ошибка при назначении удаления пользователя: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepositoryImpl) CancelDeletion(ctx context.Context, id int) error {
+	query := `
+		UPDATE users
+		SET deletion_due_at = NULL, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("ошибка при отмене удаления пользователя: %w", err)
+	}
+
+	return nil
+}
+
+// GetDueForDeletion возвращает ID пользователей, у которых истек срок до удаления аккаунта.
+func (r *UserRepositoryImpl) GetDueForDeletion(ctx context.Context, now time.Time, limit int) ([]int, error) {
+	query := `
+		SELECT id
+		FROM users
+		WHERE deletion_due_at IS NOT NULL AND deletion_due_at <= $1 AND NOT is_system
+		ORDER BY deletion_due_at
+		LIMIT $2
+	`
+
+	ids := []int{}
+	if err := r.postgres.SelectContext(ctx, &ids, query, now, limit); err != nil {
+		return nil, fmt.Errorf("ошибка при получении пользователей к удалению: %w", err)
+	}
+
+	return ids, nil
+}
+
+// Purge удаляет пользователя вместе с персональными данными. Одобренные отзывы не удаляются,
+// а переходят к анонимному служебному пользователю без правок на модерации и без истории правок,
+// сделанных до одобрения. Рейтинги затронутых компаний пересчитываются в той же транзакции.
+func (r *UserRepositoryImpl) Purge(ctx context.Context, id int) error {
+	tx, err := r.postgres.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("ошибка при начале транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	var placeholderID int
+	err = tx.QueryRowContext(ctx, "SELECT id FROM users WHERE email = $1 AND is_system", models.AnonymousUserEmail).Scan(&placeholderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("анонимный пользователь не найден")
+		}
+		return fmt.Errorf("ошибка при получении анонимного пользователя: %w", err)
+	}
+
+	if placeholderID == id {
+		return fmt.Errorf("анонимного пользователя нельзя удалить")
+	}
+
+	query := `
+		UPDATE reviews
+		SET useful_count = GREATEST(useful_count - 1, 0)
+		WHERE id IN (SELECT review_id FROM useful_marks WHERE user_id = $1)
+	`
+	if _, err = tx.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("ошибка при пересчете отметок полезности: %w", err)
+	}
+
+	query = `
+		DELETE FROM review_revisions
+		WHERE status IN ('pending', 'applied') AND review_id IN (SELECT id FROM reviews WHERE user_id = $1)
+	`
+	if _, err = tx.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("ошибка при удалении правок отзывов пользователя: %w", err)
+	}
+
+	query = `
+		UPDATE reviews
+		SET user_id = $1, updated_at = NOW()
+		WHERE user_id = $2 AND status = 'approved'
+		RETURNING company_id
+	`
+	rows, err := tx.QueryContext(ctx, query, placeholderID, id)
+	if err != nil {
+		return fmt.Errorf("ошибка при обезличивании отзывов пользователя: %w", err)
+	}
+	companyIDs, err := scanCompanyIDs(rows)
+	if err != nil {
+		return fmt.Errorf("ошибка при обезличивании отзывов пользователя: %w", err)
+	}
+
+	rows, err = tx.QueryContext(ctx, "DELETE FROM reviews WHERE user_id = $1 RETURNING company_id", id)
+	if err != nil {
+		return fmt.Errorf("ошибка при удалении отзывов пользователя: %w", err)
+	}
+	deletedCompanyIDs, err := scanCompanyIDs(rows)
+	if err != nil {
+		return fmt.Errorf("ошибка при удалении отзывов пользователя: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM login_attempts WHERE user_id = $1", id); err != nil {
+		return fmt.Errorf("ошибка при удалении истории входов пользователя: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM users WHERE id = $1", id); err != nil {
+		return fmt.Errorf("ошибка при удалении пользователя: %w", err)
+	}
+
+	for _, companyID := range uniqueIDs(append(companyIDs, deletedCompanyIDs...)) {
+		if err = updateCompanyRating(ctx, tx, companyID); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка при коммите транзакции: %w", err)
+	}
+
+	return nil
+}
+
+func scanCompanyIDs(rows *sql.Rows) ([]int, error) {
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
 func (r *UserRepositoryImpl) Delete(ctx context.Context, id int) error {
 	query := "DELETE FROM users WHERE id = $1"
 	_, err := r.postgres.ExecContext(ctx, query, id)
@@ -154,7 +466,8 @@ func (r *UserRepositoryImpl) GetAll(ctx context.Context, page, limit int) ([]mod
 	offset := (page - 1) * limit
 
 	query := `
-		SELECT id, email, phone, password_hash, first_name, last_name, role, created_at, updated_at
+		SELECT id, email, phone, password_hash, first_name, last_name, role, email_verified_at,
+		       totp_secret, totp_enabled_at, totp_last_step, login_unlocked_at, token_version, is_system, deletion_due_at, created_at, updated_at
 		FROM users
 		ORDER BY id
 		LIMIT $1 OFFSET $2
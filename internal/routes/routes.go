@@ -14,65 +14,141 @@ import (
 func SetupAuthRoutes(router *gin.RouterGroup, postgres *db.PostgreSQL, cfg *config.Config) {
 	repo := repository.NewRepository(postgres)
 	authHandler := handlers.NewAuthHandler(repo, cfg)
+	oidcHandler := handlers.NewOIDCHandler(repo, cfg)
 
 	auth := router.Group("/auth")
 	{
 		auth.POST("/register", authHandler.Register)
 		auth.POST("/login", authHandler.Login)
+		auth.POST("/login/2fa", authHandler.LoginTwoFactor)
+		auth.POST("/magic-link", authHandler.RequestMagicLink)
+		auth.POST("/magic-link/login", authHandler.MagicLinkLogin)
+		auth.POST("/2fa/setup", authHandler.SetupTwoFactorOnLogin)
+		auth.POST("/2fa/enable", authHandler.EnableTwoFactorOnLogin)
 		auth.POST("/refresh", authHandler.RefreshToken)
 		auth.POST("/logout", authHandler.Logout)
 		auth.POST("/forgot-password", authHandler.ForgotPassword)
+		auth.POST("/reset-password", authHandler.ResetPassword)
+		auth.POST("/verify-email", authHandler.VerifyEmail)
+		auth.POST("/confirm-email-change", authHandler.ConfirmEmailChange)
+		auth.POST("/cancel-email-change", authHandler.CancelEmailChange)
+
+		auth.GET("/oidc/providers", oidcHandler.GetProviders)
+		auth.GET("/oidc/:provider/authorize", oidcHandler.Authorize)
+		auth.POST("/oidc/:provider/callback", middleware.OptionalAuth(cfg, repo), oidcHandler.Callback)
+
+		authorized := auth.Group("")
+		authorized.Use(middleware.OptionalAuth(cfg, repo))
+		authorized.Use(middleware.RequireAuth())
+		authorized.POST("/resend-verification", authHandler.ResendVerification)
 	}
 }
 
 func SetupUserRoutes(router *gin.RouterGroup, postgres *db.PostgreSQL, cfg *config.Config) {
+	repo := repository.NewRepository(postgres)
 	userHandler := handlers.NewUserHandler(postgres, cfg)
+	authHandler := handlers.NewAuthHandler(repo, cfg)
+	oidcHandler := handlers.NewOIDCHandler(repo, cfg)
+	reviewHandler := handlers.NewReviewHandler(postgres, cfg)
 
 	users := router.Group("/users")
 
+	users.GET("/exports/:token", userHandler.DownloadDataExport)
+
 	authorized := users.Group("")
-	authorized.Use(middleware.OptionalAuth(cfg))
+	authorized.Use(middleware.OptionalAuth(cfg, repo))
 	authorized.Use(middleware.RequireAuth())
 
 	authorized.GET("/me", userHandler.GetProfile)
 	authorized.PUT("/me", userHandler.UpdateProfile)
+	authorized.DELETE("/me", userHandler.DeleteAccount)
+	authorized.POST("/me/restore", userHandler.CancelAccountDeletion)
+	authorized.POST("/me/export", userHandler.RequestDataExport)
+	authorized.GET("/me/exports", userHandler.GetDataExports)
+	authorized.POST("/me/email", authHandler.RequestEmailChange)
 	authorized.GET("/me/reviews", userHandler.GetUserReviews)
+	authorized.GET("/me/companies", userHandler.GetCompanyClaims)
+	authorized.GET("/me/sessions", userHandler.GetSessions)
+	authorized.DELETE("/me/sessions", userHandler.RevokeOtherSessions)
+	authorized.DELETE("/me/sessions/:sessionId", userHandler.RevokeSession)
+	authorized.POST("/me/2fa/setup", authHandler.SetupTwoFactor)
+	authorized.POST("/me/2fa/enable", authHandler.EnableTwoFactor)
+	authorized.POST("/me/2fa/disable", authHandler.DisableTwoFactor)
+	authorized.POST("/me/2fa/recovery-codes", authHandler.RegenerateRecoveryCodes)
+	authorized.GET("/me/identities", oidcHandler.GetIdentities)
+	authorized.POST("/me/identities/:provider", oidcHandler.LinkIdentity)
+	authorized.DELETE("/me/identities/:provider", oidcHandler.UnlinkIdentity)
+	authorized.GET("/me/drafts", reviewHandler.GetReviewDrafts)
+	authorized.POST("/me/drafts", reviewHandler.CreateReviewDraft)
+	authorized.GET("/me/drafts/:id", reviewHandler.GetReviewDraft)
+	authorized.PUT("/me/drafts/:id", reviewHandler.UpdateReviewDraft)
+	authorized.DELETE("/me/drafts/:id", reviewHandler.DeleteReviewDraft)
+
+	verified := authorized.Group("")
+	verified.Use(middleware.RequireVerifiedEmail(repo))
+
+	verified.POST("/me/drafts/:id/submit", middleware.Idempotency(repo, cfg.Security.IdempotencyKeyTTL), reviewHandler.SubmitReviewDraft)
 }
 
 func SetupCompanyRoutes(router *gin.RouterGroup, postgres *db.PostgreSQL, cfg *config.Config) {
+	repo := repository.NewRepository(postgres)
 	companyHandler := handlers.NewCompanyHandler(postgres, cfg)
 
 	companies := router.Group("/companies")
 
-	companies.GET("", companyHandler.GetCompanies)
-	companies.GET("/:id", companyHandler.GetCompany)
+	public := companies.Group("")
+	public.Use(middleware.APIKeyAuth(repo, models.APIKeyScopeCompaniesRead))
+
+	public.GET("", companyHandler.GetCompanies)
+	public.GET("/:id", companyHandler.GetCompany)
 
 	authorized := companies.Group("")
-	authorized.Use(middleware.OptionalAuth(cfg))
+	authorized.Use(middleware.OptionalAuth(cfg, repo))
 	authorized.Use(middleware.RequireAuth())
+
+	authorized.POST("/:id/claims/confirm", companyHandler.ConfirmCompanyClaim)
+	authorized.PUT("/:id/profile", companyHandler.UpdateCompanyProfile)
+
+	verified := authorized.Group("")
+	verified.Use(middleware.RequireVerifiedEmail(repo))
+
+	verified.POST("/:id/claims", companyHandler.SubmitCompanyClaim)
 }
 
 func SetupReviewRoutes(router *gin.RouterGroup, postgres *db.PostgreSQL, cfg *config.Config) {
+	repo := repository.NewRepository(postgres)
 	reviewHandler := handlers.NewReviewHandler(postgres, cfg)
 
 	reviews := router.Group("/reviews")
 
 	optionalAuth := reviews.Group("")
-	optionalAuth.Use(middleware.OptionalAuth(cfg))
+	optionalAuth.Use(middleware.APIKeyAuth(repo, models.APIKeyScopeReviewsRead))
+	optionalAuth.Use(middleware.OptionalAuth(cfg, repo))
 
 	optionalAuth.GET("/:id", reviewHandler.GetReview)
 	optionalAuth.GET("/company/:companyId", reviewHandler.GetCompanyReviews)
 
 	authorized := reviews.Group("")
-	authorized.Use(middleware.OptionalAuth(cfg))
+	authorized.Use(middleware.OptionalAuth(cfg, repo))
 	authorized.Use(middleware.RequireAuth())
 
-	authorized.POST("", reviewHandler.CreateReview)
-	authorized.POST("/:id/useful", reviewHandler.MarkReviewAsUseful)
-	authorized.DELETE("/:id/useful", reviewHandler.RemoveUsefulMark)
+	authorized.GET("/:id/revisions", reviewHandler.GetReviewRevisions)
+	authorized.POST("/:id/withdraw", reviewHandler.WithdrawReview)
+	authorized.GET("/:id/response", reviewHandler.GetReviewResponse)
+	authorized.DELETE("/:id/response", reviewHandler.DeleteReviewResponse)
+
+	verified := authorized.Group("")
+	verified.Use(middleware.RequireVerifiedEmail(repo))
+
+	verified.POST("", middleware.Idempotency(repo, cfg.Security.IdempotencyKeyTTL), reviewHandler.CreateReview)
+	verified.PUT("/:id", reviewHandler.UpdateReview)
+	verified.PUT("/:id/response", reviewHandler.SaveReviewResponse)
+	verified.POST("/:id/useful", reviewHandler.MarkReviewAsUseful)
+	verified.POST("/:id/flags", reviewHandler.FlagReview)
+	verified.DELETE("/:id/useful", reviewHandler.RemoveUsefulMark)
 
 	moderation := reviews.Group("")
-	moderation.Use(middleware.OptionalAuth(cfg))
+	moderation.Use(middleware.OptionalAuth(cfg, repo))
 	moderation.Use(middleware.RequireAuth())
 }
 
@@ -87,6 +163,7 @@ func SetupCityRoutes(router *gin.RouterGroup, postgres *db.PostgreSQL, cfg *conf
 }
 
 func SetupIndustryRoutes(router *gin.RouterGroup, postgres *db.PostgreSQL, cfg *config.Config) {
+	repo := repository.NewRepository(postgres)
 	industryHandler := handlers.NewIndustryHandler(postgres, cfg)
 
 	industries := router.Group("/industries")
@@ -95,7 +172,7 @@ func SetupIndustryRoutes(router *gin.RouterGroup, postgres *db.PostgreSQL, cfg *
 		industries.GET("/company/:id", industryHandler.GetCompanyIndustries)
 
 		authorized := industries.Group("")
-		authorized.Use(middleware.OptionalAuth(cfg))
+		authorized.Use(middleware.OptionalAuth(cfg, repo))
 		authorized.Use(middleware.RequireAuth())
 		authorized.PUT("/:id/color", industryHandler.UpdateIndustryColor)
 	}
@@ -148,20 +225,38 @@ func SetupAdminRoutes(router *gin.RouterGroup, postgres *db.PostgreSQL, cfg *con
 	reviewHandler := handlers.NewReviewHandler(postgres, cfg)
 
 	admin := router.Group("/admin")
-	admin.Use(middleware.OptionalAuth(cfg))
+	admin.Use(middleware.OptionalAuth(cfg, repo))
 	admin.Use(middleware.RequireAuth())
 	admin.Use(middleware.RequireRoleMiddleware(models.RoleAdmin))
+	admin.Use(middleware.RequireTwoFactorForStaff(cfg))
 
 	admin.GET("/statistics", adminHandler.GetStatistics)
 
 	admin.POST("/companies", companyHandler.CreateCompany)
 	admin.PUT("/companies/:id", companyHandler.UpdateCompany)
 	admin.DELETE("/companies/:id", companyHandler.DeleteCompany)
+	admin.GET("/companies/:id/representatives", adminHandler.GetCompanyRepresentatives)
+	admin.POST("/companies/:id/representatives", adminHandler.AddCompanyRepresentative)
+	admin.DELETE("/companies/:id/representatives/:userId", adminHandler.RevokeCompanyRepresentative)
+	admin.GET("/representatives", adminHandler.GetCompanyClaims)
+	admin.PUT("/representatives/:claimId/approve", adminHandler.ApproveCompanyClaim)
+	admin.PUT("/representatives/:claimId/reject", adminHandler.RejectCompanyClaim)
 
 	admin.GET("/users", adminHandler.GetUsers)
 	admin.GET("/users/:id", adminHandler.GetUser)
 	admin.PUT("/users/:id/role", adminHandler.UpdateUserRole)
 	admin.DELETE("/users/:id", adminHandler.DeleteUser)
+	admin.POST("/users/:id/unlock", adminHandler.UnlockUserLogin)
+	admin.GET("/users/:id/login-attempts", adminHandler.GetUserLoginAttempts)
+	admin.GET("/users/:id/sessions", adminHandler.GetUserSessions)
+	admin.DELETE("/users/:id/sessions", adminHandler.RevokeUserSessions)
+	admin.DELETE("/users/:id/sessions/:sessionId", adminHandler.RevokeUserSession)
+	admin.POST("/users/:id/export", adminHandler.RequestUserDataExport)
+	admin.GET("/users/:id/exports", adminHandler.GetUserDataExports)
+
+	admin.GET("/api-keys", adminHandler.GetAPIKeys)
+	admin.POST("/api-keys", adminHandler.CreateAPIKey)
+	admin.DELETE("/api-keys/:id", adminHandler.RevokeAPIKey)
 
 	admin.POST("/rating-categories", adminHandler.CreateRatingCategory)
 	admin.PUT("/rating-categories/:id", adminHandler.UpdateRatingCategory)
@@ -172,6 +267,15 @@ func SetupAdminRoutes(router *gin.RouterGroup, postgres *db.PostgreSQL, cfg *con
 	admin.GET("/reviews/moderation/pending", reviewHandler.GetPendingReviews)
 	admin.GET("/reviews/moderation/approved", reviewHandler.GetApprovedReviews)
 	admin.GET("/reviews/moderation/rejected", reviewHandler.GetRejectedReviews)
+	admin.GET("/reviews/moderation/revisions", reviewHandler.GetPendingRevisions)
+	admin.PUT("/reviews/revisions/:revisionId/approve", reviewHandler.ApproveRevision)
+	admin.PUT("/reviews/revisions/:revisionId/reject", reviewHandler.RejectRevision)
+	admin.GET("/reviews/moderation/responses", reviewHandler.GetPendingResponses)
+	admin.GET("/reviews/moderation/flags", reviewHandler.GetFlaggedReviews)
+	admin.PUT("/reviews/:id/flags/dismiss", reviewHandler.DismissReviewFlags)
+	admin.PUT("/reviews/:id/flags/act", reviewHandler.ActOnReviewFlags)
+	admin.PUT("/reviews/responses/:responseId/approve", reviewHandler.ApproveResponse)
+	admin.PUT("/reviews/responses/:responseId/reject", reviewHandler.RejectResponse)
 	admin.PUT("/reviews/:id/approve", reviewHandler.ApproveReview)
 	admin.PUT("/reviews/:id/reject", reviewHandler.RejectReview)
 
@@ -201,12 +305,13 @@ func SetupSuggestionRoutes(router *gin.RouterGroup, repo *repository.Repository,
 
 	suggestions := router.Group("/suggestions")
 
-	suggestions.POST("", suggestionHandler.CreateSuggestion)
+	suggestions.POST("", middleware.OptionalAuth(cfg, repo), suggestionHandler.CreateSuggestion)
 
 	adminSuggestions := suggestions.Group("")
-	adminSuggestions.Use(middleware.OptionalAuth(cfg))
+	adminSuggestions.Use(middleware.OptionalAuth(cfg, repo))
 	adminSuggestions.Use(middleware.RequireAuth())
 	adminSuggestions.Use(middleware.RequireRoleMiddleware(models.RoleAdmin))
+	adminSuggestions.Use(middleware.RequireTwoFactorForStaff(cfg))
 
 	adminSuggestions.GET("", suggestionHandler.GetAllSuggestions)
 	adminSuggestions.DELETE("/:id", suggestionHandler.DeleteSuggestion)
@@ -215,6 +320,9 @@ func SetupSuggestionRoutes(router *gin.RouterGroup, repo *repository.Repository,
 func SetupAllRoutes(router *gin.Engine, postgres *db.PostgreSQL, cfg *config.Config) {
 	repo := repository.NewRepository(postgres)
 
+	jwksHandler := handlers.NewJWKSHandler(cfg)
+	router.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
+
 	api := router.Group("/api")
 
 	SetupAuthRoutes(api, postgres, cfg)
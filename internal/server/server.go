@@ -8,7 +8,9 @@ import (
 	_ "job_solition/docs"
 	"job_solition/internal/config"
 	"job_solition/internal/db"
+	"job_solition/internal/jobs"
 	"job_solition/internal/middleware"
+	"job_solition/internal/repository"
 	"job_solition/internal/routes"
 	"job_solition/internal/utils"
 
@@ -22,6 +24,10 @@ type Server struct {
 	router     *gin.Engine
 	httpServer *http.Server
 	postgres   *db.PostgreSQL
+
+	accountDeletion       *jobs.AccountDeletionJob
+	reviewDraftPurge      *jobs.ReviewDraftPurgeJob
+	idempotencyKeyCleanup *jobs.IdempotencyKeyCleanupJob
 }
 
 func NewServer(cfg *config.Config) *Server {
@@ -40,10 +46,26 @@ func NewServer(cfg *config.Config) *Server {
 		panic(fmt.Errorf("ошибка при инициализации базы данных: %w", err))
 	}
 
+	repo := repository.NewRepository(postgres)
+
 	srv := &Server{
 		config:   cfg,
 		router:   router,
 		postgres: postgres,
+		accountDeletion: jobs.NewAccountDeletionJob(
+			repo,
+			cfg.Security.AccountDeletion.CheckInterval,
+		),
+		reviewDraftPurge: jobs.NewReviewDraftPurgeJob(
+			repo,
+			cfg.ReviewDrafts.PurgeInterval,
+			cfg.ReviewDrafts.TTL,
+		),
+		idempotencyKeyCleanup: jobs.NewIdempotencyKeyCleanupJob(
+			repo,
+			cfg.Security.IdempotencyKeyCleanupInterval,
+			cfg.Security.IdempotencyKeyTTL,
+		),
 		httpServer: &http.Server{
 			Addr:    ":" + cfg.Server.Port,
 			Handler: router,
@@ -76,10 +98,18 @@ func (s *Server) setupServer() {
 }
 
 func (s *Server) Start() error {
+	s.accountDeletion.Start()
+	s.reviewDraftPurge.Start()
+	s.idempotencyKeyCleanup.Start()
+
 	return s.httpServer.ListenAndServe()
 }
 
 func (s *Server) Stop(ctx context.Context) error {
+	s.accountDeletion.Stop()
+	s.reviewDraftPurge.Stop()
+	s.idempotencyKeyCleanup.Stop()
+
 	if err := s.postgres.Close(); err != nil {
 		return fmt.Errorf("ошибка при закрытии соединения с PostgreSQL: %w", err)
 	}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	apiKeyTag        = "jsk"
+	apiKeyPrefixSize = 4
+	apiKeySecretSize = 32
+)
+
+// GenerateAPIKey создает ключ вида jsk_<prefix>_<secret>. Открытая часть prefix позволяет узнать ключ
+// в списке, не храня его самого.
+func GenerateAPIKey() (key, prefix string, err error) {
+	rawPrefix := make([]byte, apiKeyPrefixSize)
+	if _, err := rand.Read(rawPrefix); err != nil {
+		return "", "", fmt.Errorf("ошибка при генерации API ключа: %w", err)
+	}
+
+	secret := make([]byte, apiKeySecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", fmt.Errorf("ошибка при генерации API ключа: %w", err)
+	}
+
+	prefix = apiKeyTag + "_" + hex.EncodeToString(rawPrefix)
+	key = prefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+
+	return key, prefix, nil
+}
+
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+)
+
+const verificationCodeDigits = 6
+
+// GenerateVerificationCode создает одноразовый цифровой код для подтверждения email.
+func GenerateVerificationCode() (string, error) {
+	limit := big.NewInt(1)
+	for i := 0; i < verificationCodeDigits; i++ {
+		limit.Mul(limit, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", fmt.Errorf("ошибка при генерации кода подтверждения: %w", err)
+	}
+
+	return fmt.Sprintf("%0*d", verificationCodeDigits, n.Int64()), nil
+}
+
+func HashVerificationCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// WebsiteDomain возвращает домен сайта без схемы, порта и префикса www.
+func WebsiteDomain(website string) string {
+	website = strings.TrimSpace(website)
+	if website == "" {
+		return ""
+	}
+	if !strings.Contains(website, "://") {
+		website = "https://" + website
+	}
+
+	parsed, err := url.Parse(website)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
+
+// EmailMatchesDomain сообщает, принадлежит ли email домену или одному из его поддоменов.
+func EmailMatchesDomain(email, domain string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 || domain == "" {
+		return false
+	}
+
+	emailDomain := strings.ToLower(email[at+1:])
+	return emailDomain == domain || strings.HasSuffix(emailDomain, "."+domain)
+}
@@ -4,48 +4,124 @@ import (
 	"fmt"
 	"time"
 
+	"job_solition/internal/config"
 	"job_solition/internal/models"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
 type JWT struct {
-	Secret           string
 	ExpiresIn        time.Duration
 	RefreshExpiresIn time.Duration
+
+	method           jwt.SigningMethod
+	keyID            string
+	signingKey       interface{}
+	verificationKeys map[string]interface{}
 }
 
+const (
+	ChallengePurposeTwoFactorLogin = "2fa_login"
+	ChallengePurposeTwoFactorSetup = "2fa_setup"
+)
+
+// Аудитории (aud) токенов. Сервисы, проверяющие access токены по JWKS, должны требовать AccessTokenAudience,
+// иначе они примут и токены промежуточного шага входа, подписанные тем же ключом.
+const (
+	AccessTokenAudience    = "job_solition:access"
+	ChallengeTokenAudience = "job_solition:challenge"
+)
+
 type AuthClaims struct {
-	UserID int             `json:"user_id"`
-	Email  string          `json:"email"`
-	Role   models.UserRole `json:"role"`
+	UserID    int             `json:"user_id"`
+	Email     string          `json:"email"`
+	Role      models.UserRole `json:"role"`
+	SessionID string          `json:"sid,omitempty"`
+	TwoFactor bool            `json:"mfa,omitempty"`
+	Version   int             `json:"ver,omitempty"`
+	Purpose   string          `json:"purpose,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func NewJWT(secret string, expiresIn, refreshExpiresIn time.Duration) *JWT {
-	return &JWT{
-		Secret:           secret,
-		ExpiresIn:        expiresIn,
-		RefreshExpiresIn: refreshExpiresIn,
+// NewJWT создает выпускающего и проверяющего токены по настройкам. Для HS256 используется общий секрет,
+// для RS256 и EdDSA - закрытый ключ для подписи и набор открытых ключей (по kid) для проверки.
+func NewJWT(cfg config.JWTConfig) *JWT {
+	j := &JWT{
+		ExpiresIn:        cfg.ExpiresIn,
+		RefreshExpiresIn: cfg.RefreshExpiresIn,
+		keyID:            cfg.KeyID,
+		verificationKeys: map[string]interface{}{},
 	}
+
+	switch cfg.Algorithm {
+	case config.JWTAlgorithmRS256:
+		j.method = jwt.SigningMethodRS256
+	case config.JWTAlgorithmEdDSA:
+		j.method = jwt.SigningMethodEdDSA
+	default:
+		j.method = jwt.SigningMethodHS256
+		j.signingKey = []byte(cfg.Secret)
+		j.verificationKeys[cfg.KeyID] = []byte(cfg.Secret)
+		return j
+	}
+
+	j.signingKey = cfg.SigningKey
+	for kid, key := range cfg.VerificationKeys {
+		j.verificationKeys[kid] = key
+	}
+
+	return j
 }
 
-func (j *JWT) GenerateToken(user *models.User) (string, error) {
+// GenerateToken выпускает access токен для сессии, которой принадлежит refresh токен session.
+func (j *JWT) GenerateToken(user *models.User, session *models.RefreshToken) (string, error) {
 	now := time.Now()
 	claims := &AuthClaims{
-		UserID: user.ID,
-		Email:  user.Email,
-		Role:   user.Role,
+		UserID:    user.ID,
+		Email:     user.Email,
+		Role:      user.Role,
+		SessionID: session.FamilyID,
+		TwoFactor: session.TwoFactorVerified,
+		Version:   user.TokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{AccessTokenAudience},
 			ExpiresAt: jwt.NewNumericDate(now.Add(j.ExpiresIn)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return j.sign(claims)
+}
+
+// GenerateChallengeToken выпускает короткоживущий токен промежуточного шага входа (например, ввода кода 2FA).
+// Такой токен не принимается как access токен и, как и access токен, содержит версию токенов пользователя.
+func (j *JWT) GenerateChallengeToken(user *models.User, purpose string, expiresIn time.Duration) (string, error) {
+	now := time.Now()
+	claims := &AuthClaims{
+		UserID:  user.ID,
+		Email:   user.Email,
+		Role:    user.Role,
+		Version: user.TokenVersion,
+		Purpose: purpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{ChallengeTokenAudience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(expiresIn)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+
+	return j.sign(claims)
+}
 
-	tokenString, err := token.SignedString([]byte(j.Secret))
+func (j *JWT) sign(claims *AuthClaims) (string, error) {
+	token := jwt.NewWithClaims(j.method, claims)
+	if j.keyID != "" {
+		token.Header["kid"] = j.keyID
+	}
+
+	tokenString, err := token.SignedString(j.signingKey)
 	if err != nil {
 		return "", fmt.Errorf("ошибка подписи токена: %w", err)
 	}
@@ -54,12 +130,44 @@ func (j *JWT) GenerateToken(user *models.User) (string, error) {
 }
 
 func (j *JWT) ValidateToken(tokenString string) (*AuthClaims, error) {
+	claims, err := j.parse(tokenString, AccessTokenAudience)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Purpose != "" {
+		return nil, fmt.Errorf("токен не предназначен для авторизации")
+	}
+
+	return claims, nil
+}
+
+func (j *JWT) ValidateChallengeToken(tokenString, purpose string) (*AuthClaims, error) {
+	claims, err := j.parse(tokenString, ChallengeTokenAudience)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Purpose != purpose {
+		return nil, fmt.Errorf("неверное назначение токена")
+	}
+
+	return claims, nil
+}
+
+func (j *JWT) parse(tokenString, audience string) (*AuthClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &AuthClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("неверный метод подписи: %v", token.Header["alg"])
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			kid = j.keyID
+		}
+
+		key, ok := j.verificationKeys[kid]
+		if !ok {
+			return nil, fmt.Errorf("неизвестный ключ подписи: %s", kid)
 		}
-		return []byte(j.Secret), nil
-	})
+		return key, nil
+	}, jwt.WithValidMethods([]string{j.method.Alg()}), jwt.WithAudience(audience))
 
 	if err != nil {
 		return nil, fmt.Errorf("ошибка валидации токена: %w", err)
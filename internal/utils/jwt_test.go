@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"job_solition/internal/config"
+	"job_solition/internal/models"
+)
+
+func newTestJWT() *JWT {
+	return NewJWT(config.JWTConfig{
+		Secret:           "test-secret",
+		ExpiresIn:        time.Hour,
+		RefreshExpiresIn: 24 * time.Hour,
+	})
+}
+
+func TestChallengeTokenCarriesTokenVersion(t *testing.T) {
+	j := newTestJWT()
+	user := &models.User{ID: 7, Email: "admin@example.com", Role: models.RoleAdmin, TokenVersion: 3}
+
+	token, err := j.GenerateChallengeToken(user, ChallengePurposeTwoFactorLogin, 5*time.Minute)
+	if err != nil {
+		t.Fatalf("ошибка при создании challenge токена: %v", err)
+	}
+
+	claims, err := j.ValidateChallengeToken(token, ChallengePurposeTwoFactorLogin)
+	if err != nil {
+		t.Fatalf("challenge токен отклонен: %v", err)
+	}
+	if claims.UserID != user.ID || claims.Version != user.TokenVersion {
+		t.Fatalf("получены user_id=%d ver=%d, ожидались %d и %d", claims.UserID, claims.Version, user.ID, user.TokenVersion)
+	}
+}
+
+func TestChallengeTokenIsNotAccessToken(t *testing.T) {
+	j := newTestJWT()
+	user := &models.User{ID: 7, Email: "admin@example.com", Role: models.RoleAdmin}
+
+	token, err := j.GenerateChallengeToken(user, ChallengePurposeTwoFactorSetup, 5*time.Minute)
+	if err != nil {
+		t.Fatalf("ошибка при создании challenge токена: %v", err)
+	}
+
+	if _, err := j.ValidateToken(token); err == nil {
+		t.Fatal("challenge токен принят как access токен")
+	}
+	if _, err := j.ValidateChallengeToken(token, ChallengePurposeTwoFactorLogin); err == nil {
+		t.Fatal("challenge токен принят с другим назначением")
+	}
+}
+
+func TestTokensCarryDistinctAudiences(t *testing.T) {
+	j := newTestJWT()
+	user := &models.User{ID: 7, Email: "admin@example.com", Role: models.RoleAdmin}
+
+	accessToken, err := j.GenerateToken(user, &models.RefreshToken{FamilyID: "family"})
+	if err != nil {
+		t.Fatalf("ошибка при создании access токена: %v", err)
+	}
+	claims, err := j.ValidateToken(accessToken)
+	if err != nil {
+		t.Fatalf("access токен отклонен: %v", err)
+	}
+	if len(claims.Audience) != 1 || claims.Audience[0] != AccessTokenAudience {
+		t.Fatalf("aud access токена %v, ожидался %s", claims.Audience, AccessTokenAudience)
+	}
+	if _, err := j.ValidateChallengeToken(accessToken, ""); err == nil {
+		t.Fatal("access токен принят как challenge токен")
+	}
+
+	challengeToken, err := j.GenerateChallengeToken(user, ChallengePurposeTwoFactorLogin, 5*time.Minute)
+	if err != nil {
+		t.Fatalf("ошибка при создании challenge токена: %v", err)
+	}
+	claims, err = j.ValidateChallengeToken(challengeToken, ChallengePurposeTwoFactorLogin)
+	if err != nil {
+		t.Fatalf("challenge токен отклонен: %v", err)
+	}
+	if len(claims.Audience) != 1 || claims.Audience[0] != ChallengeTokenAudience {
+		t.Fatalf("aud challenge токена %v, ожидался %s", claims.Audience, ChallengeTokenAudience)
+	}
+}
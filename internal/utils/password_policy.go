@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"job_solition/internal/config"
+	"job_solition/internal/models"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// passwordMaxBytes - ограничение bcrypt: байты после 72-го не участвуют в хеше.
+const passwordMaxBytes = 72
+
+// minPersonalInfoLength - части email и имени короче этого значения не проверяются на вхождение в пароль.
+const minPersonalInfoLength = 3
+
+// PasswordPolicy проверяет новый пароль на всех путях, где он задается: регистрация, смена в профиле, сброс.
+type PasswordPolicy struct {
+	cfg config.PasswordPolicyConfig
+}
+
+func NewPasswordPolicy(cfg config.PasswordPolicyConfig) *PasswordPolicy {
+	return &PasswordPolicy{cfg: cfg}
+}
+
+func (p *PasswordPolicy) HistorySize() int {
+	return p.cfg.HistorySize
+}
+
+// Validate возвращает нарушенные правила политики, пустой список означает, что пароль подходит.
+// previousHashes - bcrypt-хеши текущего и предыдущих паролей пользователя.
+func (p *PasswordPolicy) Validate(password string, user *models.User, previousHashes []string) []ValidationError {
+	var errors []ValidationError
+	add := func(message string) {
+		errors = append(errors, ValidationError{Field: "password", Message: message})
+	}
+
+	if len([]rune(password)) < p.cfg.MinLength {
+		add(fmt.Sprintf("Пароль должен содержать не менее %d символов", p.cfg.MinLength))
+	}
+	if len(password) > passwordMaxBytes {
+		add(fmt.Sprintf("Пароль не должен быть длиннее %d байт", passwordMaxBytes))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSpecial = true
+		}
+	}
+
+	if p.cfg.RequireUpper && !hasUpper {
+		add("Пароль должен содержать заглавную букву")
+	}
+	if p.cfg.RequireLower && !hasLower {
+		add("Пароль должен содержать строчную букву")
+	}
+	if p.cfg.RequireDigit && !hasDigit {
+		add("Пароль должен содержать цифру")
+	}
+	if p.cfg.RequireSpecial && !hasSpecial {
+		add("Пароль должен содержать специальный символ")
+	}
+
+	if user != nil && containsPersonalInfo(password, user) {
+		add("Пароль не должен содержать email или имя")
+	}
+
+	if _, breached := p.cfg.BreachedPasswords[strings.ToLower(password)]; breached {
+		add("Этот пароль встречается в утечках данных, выберите другой")
+	}
+
+	if p.cfg.HistorySize > 0 {
+		for _, hash := range previousHashes {
+			if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+				add(fmt.Sprintf("Пароль не должен совпадать с одним из %d последних паролей", p.cfg.HistorySize))
+				break
+			}
+		}
+	}
+
+	return errors
+}
+
+func containsPersonalInfo(password string, user *models.User) bool {
+	lowered := strings.ToLower(password)
+
+	parts := []string{user.FirstName, user.LastName}
+	if at := strings.Index(user.Email, "@"); at > 0 {
+		parts = append(parts, user.Email[:at])
+	}
+
+	for _, part := range parts {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if len([]rune(part)) >= minPersonalInfoLength && strings.Contains(lowered, part) {
+			return true
+		}
+	}
+
+	return false
+}
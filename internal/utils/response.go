@@ -39,6 +39,21 @@ func ErrorResponse(c *gin.Context, statusCode int, message string, err error) {
 	c.JSON(statusCode, response)
 }
 
+// ErrorResponseWithDetails отдает ошибку с дополнительными полями в объекте error (например, locked_until).
+func ErrorResponseWithDetails(c *gin.Context, statusCode int, message string, details gin.H) {
+	errorBody := gin.H{
+		"message": message,
+	}
+	for key, value := range details {
+		errorBody[key] = value
+	}
+
+	c.JSON(statusCode, gin.H{
+		"success": false,
+		"error":   errorBody,
+	})
+}
+
 type ValidationError struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
@@ -0,0 +1,142 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	totpSecretSize    = 20
+	recoveryCodeCount = 10
+	recoveryCodeSize  = 10
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTP реализует одноразовые пароли по времени (RFC 6238): HMAC-SHA1, 6 цифр, шаг 30 секунд.
+// Текущее время берется из Now, поэтому в тестах его можно подменить.
+type TOTP struct {
+	Issuer string
+	Period time.Duration
+	Digits int
+	Skew   int64
+	Now    func() time.Time
+}
+
+func NewTOTP(issuer string) *TOTP {
+	return &TOTP{
+		Issuer: issuer,
+		Period: 30 * time.Second,
+		Digits: 6,
+		Skew:   1,
+		Now:    time.Now,
+	}
+}
+
+func GenerateTOTPSecret() (string, error) {
+	secret := make([]byte, totpSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("ошибка при генерации секрета TOTP: %w", err)
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// URI возвращает ссылку otpauth:// для добавления аккаунта в приложение-аутентификатор.
+func (t *TOTP) URI(account, secret string) string {
+	label := url.PathEscape(t.Issuer + ":" + account)
+
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", t.Issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprintf("%d", t.Digits))
+	params.Set("period", fmt.Sprintf("%d", int(t.Period.Seconds())))
+
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+func (t *TOTP) Step(at time.Time) int64 {
+	return at.Unix() / int64(t.Period.Seconds())
+}
+
+func (t *TOTP) Code(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("неверный формат секрета TOTP: %w", err)
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < t.Digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", t.Digits, value%mod), nil
+}
+
+// Validate проверяет код с учетом допустимого расхождения часов и возвращает шаг, которому он соответствует.
+// Коды для шагов не позже lastStep отклоняются, чтобы один и тот же код нельзя было использовать повторно.
+func (t *TOTP) Validate(secret, code string, lastStep int64) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != t.Digits {
+		return 0, false
+	}
+
+	current := t.Step(t.Now())
+	for step := current - t.Skew; step <= current+t.Skew; step++ {
+		if step <= lastStep {
+			continue
+		}
+
+		expected, err := t.Code(secret, step)
+		if err != nil {
+			return 0, false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// GenerateRecoveryCodes создает набор одноразовых кодов восстановления вида xxxxx-xxxxx.
+func GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, fmt.Errorf("ошибка при генерации кодов восстановления: %w", err)
+		}
+
+		code := strings.ToLower(totpEncoding.EncodeToString(raw))[:recoveryCodeSize]
+		codes = append(codes, code[:5]+"-"+code[5:])
+	}
+	return codes, nil
+}
+
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
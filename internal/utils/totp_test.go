@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+func newTestTOTP(now *time.Time) *TOTP {
+	totp := NewTOTP("JobSolution")
+	totp.Now = func() time.Time {
+		return *now
+	}
+	return totp
+}
+
+func mustCode(t *testing.T, totp *TOTP, step int64) string {
+	t.Helper()
+
+	code, err := totp.Code(testTOTPSecret, step)
+	if err != nil {
+		t.Fatalf("ошибка при получении кода: %v", err)
+	}
+	return code
+}
+
+func TestTOTPValidateTimeWindow(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	totp := newTestTOTP(&now)
+	current := totp.Step(now)
+
+	for _, step := range []int64{current - 1, current, current + 1} {
+		got, ok := totp.Validate(testTOTPSecret, mustCode(t, totp, step), 0)
+		if !ok || got != step {
+			t.Fatalf("код шага %d: получено (%d, %v), ожидалось (%d, true)", step, got, ok, step)
+		}
+	}
+
+	for _, step := range []int64{current - 2, current + 2} {
+		if _, ok := totp.Validate(testTOTPSecret, mustCode(t, totp, step), 0); ok {
+			t.Fatalf("код шага %d вне окна принят", step)
+		}
+	}
+
+	code := mustCode(t, totp, current)
+	now = now.Add(2 * totp.Period)
+	if _, ok := totp.Validate(testTOTPSecret, code, 0); ok {
+		t.Fatal("код принят через два периода после выпуска")
+	}
+}
+
+func TestTOTPValidateRejectsUsedStep(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	totp := newTestTOTP(&now)
+	current := totp.Step(now)
+	code := mustCode(t, totp, current)
+
+	step, ok := totp.Validate(testTOTPSecret, code, 0)
+	if !ok {
+		t.Fatal("действующий код отклонен")
+	}
+
+	if _, ok := totp.Validate(testTOTPSecret, code, step); ok {
+		t.Fatal("повторно использованный код принят")
+	}
+
+	now = now.Add(totp.Period)
+	if _, ok := totp.Validate(testTOTPSecret, mustCode(t, totp, current+1), step); !ok {
+		t.Fatal("код следующего шага отклонен")
+	}
+}
+
+func TestTOTPValidateRejectsMalformedCode(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	totp := newTestTOTP(&now)
+
+	for _, code := range []string{"", "12345", "1234567"} {
+		if _, ok := totp.Validate(testTOTPSecret, code, 0); ok {
+			t.Fatalf("код %q принят", code)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+SET client_min_messages TO WARNING;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP;
+
+UPDATE users
+SET email_verified_at = created_at
+WHERE role IN ('admin', 'moderator') AND email_verified_at IS NULL;
+
+COMMENT ON COLUMN users.email_verified_at IS 'Время подтверждения email пользователя';
+
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token VARCHAR(255) NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_token ON email_verification_tokens(token);
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_user_id ON email_verification_tokens(user_id);
+
+COMMENT ON TABLE email_verification_tokens IS 'Токены для подтверждения email пользователей';
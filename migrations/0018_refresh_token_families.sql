@@ -0,0 +1,31 @@
+SET client_min_messages TO WARNING;
+
+ALTER TABLE refresh_tokens
+    ADD COLUMN IF NOT EXISTS family_id UUID,
+    ADD COLUMN IF NOT EXISTS rotated_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS revoked_at TIMESTAMP;
+
+UPDATE refresh_tokens SET family_id = gen_random_uuid() WHERE family_id IS NULL;
+
+ALTER TABLE refresh_tokens ALTER COLUMN family_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+
+COMMENT ON COLUMN refresh_tokens.family_id IS 'Семейство токенов: все токены, полученные ротацией в рамках одного входа';
+COMMENT ON COLUMN refresh_tokens.rotated_at IS 'Время ротации токена (токен заменен новым и больше не может использоваться)';
+COMMENT ON COLUMN refresh_tokens.revoked_at IS 'Время отзыва токена';
+
+CREATE TABLE IF NOT EXISTS security_events (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    details TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_security_events_user_id ON security_events(user_id);
+CREATE INDEX IF NOT EXISTS idx_security_events_event_type ON security_events(event_type);
+
+COMMENT ON TABLE security_events IS 'Журнал событий безопасности (повторное использование токенов и т.п.)';
@@ -0,0 +1,17 @@
+SET client_min_messages TO WARNING;
+
+ALTER TABLE refresh_tokens
+    ADD COLUMN IF NOT EXISTS user_agent TEXT,
+    ADD COLUMN IF NOT EXISTS ip_address VARCHAR(45),
+    ADD COLUMN IF NOT EXISTS session_started_at TIMESTAMP;
+
+UPDATE refresh_tokens SET session_started_at = created_at WHERE session_started_at IS NULL;
+
+ALTER TABLE refresh_tokens ALTER COLUMN session_started_at SET NOT NULL;
+ALTER TABLE refresh_tokens ALTER COLUMN session_started_at SET DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+
+COMMENT ON COLUMN refresh_tokens.user_agent IS 'User-Agent клиента, получившего токен';
+COMMENT ON COLUMN refresh_tokens.ip_address IS 'IP-адрес клиента, получившего токен';
+COMMENT ON COLUMN refresh_tokens.session_started_at IS 'Время входа, с которого началось семейство токенов';
@@ -0,0 +1,28 @@
+SET client_min_messages TO WARNING;
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS totp_secret VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS totp_enabled_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS totp_last_step BIGINT;
+
+COMMENT ON COLUMN users.totp_secret IS 'Секрет TOTP в base32. Заполняется при начале подключения 2FA';
+COMMENT ON COLUMN users.totp_enabled_at IS 'Время подключения двухфакторной аутентификации (NULL - 2FA выключена)';
+COMMENT ON COLUMN users.totp_last_step IS 'Номер последнего принятого временного шага TOTP, защищает от повторного использования кода';
+
+ALTER TABLE refresh_tokens
+    ADD COLUMN IF NOT EXISTS two_factor_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN refresh_tokens.two_factor_verified IS 'Вход выполнен с подтверждением второго фактора';
+
+CREATE TABLE IF NOT EXISTS two_factor_recovery_codes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_two_factor_recovery_codes_user_id ON two_factor_recovery_codes(user_id);
+
+COMMENT ON TABLE two_factor_recovery_codes IS 'Одноразовые коды восстановления доступа при утере устройства с 2FA';
+COMMENT ON COLUMN two_factor_recovery_codes.code_hash IS 'SHA-256 от кода восстановления';
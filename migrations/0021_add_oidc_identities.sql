@@ -0,0 +1,31 @@
+SET client_min_messages TO WARNING;
+
+CREATE TABLE IF NOT EXISTS user_identities (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (provider, subject),
+    UNIQUE (user_id, provider)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_identities_user_id ON user_identities(user_id);
+
+COMMENT ON TABLE user_identities IS 'Учетные записи внешних провайдеров входа (OIDC), привязанные к пользователям';
+COMMENT ON COLUMN user_identities.subject IS 'Идентификатор пользователя у провайдера (claim sub)';
+
+CREATE TABLE IF NOT EXISTS oauth_states (
+    state VARCHAR(255) PRIMARY KEY,
+    provider VARCHAR(50) NOT NULL,
+    code_verifier VARCHAR(255) NOT NULL,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_oauth_states_expires_at ON oauth_states(expires_at);
+
+COMMENT ON TABLE oauth_states IS 'Незавершенные входы через внешних провайдеров: state и PKCE code_verifier';
+COMMENT ON COLUMN oauth_states.user_id IS 'Пользователь, привязывающий провайдера к своему аккаунту (NULL - обычный вход)';
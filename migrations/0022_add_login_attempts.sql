@@ -0,0 +1,24 @@
+SET client_min_messages TO WARNING;
+
+CREATE TABLE IF NOT EXISTS login_attempts (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    email VARCHAR(255) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL,
+    user_agent TEXT,
+    success BOOLEAN NOT NULL,
+    failure_reason VARCHAR(50),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_attempts_email_created_at ON login_attempts(email, created_at);
+CREATE INDEX IF NOT EXISTS idx_login_attempts_ip_created_at ON login_attempts(ip_address, created_at);
+CREATE INDEX IF NOT EXISTS idx_login_attempts_user_id ON login_attempts(user_id);
+
+COMMENT ON TABLE login_attempts IS 'Журнал попыток входа, используется для блокировки перебора паролей и аудита';
+COMMENT ON COLUMN login_attempts.email IS 'Email, введенный при входе (в нижнем регистре), в том числе несуществующий';
+COMMENT ON COLUMN login_attempts.failure_reason IS 'Причина неудачи: invalid_credentials, invalid_2fa_code';
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS login_unlocked_at TIMESTAMP;
+
+COMMENT ON COLUMN users.login_unlocked_at IS 'Время ручной разблокировки входа администратором. Неудачные попытки до этого момента не учитываются';
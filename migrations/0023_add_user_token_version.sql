@@ -0,0 +1,5 @@
+SET client_min_messages TO WARNING;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS token_version INTEGER NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN users.token_version IS 'Версия access токенов пользователя. Увеличивается при смене роли или пароля, токены с прежней версией отклоняются';
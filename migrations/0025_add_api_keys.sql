@@ -0,0 +1,30 @@
+SET client_min_messages TO WARNING;
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(32) NOT NULL UNIQUE,
+    key_hash VARCHAR(64) NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    daily_quota INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE api_keys IS 'Ключи доступа к API для партнеров и внутренних сервисов';
+COMMENT ON COLUMN api_keys.prefix IS 'Открытое начало ключа, по которому его можно узнать';
+COMMENT ON COLUMN api_keys.key_hash IS 'SHA-256 полного ключа, сам ключ не хранится';
+COMMENT ON COLUMN api_keys.scopes IS 'Области доступа ключа, например companies:read, reviews:read';
+COMMENT ON COLUMN api_keys.daily_quota IS 'Лимит запросов в сутки, 0 - без ограничения';
+
+CREATE TABLE IF NOT EXISTS api_key_usage (
+    api_key_id INTEGER NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    requests INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (api_key_id, day)
+);
+
+COMMENT ON TABLE api_key_usage IS 'Количество запросов по API ключу за сутки для контроля квоты';
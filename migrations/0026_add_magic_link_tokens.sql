@@ -0,0 +1,15 @@
+SET client_min_messages TO WARNING;
+
+CREATE TABLE IF NOT EXISTS magic_link_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token VARCHAR(255) NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_magic_link_tokens_user_id ON magic_link_tokens(user_id);
+
+COMMENT ON TABLE magic_link_tokens IS 'Одноразовые токены входа по ссылке из письма';
+COMMENT ON COLUMN magic_link_tokens.token IS 'Значение токена';
+COMMENT ON COLUMN magic_link_tokens.expires_at IS 'Время истечения срока действия токена';
@@ -0,0 +1,17 @@
+SET client_min_messages TO WARNING;
+
+CREATE TABLE IF NOT EXISTS email_change_requests (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    new_email VARCHAR(255) NOT NULL,
+    token VARCHAR(255) NOT NULL UNIQUE,
+    cancel_token VARCHAR(255) NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_change_requests_user_id ON email_change_requests(user_id);
+
+COMMENT ON TABLE email_change_requests IS 'Запросы на смену email, ожидающие подтверждения с нового адреса';
+COMMENT ON COLUMN email_change_requests.token IS 'Токен подтверждения, отправленный на новый адрес';
+COMMENT ON COLUMN email_change_requests.cancel_token IS 'Токен отмены, отправленный на прежний адрес';
@@ -0,0 +1,13 @@
+SET client_min_messages TO WARNING;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_system BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_due_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_users_deletion_due_at ON users(deletion_due_at) WHERE deletion_due_at IS NOT NULL;
+
+COMMENT ON COLUMN users.is_system IS 'Служебный пользователь, под которым нельзя войти';
+COMMENT ON COLUMN users.deletion_due_at IS 'Время, после которого аккаунт будет удален по запросу пользователя';
+
+INSERT INTO users (email, password_hash, first_name, role, is_system, email_verified_at)
+VALUES ('deleted-user@jobsolution.invalid', '!', 'Удаленный пользователь', 'user', TRUE, NOW())
+ON CONFLICT (email) DO UPDATE SET is_system = TRUE;
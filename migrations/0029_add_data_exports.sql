@@ -0,0 +1,28 @@
+SET client_min_messages TO WARNING;
+
+ALTER TABLE suggestions ADD COLUMN IF NOT EXISTS user_id INTEGER REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_suggestions_user_id ON suggestions(user_id);
+
+COMMENT ON COLUMN suggestions.user_id IS 'Автор предложения, если он был авторизован';
+
+CREATE TABLE IF NOT EXISTS data_exports (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    requested_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    token VARCHAR(255) NOT NULL UNIQUE,
+    archive BYTEA,
+    error TEXT,
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_user_id ON data_exports(user_id);
+CREATE INDEX IF NOT EXISTS idx_data_exports_expires_at ON data_exports(expires_at);
+
+COMMENT ON TABLE data_exports IS 'Архивы с персональными данными пользователей, выгружаемые по запросу';
+COMMENT ON COLUMN data_exports.requested_by IS 'Кто запросил выгрузку: сам пользователь или администратор';
+COMMENT ON COLUMN data_exports.token IS 'Токен ссылки на скачивание архива';
+COMMENT ON COLUMN data_exports.expires_at IS 'Время, после которого ссылка на скачивание перестает действовать';
@@ -0,0 +1,24 @@
+SET client_min_messages TO WARNING;
+
+CREATE TABLE IF NOT EXISTS review_revisions (
+    id SERIAL PRIMARY KEY,
+    review_id INTEGER NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    editor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL,
+    content JSONB NOT NULL,
+    changes JSONB NOT NULL DEFAULT '[]',
+    moderation_comment TEXT,
+    moderated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    moderated_at TIMESTAMP,
+    UNIQUE (review_id, revision)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_review_revisions_pending ON review_revisions(review_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_review_revisions_status_created_at ON review_revisions(status, created_at);
+
+COMMENT ON TABLE review_revisions IS 'История правок отзывов их авторами';
+COMMENT ON COLUMN review_revisions.status IS 'applied - правка неодобренного отзыва, pending - правка одобренного отзыва на модерации, approved, rejected, superseded - заменена более новой правкой';
+COMMENT ON COLUMN review_revisions.content IS 'Содержимое отзыва после правки';
+COMMENT ON COLUMN review_revisions.changes IS 'Изменения относительно опубликованной (или текущей неодобренной) версии отзыва';
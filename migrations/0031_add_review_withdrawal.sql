@@ -0,0 +1,5 @@
+SET client_min_messages TO WARNING;
+
+ALTER TYPE review_status ADD VALUE IF NOT EXISTS 'withdrawn';
+
+CREATE INDEX IF NOT EXISTS idx_reviews_user_id_status ON reviews(user_id, status);
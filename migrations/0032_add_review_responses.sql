@@ -0,0 +1,41 @@
+SET client_min_messages TO WARNING;
+
+CREATE TABLE IF NOT EXISTS company_representatives (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    company_id INTEGER NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'verified',
+    verified_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    verified_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    UNIQUE (user_id, company_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_company_representatives_company_id ON company_representatives(company_id);
+
+COMMENT ON TABLE company_representatives IS 'Представители компаний, которым разрешено отвечать на отзывы от имени компании';
+COMMENT ON COLUMN company_representatives.status IS 'verified - подтвержденный представитель, revoked - полномочия отозваны';
+
+CREATE TABLE IF NOT EXISTS review_responses (
+    id SERIAL PRIMARY KEY,
+    review_id INTEGER NOT NULL UNIQUE REFERENCES reviews(id) ON DELETE CASCADE,
+    company_id INTEGER NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    author_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    text TEXT NOT NULL,
+    published_text TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    moderation_comment TEXT,
+    moderated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    moderated_at TIMESTAMP,
+    published_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_responses_status_updated_at ON review_responses(status, updated_at);
+
+COMMENT ON TABLE review_responses IS 'Официальные ответы компаний на отзывы, не более одного на отзыв';
+COMMENT ON COLUMN review_responses.text IS 'Последняя отправленная представителем версия ответа';
+COMMENT ON COLUMN review_responses.published_text IS 'Одобренная модератором версия ответа, которая показывается вместе с отзывом';
+COMMENT ON COLUMN review_responses.status IS 'Статус модерации последней версии: pending, approved, rejected';
@@ -0,0 +1,20 @@
+SET client_min_messages TO WARNING;
+
+ALTER TABLE company_representatives ADD COLUMN IF NOT EXISTS corporate_email VARCHAR(255);
+ALTER TABLE company_representatives ADD COLUMN IF NOT EXISTS code_hash VARCHAR(64);
+ALTER TABLE company_representatives ADD COLUMN IF NOT EXISTS code_expires_at TIMESTAMP;
+ALTER TABLE company_representatives ADD COLUMN IF NOT EXISTS code_attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE company_representatives ADD COLUMN IF NOT EXISTS email_confirmed_at TIMESTAMP;
+ALTER TABLE company_representatives ADD COLUMN IF NOT EXISTS permissions TEXT[] NOT NULL DEFAULT '{reviews:respond,company:update}';
+ALTER TABLE company_representatives ADD COLUMN IF NOT EXISTS comment TEXT;
+ALTER TABLE company_representatives ADD COLUMN IF NOT EXISTS review_comment TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_company_representatives_status_created_at ON company_representatives(status, created_at);
+
+COMMENT ON TABLE company_representatives IS 'Заявки пользователей на представительство компаний и подтвержденные представители';
+COMMENT ON COLUMN company_representatives.status IS 'unconfirmed - корпоративный email не подтвержден, pending - ждет решения администратора, verified - подтвержденный представитель, rejected - заявка отклонена, revoked - полномочия отозваны';
+COMMENT ON COLUMN company_representatives.corporate_email IS 'Корпоративный email в домене сайта компании, подтверждающий связь с ней';
+COMMENT ON COLUMN company_representatives.code_hash IS 'SHA-256 кода подтверждения, отправленного на корпоративный email';
+COMMENT ON COLUMN company_representatives.permissions IS 'Права представителя в рамках компании, например reviews:respond, company:update';
+COMMENT ON COLUMN company_representatives.comment IS 'Комментарий пользователя к заявке';
+COMMENT ON COLUMN company_representatives.review_comment IS 'Комментарий администратора при рассмотрении заявки';
@@ -0,0 +1,26 @@
+SET client_min_messages TO WARNING;
+
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS is_hidden BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS hidden_at TIMESTAMP;
+
+COMMENT ON COLUMN reviews.is_hidden IS 'Отзыв скрыт из публичной выдачи до проверки жалоб модератором';
+
+CREATE TABLE IF NOT EXISTS review_flags (
+    id SERIAL PRIMARY KEY,
+    review_id INTEGER NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    reason VARCHAR(30) NOT NULL,
+    comment TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    resolution_comment TEXT,
+    resolved_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP,
+    UNIQUE (review_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_flags_status_review_id ON review_flags(status, review_id);
+
+COMMENT ON TABLE review_flags IS 'Жалобы читателей на отзывы, не более одной от пользователя на отзыв';
+COMMENT ON COLUMN review_flags.reason IS 'Причина: defamation, personal_data, spam, offensive, false_information, other';
+COMMENT ON COLUMN review_flags.status IS 'open - ждет модератора, dismissed - отклонена, actioned - отзыв снят с публикации';
@@ -0,0 +1,21 @@
+SET client_min_messages TO WARNING;
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS is_suspicious BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN reviews.is_suspicious IS 'Текст отзыва почти совпадает с другими отзывами, отзыв требует ручной модерации';
+
+CREATE INDEX IF NOT EXISTS idx_reviews_text_trgm ON reviews USING GIN ((pros || ' ' || cons) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_reviews_user_company_status ON reviews(user_id, company_id, status);
+
+CREATE TABLE IF NOT EXISTS review_similarities (
+    review_id INTEGER NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    similar_review_id INTEGER NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    score REAL NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (review_id, similar_review_id)
+);
+
+COMMENT ON TABLE review_similarities IS 'Найденные почти дубликаты текста отзывов (триграммное сходство плюсов и минусов)';
+COMMENT ON COLUMN review_similarities.score IS 'Сходство текстов от 0 до 1';
@@ -0,0 +1,5 @@
+SET client_min_messages TO WARNING;
+
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS moderation_trace JSONB;
+
+COMMENT ON COLUMN reviews.moderation_trace IS 'Результат последней автоматической предмодерации отзыва или его правки: решение, суммарный балл и сработавшие правила';
@@ -0,0 +1,19 @@
+SET client_min_messages TO WARNING;
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key VARCHAR(255) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    status_code INTEGER,
+    response_body BYTEA,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP,
+    UNIQUE (user_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_created_at ON idempotency_keys(created_at);
+
+COMMENT ON TABLE idempotency_keys IS 'Ключи идемпотентности запросов (заголовок Idempotency-Key) и сохраненные ответы для повторов';
+COMMENT ON COLUMN idempotency_keys.request_hash IS 'SHA-256 метода, пути и тела запроса: повтор с тем же ключом должен совпадать с исходным запросом';
+COMMENT ON COLUMN idempotency_keys.status_code IS 'Код исходного ответа, NULL - запрос еще обрабатывается';
@@ -0,0 +1,15 @@
+SET client_min_messages TO WARNING;
+
+CREATE TABLE IF NOT EXISTS review_drafts (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    payload JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_drafts_user_updated_at ON review_drafts(user_id, updated_at DESC);
+CREATE INDEX IF NOT EXISTS idx_review_drafts_updated_at ON review_drafts(updated_at);
+
+COMMENT ON TABLE review_drafts IS 'Черновики отзывов, автоматически сохраняемые во время заполнения формы';
+COMMENT ON COLUMN review_drafts.payload IS 'Частично заполненные данные отзыва в формате запроса на создание отзыва';
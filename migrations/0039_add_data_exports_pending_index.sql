@@ -0,0 +1,8 @@
+SET client_min_messages TO WARNING;
+
+UPDATE data_exports
+SET status = 'failed', error = 'сборка архива не завершилась', completed_at = NOW()
+WHERE status = 'pending'
+  AND id NOT IN (SELECT MAX(id) FROM data_exports WHERE status = 'pending' GROUP BY user_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_data_exports_user_pending ON data_exports(user_id) WHERE status = 'pending';